@@ -2,6 +2,9 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
 	"github.com/bwmarrin/discordgo"
 	"libs/go/meowbot/feature/api"
 	"libs/go/meowbot/feature/db"
@@ -9,7 +12,11 @@ import (
 	"libs/go/meowbot/util"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
+	// Guild timezones must resolve in the distroless image.
+	_ "time/tzdata"
 )
 
 func Run(ctx context.Context, cfg util.AppConfig) error {
@@ -20,10 +27,25 @@ func Run(ctx context.Context, cfg util.AppConfig) error {
 
 	// Initialize emojis and DB connection
 	util.InitEmojis()
-	if err := db.InitDB(ctx); err != nil {
+	conn, err := db.Connect(ctx)
+	if err != nil {
 		return err
 	}
-	util.Cfg.Logger.Info("✅ Connected to meowbot PostgreSQL!")
+	util.Cfg.Logger.Info("✅ Connected to the meowbot database!", "driver", cfg.DatabaseDriver)
+
+	// Bring the schema up to date, or make sure someone else did
+	if cfg.AutoMigrate {
+		if err := db.Migrate(ctx, conn); err != nil {
+			return err
+		}
+	} else if err := db.CheckSchema(ctx, conn); err != nil {
+		return err
+	}
+	var store db.Store = db.NewSQLStore(conn)
+	if cfg.RankCacheMaxAge > 0 {
+		store = db.NewRankCache(store, cfg.RankCacheMaxAge)
+	}
+	bot := handler.NewBot(store)
 
 	// Create Discord session
 	sess, err := discordgo.New("Bot " + cfg.BotToken)
@@ -32,16 +54,32 @@ func Run(ctx context.Context, cfg util.AppConfig) error {
 	}
 
 	// Set up intents and handlers
-	sess.Identify.Intents = discordgo.IntentsGuildMessages | discordgo.IntentsMessageContent
-	apiServer := api.New(db.DB, sess)
+	// Message content is a privileged intent that must be enabled for the bot
+	// in the Discord Developer Portal, and so is guild members, which is only
+	// requested when member sync is turned on.
+	sess.Identify.Intents = discordgo.IntentsGuilds | discordgo.IntentsGuildMessages | discordgo.IntentsMessageContent
+	if cfg.MemberSync {
+		sess.Identify.Intents |= discordgo.IntentsGuildMembers
+	}
+	apiServer := api.New(store, sess)
+	apiServer.UserDeleted = bot.ForgetUser
+	apiServer.GuildImported = bot.ForgetGuild
 	apiCtx, apiCancel := context.WithCancel(ctx)
 	defer apiCancel()
 	go apiServer.Start(apiCtx)
 
 	// Add message handlers
-	sess.AddHandler(handler.MessageHandler(ctx))
-	sess.AddHandler(handler.CommandHandler(ctx))
-	sess.AddHandler(handler.ComponentHandler(ctx))
+	registry := bot.NewDefaultRegistry()
+	sess.AddHandler(bot.MessageHandler(ctx))
+	sess.AddHandler(bot.CommandHandler(ctx, registry))
+	sess.AddHandler(bot.ComponentHandler(ctx, registry))
+	sess.AddHandler(bot.GuildCreateHandler(ctx))
+	sess.AddHandler(bot.GuildUpdateHandler(ctx))
+	sess.AddHandler(bot.GuildDeleteHandler(ctx))
+	if cfg.MemberSync {
+		sess.AddHandler(bot.GuildMemberUpdateHandler(ctx))
+		sess.AddHandler(bot.UserUpdateHandler(ctx))
+	}
 
 	// Open Discord session
 	if err := sess.Open(); err != nil {
@@ -55,11 +93,21 @@ func Run(ctx context.Context, cfg util.AppConfig) error {
 		}
 	}()
 
-	// Register commands
-	if err := handler.RegisterCommands(sess); err != nil {
+	// Sync commands
+	if _, err := handler.SyncCommands(sess, registry, cfg.CommandSyncDryRun); err != nil {
 		return err
 	}
 
+	// Close ended seasons and post their recaps
+	seasonsCtx, seasonsCancel := context.WithCancel(ctx)
+	defer seasonsCancel()
+	go bot.RunSeasons(seasonsCtx, sess, time.Minute)
+
+	// Post daily and weekly recaps for guilds that opted in
+	recapsCtx, recapsCancel := context.WithCancel(ctx)
+	defer recapsCancel()
+	go bot.RunRecaps(recapsCtx, sess, time.Minute)
+
 	util.Cfg.Logger.Info("🐱 Meow bot is online!")
 
 	// Wait for termination signal
@@ -68,15 +116,143 @@ func Run(ctx context.Context, cfg util.AppConfig) error {
 	<-stop
 
 	// Graceful shutdown
-	if err := db.CloseDB(); err != nil {
-		return err
+	if err := conn.Close(); err != nil {
+		return fmt.Errorf("failed to close DB connection: %w", err)
 	}
 
 	util.Cfg.Logger.Info("👋 Meow bot has shut down gracefully.")
 	return nil
 }
 
+// runMigrate implements "meowbot migrate [up | down <version> | status]".
+func runMigrate(ctx context.Context, args []string) error {
+	conn, err := db.Connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	action := "up"
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	switch action {
+	case "up":
+		return db.Migrate(ctx, conn)
+	case "down":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: meowbot migrate down <version>")
+		}
+		target, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid version %q: %w", args[1], err)
+		}
+		return db.MigrateDown(ctx, conn, target)
+	case "status":
+		version, err := db.SchemaVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		migrations, err := db.Migrations(conn)
+		if err != nil {
+			return err
+		}
+		util.Cfg.Logger.Info("🗄️ Schema version", "current", version, "latest", len(migrations))
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate action %q, want up, down or status", action)
+	}
+}
+
+// runGuild implements "meowbot guild export <guild-id> [file]" and
+// "meowbot guild import [-dry-run] <file>". Exports go to stdout without a
+// file; imports print their report to stdout. Import while the bot is
+// stopped, or through the API, so the bot doesn't keep the old streak.
+func runGuild(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: meowbot guild export|import ...")
+	}
+
+	conn, err := db.Connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+	if err := db.CheckSchema(ctx, conn); err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "export":
+		if len(args) < 2 || len(args) > 3 {
+			return fmt.Errorf("usage: meowbot guild export <guild-id> [file]")
+		}
+		export, err := db.ExportGuild(ctx, conn, args[1])
+		if err != nil {
+			return err
+		}
+		body, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			return err
+		}
+		if len(args) == 2 {
+			_, err = fmt.Println(string(body))
+			return err
+		}
+		if err := os.WriteFile(args[2], body, 0o600); err != nil {
+			return err
+		}
+		util.Cfg.Logger.Info("📦 Exported guild", "guildID", args[1], "file", args[2], "users", len(export.Users))
+		return nil
+	case "import":
+		flags := flag.NewFlagSet("guild import", flag.ContinueOnError)
+		dryRun := flags.Bool("dry-run", false, "validate the export and report conflicts without importing")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if flags.NArg() != 1 {
+			return fmt.Errorf("usage: meowbot guild import [-dry-run] <file>")
+		}
+		body, err := os.ReadFile(flags.Arg(0))
+		if err != nil {
+			return err
+		}
+		var export db.GuildExport
+		if err := json.Unmarshal(body, &export); err != nil {
+			return fmt.Errorf("invalid guild export %s: %w", flags.Arg(0), err)
+		}
+
+		report, importErr := db.ImportGuild(ctx, conn, &export, *dryRun)
+		if report != nil {
+			out, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+		}
+		return importErr
+	default:
+		return fmt.Errorf("unknown guild action %q, want export or import", args[0])
+	}
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(context.Background(), os.Args[2:]); err != nil {
+			util.Cfg.Logger.Error("❌ Migration failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "guild" {
+		if err := runGuild(context.Background(), os.Args[2:]); err != nil {
+			util.Cfg.Logger.Error("❌ Guild command failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Ensure bot token is available before proceeding
 	if util.Cfg.BotToken == "" {
 		util.Cfg.Logger.Error("❌ DISCORD_BOT_TOKEN not set. Exiting.")
@@ -2,28 +2,31 @@ package api
 
 import (
 	"context"
-	"database/sql"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"github.com/bwmarrin/discordgo"
 	"libs/go/meowbot/util"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"libs/go/meowbot/feature/db"
 )
 
-func New(db *sql.DB, sess *discordgo.Session) *Server {
+func New(store db.Store, sess *discordgo.Session) *Server {
 	return &Server{
 		Logger:  util.Cfg.Logger,
-		DB:      db,
+		Store:   store,
 		Session: sess,
+		Token:   util.Cfg.ApiToken,
 	}
 }
 
-func (s *Server) Start(ctx context.Context) {
+// routes returns the handler serving every endpoint of the API.
+func (s *Server) routes() http.Handler {
 	mux := http.NewServeMux()
 
 	// Health
@@ -37,12 +40,23 @@ func (s *Server) Start(ctx context.Context) {
 	mux.HandleFunc("/leaderboard", s.leaderboardHandler)
 	mux.HandleFunc("/users", s.usersHandler)
 	mux.HandleFunc("/guilds", s.guildsHandler)
+	mux.HandleFunc("/seasons", s.seasonsHandler)
+
+	// Moderation
+	mux.HandleFunc("/bans", s.requireToken(s.bansHandler))
+
+	// Admin
+	mux.HandleFunc("/audit", s.requireToken(s.auditHandler))
+
+	return mux
+}
 
+func (s *Server) Start(ctx context.Context) {
 	addr := ":" + util.Cfg.ApiPort
 
 	srv := &http.Server{
 		Addr:         addr,
-		Handler:      mux,
+		Handler:      s.routes(),
 		ReadTimeout:  5 * time.Second,
 		WriteTimeout: 10 * time.Second,
 	}
@@ -68,7 +82,7 @@ func (s *Server) Start(ctx context.Context) {
 func (s *Server) livenessHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	dbHealthy := s.DB.PingContext(ctx) == nil
+	dbHealthy := s.Store.Ping(ctx) == nil
 	discordHealthy := s.Session != nil &&
 		s.Session.State != nil &&
 		s.Session.State.User != nil &&
@@ -99,7 +113,7 @@ func (s *Server) livenessHandler(w http.ResponseWriter, r *http.Request) {
 func (s *Server) readinessHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	dbHealthy := s.DB.PingContext(ctx) == nil
+	dbHealthy := s.Store.Ping(ctx) == nil
 	discordHealthy := s.Session != nil &&
 		s.Session.State != nil &&
 		s.Session.State.User != nil &&
@@ -129,10 +143,9 @@ func (s *Server) readinessHandler(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) statsHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	stats, err := db.GetGlobalStats(ctx, s.DB)
+	stats, err := s.Store.GetGlobalStats(ctx)
 	if err != nil {
-		s.Logger.Error("❌ Failed to fetch stats", "error", err)
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		s.writeError(w, dbErrorStatus(err), "failed to fetch stats", err)
 		return
 	}
 
@@ -142,72 +155,372 @@ func (s *Server) statsHandler(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) guildStatsRouter(w http.ResponseWriter, r *http.Request) {
 	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
-	if len(parts) != 3 || parts[0] != "guilds" || parts[2] != "stats" {
+	switch {
+	case len(parts) == 2 && parts[0] == "guilds":
+		s.guildHandler(w, r, parts[1])
+	case len(parts) == 3 && parts[0] == "guilds" && parts[2] == "stats":
+		s.guildStatsHandler(w, r, parts[1])
+	case len(parts) == 3 && parts[0] == "guilds" && parts[2] == "export":
+		s.requireToken(func(w http.ResponseWriter, r *http.Request) {
+			s.guildExportHandler(w, r, parts[1])
+		})(w, r)
+	case len(parts) == 3 && parts[0] == "guilds" && parts[2] == "import":
+		s.requireToken(func(w http.ResponseWriter, r *http.Request) {
+			s.guildImportHandler(w, r, parts[1])
+		})(w, r)
+	default:
 		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) guildHandler(w http.ResponseWriter, r *http.Request, guildID string) {
+	guild, err := s.Store.GetGuild(r.Context(), guildID)
+	if err != nil {
+		s.writeDBError(w, "guild not found", err)
 		return
 	}
-	s.guildStatsHandler(w, r, parts[1])
+
+	s.writeJSON(w, guild)
 }
 
 func (s *Server) guildStatsHandler(w http.ResponseWriter, r *http.Request, guildID string) {
 	ctx := r.Context()
-	streak, err := db.GetGuildStats(ctx, s.DB, guildID)
+	streak, err := s.Store.GetGuildStats(ctx, guildID)
 	if err != nil {
-		s.writeError(w, http.StatusNotFound, "guild not found", err)
+		s.writeDBError(w, "guild not found", err)
 		return
 	}
 
 	s.writeJSON(w, streak)
 }
 
+// guildExportHandler serves everything tied to a guild as a versioned
+// export that guildImportHandler accepts.
+func (s *Server) guildExportHandler(w http.ResponseWriter, r *http.Request, guildID string) {
+	export, err := s.Store.ExportGuild(r.Context(), guildID)
+	if err != nil {
+		s.writeDBError(w, "guild not found", err)
+		return
+	}
+	s.writeJSON(w, export)
+}
+
+// maxImportSize caps the body of a guild import.
+const maxImportSize = 32 << 20
+
+// Audited actions taken through the API, which is their actor.
+const (
+	auditActor       = "api"
+	auditGuildImport = "guild.import"
+	auditUserDelete  = "user.delete"
+)
+
+// recordAudit stores an action taken through the API in the audit log.
+func (s *Server) recordAudit(ctx context.Context, guildID, action, target, before, after string) {
+	entry := db.AuditEntry{
+		GuildID:   guildID,
+		ActorID:   auditActor,
+		Action:    action,
+		Target:    target,
+		Before:    before,
+		After:     after,
+		CreatedAt: time.Now(),
+	}
+	if err := s.Store.RecordAudit(ctx, entry); err != nil {
+		s.Logger.Error("❌ Failed to record audit entry", slog.String("guild_id", guildID), slog.String("action", action), slog.Any("error", err))
+	}
+}
+
+// guildImportHandler replaces a guild's data with the posted export, or only
+// checks it with ?dry_run=true. The report lists what was, or would be,
+// replaced, and why an invalid export was rejected.
+func (s *Server) guildImportHandler(w http.ResponseWriter, r *http.Request, guildID string) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		s.writeError(w, http.StatusMethodNotAllowed, "method not allowed", errors.New("unsupported method "+r.Method))
+		return
+	}
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+
+	var export db.GuildExport
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxImportSize)).Decode(&export); err != nil {
+		s.writeError(w, http.StatusBadRequest, "invalid guild export", err)
+		return
+	}
+	if export.Guild.ID != guildID {
+		s.writeError(w, http.StatusBadRequest, "export is for another guild", errors.New("guild ID mismatch: "+export.Guild.ID))
+		return
+	}
+
+	report, err := s.Store.ImportGuild(r.Context(), &export, dryRun)
+	if errors.Is(err, db.ErrInvalidInput) && report != nil {
+		s.Logger.Warn("⚠ Rejected guild import", slog.String("guild_id", guildID), slog.Any("problems", report.Problems))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(report)
+		return
+	}
+	if err != nil {
+		s.writeError(w, dbErrorStatus(err), "failed to import guild", err)
+		return
+	}
+	if !dryRun {
+		// The entry keeps the rows the import replaced and when the export
+		// was made.
+		var replaced string
+		if b, err := json.Marshal(report.Conflicts); err == nil && report.Conflicts != nil {
+			replaced = string(b)
+		}
+		s.recordAudit(r.Context(), guildID, auditGuildImport, "", replaced, export.ExportedAt.UTC().Format(time.RFC3339))
+		if s.GuildImported != nil {
+			s.GuildImported(guildID)
+		}
+		s.Logger.Info("📥 Imported guild data", slog.String("guild_id", guildID))
+	}
+	s.writeJSON(w, report)
+}
+
 func (s *Server) userStatsRouter(w http.ResponseWriter, r *http.Request) {
 	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
-	if len(parts) != 3 || parts[0] != "users" || parts[2] != "stats" {
+	switch {
+	case len(parts) == 3 && parts[0] == "users" && parts[2] == "stats":
+		s.userStatsHandler(w, r, parts[1])
+	case len(parts) == 3 && parts[0] == "users" && parts[2] == "data":
+		s.requireToken(func(w http.ResponseWriter, r *http.Request) {
+			s.userDataHandler(w, r, parts[1])
+		})(w, r)
+	default:
 		http.NotFound(w, r)
-		return
 	}
-	s.userStatsHandler(w, r, parts[1])
 }
 
 func (s *Server) userStatsHandler(w http.ResponseWriter, r *http.Request, userID string) {
-	stats, err := db.GetUserGlobalStats(r.Context(), s.DB, userID)
+	stats, err := s.Store.GetUserGlobalStats(r.Context(), userID)
 	if err != nil {
-		s.writeError(w, http.StatusNotFound, "user not found", err)
+		s.writeDBError(w, "user not found", err)
 		return
 	}
 
-	perGuildStats, err := db.GetUserPerGuildStats(r.Context(), s.DB, userID)
+	perGuildStats, err := s.Store.GetUserPerGuildStats(r.Context(), userID)
 	if err != nil {
 		s.Logger.Warn("⚠ Failed to fetch per-guild stats", slog.String("user_id", userID), slog.Any("error", err))
 	}
 	stats.GuildStats = perGuildStats
 
+	achievements, err := s.Store.GetUserAchievements(r.Context(), userID, nil)
+	if err != nil {
+		s.Logger.Warn("⚠ Failed to fetch achievements", slog.String("user_id", userID), slog.Any("error", err))
+	}
+	stats.Achievements = achievements
+
 	s.writeJSON(w, stats)
 }
 
+// userDataHandler exports (GET) or deletes (DELETE) everything stored about
+// a user, for operators handling privacy requests.
+func (s *Server) userDataHandler(w http.ResponseWriter, r *http.Request, userID string) {
+	switch r.Method {
+	case http.MethodGet:
+		data, err := s.Store.ExportUserData(r.Context(), userID)
+		if err != nil {
+			s.writeDBError(w, "user not found", err)
+			return
+		}
+		s.writeJSON(w, data)
+	case http.MethodDelete:
+		anonID, err := s.Store.DeleteUserData(r.Context(), userID)
+		if err != nil {
+			s.writeDBError(w, "user not found", err)
+			return
+		}
+		// The entry names the user by their anonymous ID only, so the log
+		// doesn't keep the ID that was erased.
+		s.recordAudit(r.Context(), "", auditUserDelete, "<@"+anonID+">", "", "")
+		if s.UserDeleted != nil {
+			s.UserDeleted(userID, anonID)
+		}
+		s.Logger.Info("🗑️ Deleted user data", slog.String("user_id", userID))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, DELETE")
+		s.writeError(w, http.StatusMethodNotAllowed, "method not allowed", errors.New("unsupported method "+r.Method))
+	}
+}
+
 func (s *Server) leaderboardHandler(w http.ResponseWriter, r *http.Request) {
-	entries, err := db.GetLeaderboard3(r.Context(), s.DB, 10)
+	if param := r.URL.Query().Get("season"); param != "" {
+		s.seasonLeaderboardHandler(w, r, param)
+		return
+	}
+
+	entries, err := s.Store.GetLeaderboard3(r.Context(), 10)
 	if err != nil {
-		s.writeError(w, http.StatusInternalServerError, "internal error", err)
+		s.writeError(w, dbErrorStatus(err), "internal error", err)
 		return
 	}
 
 	s.writeJSON(w, entries)
 }
 
+// seasonLeaderboardHandler serves the top 10 of a season. The season is
+// "current" (the one running for the optional guild_id, or the global one)
+// or a season ID.
+func (s *Server) seasonLeaderboardHandler(w http.ResponseWriter, r *http.Request, param string) {
+	ctx := r.Context()
+
+	var season *db.Season
+	if param == "current" {
+		var guildID *string
+		if id := r.URL.Query().Get("guild_id"); id != "" {
+			guildID = &id
+		}
+		active, err := s.Store.GetActiveSeason(ctx, guildID, time.Now())
+		if err != nil {
+			s.writeError(w, dbErrorStatus(err), "failed to fetch season", err)
+			return
+		}
+		if active == nil {
+			s.writeError(w, http.StatusNotFound, "no active season", nil)
+			return
+		}
+		season = active
+	} else {
+		id, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			s.writeError(w, http.StatusBadRequest, "invalid season", err)
+			return
+		}
+		season, err = s.Store.GetSeason(ctx, id)
+		if err != nil {
+			s.writeDBError(w, "season not found", err)
+			return
+		}
+	}
+
+	top, err := s.Store.GetSeasonLeaderboard(ctx, season, db.LeaderboardQuery{Metric: db.MetricTotal, Limit: 10})
+	if err != nil {
+		s.writeError(w, dbErrorStatus(err), "internal error", err)
+		return
+	}
+
+	s.writeJSON(w, SeasonLeaderboardResponse{Season: season, Entries: top.Entries})
+}
+
+// seasonsHandler lists global seasons, plus the guild's own with ?guild_id=.
+func (s *Server) seasonsHandler(w http.ResponseWriter, r *http.Request) {
+	var guildID *string
+	if id := r.URL.Query().Get("guild_id"); id != "" {
+		guildID = &id
+	}
+
+	seasons, err := s.Store.ListSeasons(r.Context(), guildID)
+	if err != nil {
+		s.writeError(w, dbErrorStatus(err), "failed to fetch seasons", err)
+		return
+	}
+	s.writeJSON(w, seasons)
+}
+
+func (s *Server) bansHandler(w http.ResponseWriter, r *http.Request) {
+	var guildID *string
+	if id := r.URL.Query().Get("guild_id"); id != "" {
+		guildID = &id
+	}
+
+	bans, err := s.Store.ListBans(r.Context(), guildID, time.Now())
+	if err != nil {
+		s.writeError(w, dbErrorStatus(err), "failed to fetch bans", err)
+		return
+	}
+	s.writeJSON(w, bans)
+}
+
+// requireToken only lets requests carrying the admin bearer token through.
+func (s *Server) requireToken(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.Token == "" {
+			s.writeError(w, http.StatusServiceUnavailable, "admin endpoints are disabled", errors.New("API_TOKEN not set"))
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			s.writeError(w, http.StatusUnauthorized, "unauthorized", errors.New("missing or invalid bearer token"))
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) auditHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := db.AuditFilter{
+		GuildID: q.Get("guild_id"),
+		ActorID: q.Get("actor_id"),
+	}
+
+	var err error
+	if filter.Since, err = parseAuditTime(q.Get("since"), false); err != nil {
+		s.writeError(w, http.StatusBadRequest, "invalid since", err)
+		return
+	}
+	if filter.Until, err = parseAuditTime(q.Get("until"), true); err != nil {
+		s.writeError(w, http.StatusBadRequest, "invalid until", err)
+		return
+	}
+	if limit := q.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit < 1 || filter.Limit > 500 {
+			s.writeError(w, http.StatusBadRequest, "limit must be between 1 and 500", err)
+			return
+		}
+	}
+	if offset := q.Get("offset"); offset != "" {
+		if filter.Offset, err = strconv.Atoi(offset); err != nil || filter.Offset < 0 {
+			s.writeError(w, http.StatusBadRequest, "invalid offset", err)
+			return
+		}
+	}
+
+	entries, err := s.Store.GetAuditLog(r.Context(), filter)
+	if err != nil {
+		s.writeError(w, dbErrorStatus(err), "failed to fetch audit log", err)
+		return
+	}
+	s.writeJSON(w, entries)
+}
+
+// parseAuditTime parses an RFC 3339 timestamp or a YYYY-MM-DD date (UTC).
+// A date used as an end bound covers the whole day.
+func parseAuditTime(value string, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
 func (s *Server) usersHandler(w http.ResponseWriter, r *http.Request) {
-	users, err := db.GetAllUsers(r.Context(), s.DB)
+	users, err := s.Store.GetAllUsers(r.Context())
 	if err != nil {
-		s.writeError(w, http.StatusInternalServerError, "failed to fetch users", err)
+		s.writeError(w, dbErrorStatus(err), "failed to fetch users", err)
 		return
 	}
 	s.writeJSON(w, users)
 }
 
 func (s *Server) guildsHandler(w http.ResponseWriter, r *http.Request) {
-	guilds, err := db.GetAllGuilds(r.Context(), s.DB)
+	guilds, err := s.Store.GetAllGuilds(r.Context())
 	if err != nil {
-		s.writeError(w, http.StatusInternalServerError, "failed to fetch guilds", err)
+		s.writeError(w, dbErrorStatus(err), "failed to fetch guilds", err)
 		return
 	}
 	s.writeJSON(w, guilds)
@@ -221,11 +534,39 @@ func (s *Server) writeJSON(w http.ResponseWriter, data any) {
 	}
 }
 
+// writeError logs err under a fresh incident ID and writes msg and that ID
+// to the client. The raw error is never sent.
 func (s *Server) writeError(w http.ResponseWriter, status int, msg string, err error) {
-	s.Logger.Error("❌ "+msg, "error", err)
+	incidentID := util.NewCorrelationID()
+	s.Logger.Error("❌ "+msg, "incidentID", incidentID, "status", status, "error", err)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
-	if encodeErr := json.NewEncoder(w).Encode(map[string]string{"error": msg}); encodeErr != nil {
+	if encodeErr := json.NewEncoder(w).Encode(ErrorResponse{Error: msg, IncidentID: incidentID}); encodeErr != nil {
 		s.Logger.Error("❌ Failed to write error JSON", "error", encodeErr)
 	}
 }
+
+// writeDBError writes a db error for a lookup of a single resource, using
+// notFoundMsg when the resource doesn't exist.
+func (s *Server) writeDBError(w http.ResponseWriter, notFoundMsg string, err error) {
+	status := dbErrorStatus(err)
+	msg := http.StatusText(status)
+	if status == http.StatusNotFound {
+		msg = notFoundMsg
+	}
+	s.writeError(w, status, strings.ToLower(msg), err)
+}
+
+// dbErrorStatus maps the db package's error kinds to HTTP statuses.
+func dbErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, db.ErrUnavailable):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, db.ErrInvalidInput):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
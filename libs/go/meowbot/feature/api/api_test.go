@@ -0,0 +1,391 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"libs/go/meowbot/feature/db"
+)
+
+const testToken = "secret"
+
+// newTestServer returns a Server on an empty in-memory store whose admin
+// endpoints take testToken.
+func newTestServer(t *testing.T) (*Server, db.Store) {
+	t.Helper()
+	store := db.NewMemoryStore()
+	return &Server{Logger: slog.New(slog.DiscardHandler), Store: store, Token: testToken}, store
+}
+
+// serve sends the request to the server's routes, with the bearer token if
+// token isn't empty.
+func serve(s *Server, method, target, token string, body io.Reader) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, body)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	s.routes().ServeHTTP(rec, req)
+	return rec
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.NewDecoder(rec.Body).Decode(&v); err != nil {
+		t.Fatalf("failed to decode response %q: %v", rec.Body.String(), err)
+	}
+	return v
+}
+
+func TestRequireToken(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{"missing", "", http.StatusUnauthorized},
+		{"wrong", "Bearer nope", http.StatusUnauthorized},
+		{"not bearer", "Basic " + testToken, http.StatusUnauthorized},
+		{"valid", "Bearer " + testToken, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, target := range []string{"/audit", "/bans"} {
+				req := httptest.NewRequest(http.MethodGet, target, nil)
+				if tt.header != "" {
+					req.Header.Set("Authorization", tt.header)
+				}
+				rec := httptest.NewRecorder()
+				s.routes().ServeHTTP(rec, req)
+
+				if rec.Code != tt.want {
+					t.Errorf("GET %s status = %d, want %d", target, rec.Code, tt.want)
+				}
+				if tt.want == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") != "Bearer" {
+					t.Errorf("GET %s WWW-Authenticate = %q, want Bearer", target, rec.Header().Get("WWW-Authenticate"))
+				}
+			}
+		})
+	}
+}
+
+func TestRequireToken_Disabled(t *testing.T) {
+	s, _ := newTestServer(t)
+	s.Token = ""
+
+	for _, token := range []string{"", testToken} {
+		rec := serve(s, http.MethodGet, "/audit", token, nil)
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Errorf("status = %d, want %d while API_TOKEN is unset", rec.Code, http.StatusServiceUnavailable)
+		}
+		if resp := decode[ErrorResponse](t, rec); resp.IncidentID == "" {
+			t.Error("error response has no incident ID")
+		}
+	}
+}
+
+func TestAuditHandler(t *testing.T) {
+	s, store := newTestServer(t)
+	ctx := context.Background()
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	entries := []db.AuditEntry{
+		{GuildID: "g1", ActorID: "alice", Action: "channel", CreatedAt: base},
+		{GuildID: "g1", ActorID: "bob", Action: "locale", CreatedAt: base.Add(time.Hour)},
+		{GuildID: "g1", ActorID: "alice", Action: "ban", CreatedAt: base.AddDate(0, 0, 1)},
+		{GuildID: "g2", ActorID: "alice", Action: "season", CreatedAt: base.AddDate(0, 0, 2)},
+	}
+	for _, e := range entries {
+		if err := store.RecordAudit(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"season", "ban", "locale", "channel"}},
+		{"?guild_id=g1", []string{"ban", "locale", "channel"}},
+		{"?guild_id=g1&actor_id=alice", []string{"ban", "channel"}},
+		{"?since=2024-05-02", []string{"season", "ban"}},
+		{"?until=2024-05-01", []string{"locale", "channel"}},
+		{"?since=2024-05-01T12:30:00Z&until=2024-05-02T12:00:00Z", []string{"locale"}},
+		{"?limit=2", []string{"season", "ban"}},
+		{"?limit=2&offset=2", []string{"locale", "channel"}},
+		{"?offset=10", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rec := serve(s, http.MethodGet, "/audit"+tt.query, testToken, nil)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			got := []string{}
+			for _, e := range decode[[]db.AuditEntry](t, rec) {
+				got = append(got, e.Action)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("actions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditHandler_BadParams(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	for _, query := range []string{"?since=yesterday", "?until=2024-13-01", "?limit=0", "?limit=501", "?limit=ten", "?offset=-1"} {
+		t.Run(query, func(t *testing.T) {
+			if rec := serve(s, http.MethodGet, "/audit"+query, testToken, nil); rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+// seedGuild gives guild g1 a meow channel and a player.
+func seedGuild(t *testing.T, store db.Store) {
+	t.Helper()
+	ctx := context.Background()
+	if err := store.UpsertGuild(ctx, db.Guild{ID: "g1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.UpsertUser(ctx, db.User{ID: "alice", Username: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.UpsertGuildChannel(ctx, "g1", "c1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.IncrementMeow(ctx, "g1", "alice", true, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGuildImportHandler(t *testing.T) {
+	ctx := context.Background()
+	s, store := newTestServer(t)
+	seedGuild(t, store)
+	var imported []string
+	s.GuildImported = func(guildID string) { imported = append(imported, guildID) }
+
+	export, err := store.ExportGuild(ctx, "g1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := json.Marshal(export)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Changed after the export, so an import puts c1 back.
+	if err := store.UpsertGuildChannel(ctx, "g1", "c2"); err != nil {
+		t.Fatal(err)
+	}
+	channel := func() string {
+		t.Helper()
+		channelID, err := store.GetChannelForGuild(ctx, "g1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return channelID
+	}
+
+	if rec := serve(s, http.MethodPost, "/guilds/g1/import", "", bytes.NewReader(body)); rec.Code != http.StatusUnauthorized {
+		t.Errorf("import without token status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	rec := serve(s, http.MethodPost, "/guilds/g1/import?dry_run=true", testToken, bytes.NewReader(body))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("dry run status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	report := decode[db.ImportReport](t, rec)
+	if !report.DryRun || report.Stats != 1 || len(report.Conflicts) == 0 {
+		t.Errorf("dry run report = %+v, want a dry run of 1 stats row with conflicts", report)
+	}
+	if got := channel(); got != "c2" {
+		t.Errorf("channel after dry run = %q, want c2 unchanged", got)
+	}
+	if len(imported) != 0 {
+		t.Errorf("GuildImported called for a dry run: %v", imported)
+	}
+	if entries, err := store.GetAuditLog(ctx, db.AuditFilter{ActorID: auditActor}); err != nil || len(entries) != 0 {
+		t.Errorf("audit entries after dry run = %+v, %v, want none", entries, err)
+	}
+
+	rec = serve(s, http.MethodPost, "/guilds/g1/import", testToken, bytes.NewReader(body))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("import status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	if report := decode[db.ImportReport](t, rec); report.DryRun {
+		t.Errorf("import report = %+v, want no dry run", report)
+	}
+	if got := channel(); got != "c1" {
+		t.Errorf("channel after import = %q, want c1 from the export", got)
+	}
+	if !slices.Equal(imported, []string{"g1"}) {
+		t.Errorf("GuildImported calls = %v, want [g1]", imported)
+	}
+	entries, err := store.GetAuditLog(ctx, db.AuditFilter{ActorID: auditActor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].GuildID != "g1" || entries[0].Action != auditGuildImport ||
+		!strings.Contains(entries[0].Before, "guild_channels") || entries[0].After != export.ExportedAt.Format(time.RFC3339) {
+		t.Errorf("audit entries after import = %+v, want one import of g1 replacing the channel", entries)
+	}
+}
+
+func TestGuildImportHandler_Rejected(t *testing.T) {
+	ctx := context.Background()
+	s, store := newTestServer(t)
+	seedGuild(t, store)
+
+	tests := []struct {
+		name        string
+		edit        func(export *db.GuildExport)
+		wantProblem string
+	}{
+		{
+			name:        "version mismatch",
+			edit:        func(export *db.GuildExport) { export.Version = db.GuildExportVersion + 1 },
+			wantProblem: "unsupported export version",
+		},
+		{
+			name:        "invalid rows",
+			edit:        func(export *db.GuildExport) { export.Settings.Timezone = "Mars/Olympus_Mons" },
+			wantProblem: "unknown timezone",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			export, err := store.ExportGuild(ctx, "g1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			export.ChannelID = "c9"
+			tt.edit(export)
+			body, err := json.Marshal(export)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, target := range []string{"/guilds/g1/import?dry_run=true", "/guilds/g1/import"} {
+				rec := serve(s, http.MethodPost, target, testToken, bytes.NewReader(body))
+				if rec.Code != http.StatusUnprocessableEntity {
+					t.Fatalf("POST %s status = %d, want %d: %s", target, rec.Code, http.StatusUnprocessableEntity, rec.Body)
+				}
+				report := decode[db.ImportReport](t, rec)
+				if !slices.ContainsFunc(report.Problems, func(p string) bool { return strings.Contains(p, tt.wantProblem) }) {
+					t.Errorf("POST %s problems = %v, want one about %q", target, report.Problems, tt.wantProblem)
+				}
+			}
+			if channelID, _ := store.GetChannelForGuild(ctx, "g1"); channelID != "c1" {
+				t.Errorf("channel = %q, want c1: a rejected import changes nothing", channelID)
+			}
+		})
+	}
+}
+
+func TestGuildImportHandler_BadRequest(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"not JSON", "{"},
+		{"other guild", `{"version": 1, "guild": {"id": "g2"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(s, http.MethodPost, "/guilds/g1/import", testToken, strings.NewReader(tt.body))
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestUserDataHandler(t *testing.T) {
+	ctx := context.Background()
+	s, store := newTestServer(t)
+	seedGuild(t, store)
+	if err := store.UpsertGuildMember(ctx, db.GuildMember{GuildID: "g1", UserID: "alice", Nickname: "Al", UpdatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.AddTreats(ctx, "g1", "alice", 10, "meow", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	var deleted []string
+	s.UserDeleted = func(userID, anonID string) { deleted = append(deleted, userID, anonID) }
+
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		if rec := serve(s, method, "/users/alice/data", "", nil); rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s without token status = %d, want %d", method, rec.Code, http.StatusUnauthorized)
+		}
+		if rec := serve(s, method, "/users/alice/data", "nope", nil); rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s with wrong token status = %d, want %d", method, rec.Code, http.StatusUnauthorized)
+		}
+	}
+	if len(deleted) != 0 {
+		t.Fatalf("UserDeleted called without the token: %v", deleted)
+	}
+
+	rec := serve(s, http.MethodGet, "/users/alice/data", testToken, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	data := decode[db.UserData](t, rec)
+	for _, table := range []string{"users", "user_guild_stats", "guild_members", "treat_balances"} {
+		if len(data.Tables[table]) == 0 {
+			t.Errorf("export has no %s rows before the delete", table)
+		}
+	}
+
+	if rec := serve(s, http.MethodDelete, "/users/alice/data", testToken, nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("DELETE status = %d, want %d: %s", rec.Code, http.StatusNoContent, rec.Body)
+	}
+	if len(deleted) != 2 || deleted[0] != "alice" || deleted[1] == "" || deleted[1] == "alice" {
+		t.Errorf("UserDeleted calls = %v, want alice and the anonymous ID", deleted)
+	}
+
+	if rec := serve(s, http.MethodGet, "/users/alice/data", testToken, nil); rec.Code != http.StatusNotFound {
+		t.Errorf("GET after delete status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	entries, err := store.GetAuditLog(ctx, db.AuditFilter{ActorID: auditActor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Action != auditUserDelete || entries[0].Target != "<@"+deleted[1]+">" {
+		t.Errorf("audit entries after delete = %+v, want one delete of the anonymous ID", entries)
+	}
+	if _, err := store.ExportUserData(ctx, "alice"); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("ExportUserData() after delete error = %v, want ErrNotFound", err)
+	}
+	if member, err := store.GetGuildMember(ctx, "g1", "alice"); err != nil || member != nil {
+		t.Errorf("GetGuildMember() after delete = %+v, %v, want no member", member, err)
+	}
+	if balance, err := store.GetTreatBalance(ctx, "g1", "alice"); err != nil || balance != 0 {
+		t.Errorf("GetTreatBalance() after delete = %d, %v, want 0", balance, err)
+	}
+	// The guild's counts stay, under the anonymous ID.
+	if stats, err := store.GetUserGlobalStats(ctx, deleted[1]); err != nil || stats.TotalMeows != 1 {
+		t.Errorf("anonymous stats = %+v, %v, want the 1 meow kept", stats, err)
+	}
+
+	if rec := serve(s, http.MethodDelete, "/users/alice/data", testToken, nil); rec.Code != http.StatusNotFound {
+		t.Errorf("second DELETE status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
@@ -1,15 +1,24 @@
 package api
 
 import (
-	"database/sql"
 	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
 	"log/slog"
 )
 
 type Server struct {
 	Logger  *slog.Logger
-	DB      *sql.DB
+	Store   db.Store
 	Session *discordgo.Session
+	// Token is the bearer token admin endpoints require. Admin endpoints are
+	// disabled if it is empty.
+	Token string
+	// UserDeleted, if set, is called after a user's data is deleted through
+	// the API so the bot can drop the user from its in-memory state.
+	UserDeleted func(userID, anonID string)
+	// GuildImported, if set, is called after a guild's data is imported
+	// through the API so the bot reloads the guild's in-memory state.
+	GuildImported func(guildID string)
 }
 
 type HealthResponse struct {
@@ -17,3 +26,13 @@ type HealthResponse struct {
 	Database string `json:"database"`
 	Discord  string `json:"discord"`
 }
+
+type ErrorResponse struct {
+	Error      string `json:"error"`
+	IncidentID string `json:"incident_id"`
+}
+
+type SeasonLeaderboardResponse struct {
+	Season  *db.Season            `json:"season"`
+	Entries []db.LeaderboardEntry `json:"entries"`
+}
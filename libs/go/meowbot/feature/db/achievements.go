@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// UnlockAchievement records that the user unlocked an achievement in a guild.
+// It reports false if the user had already unlocked it there.
+func UnlockAchievement(ctx context.Context, db *sql.DB, guildID, userID, achievementID string, at time.Time) (bool, error) {
+	defer trace(ctx, "UnlockAchievement")()
+
+	query := `
+		INSERT INTO user_achievements (guild_id, user_id, achievement_id, unlocked_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (guild_id, user_id, achievement_id) DO NOTHING;
+	`
+
+	res, err := db.ExecContext(ctx, query, guildID, userID, achievementID, at)
+	if err != nil {
+		return false, classify(fmt.Errorf("failed to unlock achievement: %w", err))
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, classify(err)
+	}
+	return n > 0, nil
+}
+
+// GetUserAchievements lists the user's achievements, oldest first. With a nil
+// guildID every achievement is listed once, dated by its first unlock.
+func GetUserAchievements(ctx context.Context, db *sql.DB, userID string, guildID *string) ([]UserAchievement, error) {
+	defer trace(ctx, "GetUserAchievements")()
+
+	var (
+		query string
+		args  []any
+	)
+
+	if guildID != nil {
+		query = `
+			SELECT guild_id, user_id, achievement_id, unlocked_at
+			FROM user_achievements
+			WHERE guild_id = $1 AND user_id = $2
+			ORDER BY unlocked_at, achievement_id
+		`
+		args = []any{*guildID, userID}
+	} else {
+		query = `
+			SELECT '' AS guild_id, user_id, achievement_id, MIN(unlocked_at) AS unlocked_at
+			FROM user_achievements
+			WHERE user_id = $1
+			GROUP BY user_id, achievement_id
+			ORDER BY unlocked_at, achievement_id
+		`
+		args = []any{userID}
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to get user achievements: %w", err))
+	}
+	defer rows.Close()
+
+	var achievements []UserAchievement
+	for rows.Next() {
+		var a UserAchievement
+		var unlockedAt *time.Time
+		if err := rows.Scan(&a.GuildID, &a.UserID, &a.AchievementID, nullTime{&unlockedAt}); err != nil {
+			return nil, classify(err)
+		}
+		if unlockedAt != nil {
+			a.UnlockedAt = *unlockedAt
+		}
+		achievements = append(achievements, a)
+	}
+	return achievements, classify(rows.Err())
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnlockAchievement(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	now := time.Now()
+	query := regexp.QuoteMeta(`INSERT INTO user_achievements (guild_id, user_id, achievement_id, unlocked_at)`)
+	mock.ExpectExec(query).
+		WithArgs("guild-foo", "user-1", "first_meow", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).
+		WithArgs("guild-foo", "user-1", "first_meow", now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	unlocked, err := UnlockAchievement(context.Background(), mockDB, "guild-foo", "user-1", "first_meow", now)
+	require.NoError(t, err)
+	require.True(t, unlocked)
+
+	unlocked, err = UnlockAchievement(context.Background(), mockDB, "guild-foo", "user-1", "first_meow", now)
+	require.NoError(t, err)
+	require.False(t, unlocked, "already unlocked achievements must not be reported again")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUserAchievements_Global(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"guild_id", "user_id", "achievement_id", "unlocked_at"}).
+		AddRow("", "user-1", "first_meow", now.Add(-time.Hour)).
+		AddRow("", "user-1", "night_owl", now)
+	mock.ExpectQuery(regexp.QuoteMeta(`GROUP BY user_id, achievement_id`)).
+		WithArgs("user-1").
+		WillReturnRows(rows)
+
+	achievements, err := GetUserAchievements(context.Background(), mockDB, "user-1", nil)
+	require.NoError(t, err)
+	require.Len(t, achievements, 2)
+	require.Equal(t, "first_meow", achievements[0].AchievementID)
+	require.Equal(t, "night_owl", achievements[1].AchievementID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+const defaultAuditLimit = 50
+
+func RecordAudit(ctx context.Context, db *sql.DB, entry AuditEntry) error {
+	defer trace(ctx, "RecordAudit")()
+
+	query := `
+		INSERT INTO audit_log (guild_id, actor_id, action, target, before_value, after_value, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), $7);
+	`
+
+	_, err := db.ExecContext(ctx, query, entry.GuildID, entry.ActorID, entry.Action, entry.Target, entry.Before, entry.After, entry.CreatedAt)
+	if err != nil {
+		return classify(fmt.Errorf("failed to record audit entry: %w", err))
+	}
+	return nil
+}
+
+// GetAuditLog returns audit entries matching filter, newest first. Since is
+// inclusive and Until exclusive.
+func GetAuditLog(ctx context.Context, db *sql.DB, filter AuditFilter) ([]AuditEntry, error) {
+	defer trace(ctx, "GetAuditLog")()
+
+	var (
+		conditions []string
+		args       []any
+	)
+	where := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.GuildID != "" {
+		where("guild_id = $%d", filter.GuildID)
+	}
+	if filter.ActorID != "" {
+		where("actor_id = $%d", filter.ActorID)
+	}
+	if !filter.Since.IsZero() {
+		where("created_at >= $%d", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		where("created_at < $%d", filter.Until)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+
+	query := `
+		SELECT id, guild_id, actor_id, action, COALESCE(target, ''), COALESCE(before_value, ''), COALESCE(after_value, ''), created_at
+		FROM audit_log
+	`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT %d OFFSET %d", limit, max(filter.Offset, 0))
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to get audit log: %w", err))
+	}
+	defer rows.Close()
+
+	entries := []AuditEntry{}
+	for rows.Next() {
+		var e AuditEntry
+		if err := rows.Scan(&e.ID, &e.GuildID, &e.ActorID, &e.Action, &e.Target, &e.Before, &e.After, &e.CreatedAt); err != nil {
+			return nil, classify(err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, classify(rows.Err())
+}
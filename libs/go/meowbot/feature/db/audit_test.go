@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAuditLog_Filters(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	since := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 0, 7)
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE guild_id = $1 AND actor_id = $2 AND created_at >= $3 AND created_at < $4 ORDER BY created_at DESC, id DESC LIMIT 10 OFFSET 0`)).
+		WithArgs("guild-foo", "admin-1", since, until).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "guild_id", "actor_id", "action", "target", "before", "after", "created_at"}).
+			AddRow(2, "guild-foo", "admin-1", "channel.set", "", "c1", "c2", since.Add(time.Hour)))
+
+	entries, err := GetAuditLog(context.Background(), mockDB, AuditFilter{
+		GuildID: "guild-foo",
+		ActorID: "admin-1",
+		Since:   since,
+		Until:   until,
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "c1", entries[0].Before)
+	require.Equal(t, "c2", entries[0].After)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAuditLog_NoFilters(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM audit_log
+	 ORDER BY created_at DESC, id DESC LIMIT 50 OFFSET 0`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "guild_id", "actor_id", "action", "target", "before", "after", "created_at"}))
+
+	entries, err := GetAuditLog(context.Background(), mockDB, AuditFilter{})
+	require.NoError(t, err)
+	require.Empty(t, entries)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
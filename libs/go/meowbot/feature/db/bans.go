@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// BanUser bans a user from the guild's meow game, replacing any earlier ban.
+func BanUser(ctx context.Context, db *sql.DB, ban MeowBan) error {
+	defer trace(ctx, "BanUser")()
+
+	query := `
+		INSERT INTO meow_bans (guild_id, user_id, reason, banned_by, delete_messages, created_at, expires_at)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5, $6, $7)
+		ON CONFLICT (guild_id, user_id) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			banned_by = EXCLUDED.banned_by,
+			delete_messages = EXCLUDED.delete_messages,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at;
+	`
+
+	_, err := db.ExecContext(ctx, query, ban.GuildID, ban.UserID, ban.Reason, ban.BannedBy, ban.DeleteMessages, ban.CreatedAt, ban.ExpiresAt)
+	if err != nil {
+		return classify(fmt.Errorf("failed to ban user: %w", err))
+	}
+	return nil
+}
+
+// UnbanUser lifts a user's ban. It reports false if the user wasn't banned.
+func UnbanUser(ctx context.Context, db *sql.DB, guildID, userID string) (bool, error) {
+	defer trace(ctx, "UnbanUser")()
+
+	res, err := db.ExecContext(ctx, `DELETE FROM meow_bans WHERE guild_id = $1 AND user_id = $2;`, guildID, userID)
+	if err != nil {
+		return false, classify(fmt.Errorf("failed to unban user: %w", err))
+	}
+	n, err := res.RowsAffected()
+	return n > 0, classify(err)
+}
+
+// GetActiveBan returns the user's ban in the guild if it hasn't expired by
+// at, or nil if there is none.
+func GetActiveBan(ctx context.Context, db *sql.DB, guildID, userID string, at time.Time) (*MeowBan, error) {
+	defer trace(ctx, "GetActiveBan")()
+
+	query := `
+		SELECT guild_id, user_id, COALESCE(reason, ''), COALESCE(banned_by, ''), delete_messages, created_at, expires_at
+		FROM meow_bans
+		WHERE guild_id = $1 AND user_id = $2 AND (expires_at IS NULL OR expires_at > $3);
+	`
+
+	var ban MeowBan
+	err := db.QueryRowContext(ctx, query, guildID, userID, at).Scan(
+		&ban.GuildID, &ban.UserID, &ban.Reason, &ban.BannedBy, &ban.DeleteMessages, &ban.CreatedAt, &ban.ExpiresAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to get ban: %w", err))
+	}
+	return &ban, nil
+}
+
+// ListBans returns the bans still active at at, of one guild or of all guilds
+// if guildID is nil.
+func ListBans(ctx context.Context, db *sql.DB, guildID *string, at time.Time) ([]MeowBan, error) {
+	defer trace(ctx, "ListBans")()
+
+	query := `
+		SELECT guild_id, user_id, COALESCE(reason, ''), COALESCE(banned_by, ''), delete_messages, created_at, expires_at
+		FROM meow_bans
+		WHERE (expires_at IS NULL OR expires_at > $1)
+	`
+	args := []any{at}
+	if guildID != nil {
+		query += ` AND guild_id = $2`
+		args = append(args, *guildID)
+	}
+	query += ` ORDER BY guild_id, created_at DESC;`
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to list bans: %w", err))
+	}
+	defer rows.Close()
+
+	bans := []MeowBan{}
+	for rows.Next() {
+		var ban MeowBan
+		if err := rows.Scan(&ban.GuildID, &ban.UserID, &ban.Reason, &ban.BannedBy, &ban.DeleteMessages, &ban.CreatedAt, &ban.ExpiresAt); err != nil {
+			return nil, classify(err)
+		}
+		bans = append(bans, ban)
+	}
+	return bans, classify(rows.Err())
+}
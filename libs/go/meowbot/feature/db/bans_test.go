@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetActiveBan_NotBanned(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM meow_bans`)).
+		WithArgs("guild-foo", "user-1", now).
+		WillReturnError(sql.ErrNoRows)
+
+	ban, err := GetActiveBan(context.Background(), mockDB, "guild-foo", "user-1", now)
+	require.NoError(t, err)
+	require.Nil(t, ban)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListBans_Guild(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	guildID := "guild-foo"
+	mock.ExpectQuery(regexp.QuoteMeta(`AND guild_id = $2`)).
+		WithArgs(now, guildID).
+		WillReturnRows(sqlmock.NewRows([]string{"guild_id", "user_id", "reason", "banned_by", "delete_messages", "created_at", "expires_at"}).
+			AddRow(guildID, "user-1", "troll", "mod-1", true, now, expiresAt).
+			AddRow(guildID, "user-2", "", "mod-1", false, now, nil))
+
+	bans, err := ListBans(context.Background(), mockDB, &guildID, now)
+	require.NoError(t, err)
+	require.Len(t, bans, 2)
+	require.Equal(t, "troll", bans[0].Reason)
+	require.True(t, bans[0].DeleteMessages)
+	require.Equal(t, expiresAt, *bans[0].ExpiresAt)
+	require.Nil(t, bans[1].ExpiresAt)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
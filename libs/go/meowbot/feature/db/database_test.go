@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testPostgresEnv names a scratch Postgres database the tests may wipe. Without
+// it the SQL tests run on SQLite only.
+const testPostgresEnv = "MEOWBOT_TEST_DATABASE_URL"
+
+// forEachDatabase runs fn on an empty, migrated database of each backend: an
+// in-memory SQLite database, and Postgres if testPostgresEnv is set.
+func forEachDatabase(t *testing.T, fn func(t *testing.T, db *sql.DB)) {
+	t.Run("sqlite", func(t *testing.T) {
+		db, err := OpenSQLite(context.Background(), ":memory:")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+		require.NoError(t, Migrate(context.Background(), db))
+		fn(t, db)
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		url := os.Getenv(testPostgresEnv)
+		if url == "" {
+			t.Skip(testPostgresEnv + " not set")
+		}
+		db, err := sql.Open("postgres", url)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+		require.NoError(t, MigrateDown(context.Background(), db, 0))
+		require.NoError(t, Migrate(context.Background(), db))
+		fn(t, db)
+	})
+}
+
+// seedDatabase creates guilds g1 and g2 and users alice, bob and carol.
+func seedDatabase(t *testing.T, db *sql.DB) {
+	t.Helper()
+	ctx := context.Background()
+	for _, id := range []string{"g1", "g2"} {
+		require.NoError(t, UpsertGuild(ctx, db, Guild{ID: id}))
+	}
+	for _, name := range []string{"alice", "bob", "carol"} {
+		require.NoError(t, UpsertUser(ctx, db, User{ID: name, Username: name}))
+	}
+}
+
+func TestMigrate_RoundTrip(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *sql.DB) {
+		ctx := context.Background()
+		migrations, err := Migrations(db)
+		require.NoError(t, err)
+		require.NoError(t, CheckSchema(ctx, db))
+
+		require.NoError(t, MigrateDown(ctx, db, 0))
+		version, err := SchemaVersion(ctx, db)
+		require.NoError(t, err)
+		require.Equal(t, 0, version)
+		require.ErrorIs(t, CheckSchema(ctx, db), ErrSchemaIncompatible)
+
+		require.NoError(t, Migrate(ctx, db))
+		version, err = SchemaVersion(ctx, db)
+		require.NoError(t, err)
+		require.Equal(t, len(migrations), version)
+	})
+}
+
+func TestClassify_Database(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *sql.DB) {
+		ctx := context.Background()
+		seedDatabase(t, db)
+
+		// Unknown guild: foreign key violation.
+		err := IncrementMeow(ctx, db, "nope", "alice", true, time.Now())
+		require.ErrorIs(t, err, ErrInvalidInput)
+
+		_, err = GetGuildStreak(ctx, db, "g1")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"modernc.org/sqlite"
+)
+
+// dialect is the SQL flavour of the database behind a *sql.DB. Queries are
+// written for Postgres and SQLite runs most of them as is; the few it can't
+// have a SQLite counterpart chosen with pick.
+type dialect int
+
+const (
+	dialectPostgres dialect = iota
+	dialectSQLite
+)
+
+// dialectOf tells the dialect from db's driver. Anything but the SQLite
+// driver, including sqlmock in tests, is taken to be Postgres.
+func dialectOf(db *sql.DB) dialect {
+	if _, ok := db.Driver().(*sqlite.Driver); ok {
+		return dialectSQLite
+	}
+	return dialectPostgres
+}
+
+// pick returns the variant, usually a query, written for d.
+func (d dialect) pick(postgres, sqlite string) string {
+	if d == dialectSQLite {
+		return sqlite
+	}
+	return postgres
+}
+
+// nullTime scans a nullable timestamp into *t. SQLite stores timestamps as
+// Unix nanoseconds and only converts them back for columns declared
+// TIMESTAMP, so aggregates like MAX(last_meow_at) arrive as integers.
+type nullTime struct {
+	t **time.Time
+}
+
+func (n nullTime) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*n.t = nil
+	case time.Time:
+		*n.t = &v
+	case int64:
+		t := time.Unix(0, v).UTC()
+		*n.t = &t
+	default:
+		return fmt.Errorf("cannot scan %T into a timestamp", src)
+	}
+	return nil
+}
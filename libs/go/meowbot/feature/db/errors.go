@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/lib/pq"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Error kinds returned (wrapped) by this package. Callers should test for them
+// with errors.Is to decide what to tell users, instead of inspecting driver
+// errors or showing err.Error().
+var (
+	// ErrNotFound means the requested row doesn't exist.
+	ErrNotFound = errors.New("not found")
+	// ErrUnavailable means the database couldn't be reached or the query
+	// was cancelled or timed out. Retrying later may succeed.
+	ErrUnavailable = errors.New("database unavailable")
+	// ErrInvalidInput means the caller passed arguments the query rejected.
+	ErrInvalidInput = errors.New("invalid input")
+)
+
+// classify wraps err with the matching error kind, keeping the original error
+// in the chain so errors.Is(err, sql.ErrNoRows) etc. keep working.
+func classify(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrUnavailable) || errors.Is(err, ErrInvalidInput) {
+		return err
+	}
+
+	var netErr net.Error
+	var pqErr *pq.Error
+	var sqliteErr *sqlite.Error
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, context.Canceled),
+		errors.Is(err, driver.ErrBadConn),
+		errors.Is(err, sql.ErrConnDone),
+		errors.As(err, &netErr):
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	case errors.As(err, &pqErr):
+		switch pqErr.Code.Class() {
+		case "08", // connection exception
+			"53", // insufficient resources
+			"57": // operator intervention (e.g. shutdown, query cancelled)
+			return fmt.Errorf("%w: %w", ErrUnavailable, err)
+		case "22", // data exception
+			"23": // integrity constraint violation
+			return fmt.Errorf("%w: %w", ErrInvalidInput, err)
+		}
+	case errors.As(err, &sqliteErr):
+		// The low byte is the primary result code, the rest says which
+		// constraint etc. failed.
+		switch sqliteErr.Code() & 0xff {
+		case sqlite3.SQLITE_BUSY,
+			sqlite3.SQLITE_LOCKED,
+			sqlite3.SQLITE_IOERR,
+			sqlite3.SQLITE_CANTOPEN,
+			sqlite3.SQLITE_FULL:
+			return fmt.Errorf("%w: %w", ErrUnavailable, err)
+		case sqlite3.SQLITE_CONSTRAINT,
+			sqlite3.SQLITE_MISMATCH,
+			sqlite3.SQLITE_TOOBIG:
+			return fmt.Errorf("%w: %w", ErrInvalidInput, err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{"no rows", sql.ErrNoRows, ErrNotFound},
+		{"deadline", context.DeadlineExceeded, ErrUnavailable},
+		{"conn done", sql.ErrConnDone, ErrUnavailable},
+		{"connection failure", &pq.Error{Code: "08006"}, ErrUnavailable},
+		{"admin shutdown", &pq.Error{Code: "57P01"}, ErrUnavailable},
+		{"unique violation", &pq.Error{Code: "23505"}, ErrInvalidInput},
+		{"invalid text", &pq.Error{Code: "22P02"}, ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classify(tt.err)
+			require.ErrorIs(t, err, tt.kind)
+			require.ErrorIs(t, err, tt.err, "original error must stay in the chain")
+		})
+	}
+}
+
+func TestClassify_Unclassified(t *testing.T) {
+	require.NoError(t, classify(nil))
+
+	syntax := &pq.Error{Code: "42601"}
+	err := classify(syntax)
+	require.Same(t, syntax, err)
+	require.False(t, errors.Is(err, ErrNotFound) || errors.Is(err, ErrUnavailable) || errors.Is(err, ErrInvalidInput))
+}
+
+func TestClassify_AlreadyClassified(t *testing.T) {
+	err := classify(sql.ErrNoRows)
+	require.Same(t, err, classify(err))
+}
@@ -0,0 +1,737 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// GuildExportVersion is the version of the GuildExport format. It goes up
+// whenever the format changes in a way older importers can't read, and
+// ImportGuild only accepts exports of this version. Version 2 added treats,
+// items and seasons.
+const GuildExportVersion = 2
+
+// GuildExport is everything tied to a guild: its details, meow channel,
+// settings, recap opt-in, streak and item roles, its players' stats,
+// memberships, achievements, bans, treats and items, and its own seasons with
+// their counters and standings, with the profiles of those players. It also
+// holds the guild's counters in open global seasons, which keep their IDs.
+// Meow history and the audit log are not included.
+type GuildExport struct {
+	Version      int                `json:"version"`
+	ExportedAt   time.Time          `json:"exported_at"`
+	Guild        Guild              `json:"guild"`
+	ChannelID    string             `json:"channel_id,omitempty"`
+	Settings     GuildSettings      `json:"settings"`
+	Recap        *RecapSettings     `json:"recap,omitempty"`
+	Streak       *GuildStreak       `json:"streak,omitempty"`
+	Users        []User             `json:"users"`
+	Stats        []UserGuildStats   `json:"stats"`
+	Members      []GuildMember      `json:"members"`
+	Achievements []UserAchievement  `json:"achievements"`
+	Bans         []MeowBan          `json:"bans"`
+	Balances     []TreatBalance     `json:"balances"`
+	Ledger       []TreatLedgerEntry `json:"ledger"`
+	Items        []UserItem         `json:"items"`
+	ItemRoles    []ItemRole         `json:"item_roles"`
+	Seasons      []Season           `json:"seasons"`
+	SeasonStats  []SeasonStats      `json:"season_stats"`
+	Standings    []SeasonStanding   `json:"standings"`
+}
+
+// ImportReport says what ImportGuild did, or would do in a dry run. Problems
+// are reasons the export can't be imported; Conflicts are existing rows the
+// import replaces. SkippedSeasonStats counts the counters of global seasons
+// that the database doesn't have open, which the import leaves out.
+type ImportReport struct {
+	GuildID            string           `json:"guild_id"`
+	DryRun             bool             `json:"dry_run"`
+	Users              int              `json:"users"`
+	Stats              int              `json:"stats"`
+	Members            int              `json:"members"`
+	Achievements       int              `json:"achievements"`
+	Bans               int              `json:"bans"`
+	Balances           int              `json:"balances"`
+	Ledger             int              `json:"ledger"`
+	Items              int              `json:"items"`
+	ItemRoles          int              `json:"item_roles"`
+	Seasons            int              `json:"seasons"`
+	SeasonStats        int              `json:"season_stats"`
+	Standings          int              `json:"standings"`
+	SkippedSeasonStats int              `json:"skipped_season_stats,omitempty"`
+	Conflicts          []ImportConflict `json:"conflicts,omitempty"`
+	Problems           []string         `json:"problems,omitempty"`
+}
+
+// newImportReport counts the rows of export.
+func newImportReport(export *GuildExport, dryRun bool) *ImportReport {
+	return &ImportReport{
+		GuildID:      export.Guild.ID,
+		DryRun:       dryRun,
+		Users:        len(export.Users),
+		Stats:        len(export.Stats),
+		Members:      len(export.Members),
+		Achievements: len(export.Achievements),
+		Bans:         len(export.Bans),
+		Balances:     len(export.Balances),
+		Ledger:       len(export.Ledger),
+		Items:        len(export.Items),
+		ItemRoles:    len(export.ItemRoles),
+		Seasons:      len(export.Seasons),
+		SeasonStats:  len(export.SeasonStats),
+		Standings:    len(export.Standings),
+	}
+}
+
+// ImportConflict counts the rows of a table that the guild already has and
+// that an import replaces.
+type ImportConflict struct {
+	Table string `json:"table"`
+	Rows  int    `json:"rows"`
+}
+
+// guildTable is a table whose rows for a guild ImportGuild replaces, with the
+// condition that selects them.
+type guildTable struct {
+	name, where string
+}
+
+// guildTables are the tables ImportGuild clears, standings and counters
+// before the seasons they belong to. The guild's counters in global seasons
+// go too, as the export holds them.
+var guildTables = []guildTable{
+	{"guild_channels", "guild_id = $1"},
+	{"guild_settings", "guild_id = $1"},
+	{"recap_settings", "guild_id = $1"},
+	{"guild_streaks", "guild_id = $1"},
+	{"user_guild_stats", "guild_id = $1"},
+	{"guild_members", "guild_id = $1"},
+	{"user_achievements", "guild_id = $1"},
+	{"meow_bans", "guild_id = $1"},
+	{"treat_balances", "guild_id = $1"},
+	{"treat_ledger", "guild_id = $1"},
+	{"user_items", "guild_id = $1"},
+	{"item_roles", "guild_id = $1"},
+	{"season_standings", "season_id IN (SELECT id FROM seasons WHERE guild_id = $1)"},
+	{"season_stats", "guild_id = $1"},
+	{"seasons", "guild_id = $1"},
+}
+
+// errDryRun rolls back a dry-run import.
+var errDryRun = errors.New("dry run")
+
+// queryAll runs query and scans each of its rows with scan.
+func queryAll[T any](ctx context.Context, db *sql.DB, scan func(rows *sql.Rows) (T, error), query string, args ...any) (result []T, err error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close rows: %w", closeErr)
+		}
+	}()
+
+	result = []T{}
+	for rows.Next() {
+		v, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	return result, rows.Err()
+}
+
+// ExportGuild collects everything tied to a guild into a GuildExport. It
+// fails with ErrNotFound for an unknown guild.
+func ExportGuild(ctx context.Context, db *sql.DB, guildID string) (*GuildExport, error) {
+	defer trace(ctx, "ExportGuild")()
+
+	guild, err := GetGuild(ctx, db, guildID)
+	if err != nil {
+		return nil, err
+	}
+	export := &GuildExport{Version: GuildExportVersion, ExportedAt: time.Now().UTC(), Guild: *guild}
+
+	if export.ChannelID, err = GetChannelForGuild(ctx, db, guildID); err != nil {
+		return nil, err
+	}
+	settings, err := GetGuildSettings(ctx, db, guildID)
+	if err != nil {
+		return nil, err
+	}
+	export.Settings = *settings
+	if export.Recap, err = GetGuildRecapSettings(ctx, db, guildID); err != nil {
+		return nil, err
+	}
+	export.Streak, err = GetGuildStreak(ctx, db, guildID)
+	if errors.Is(err, ErrNotFound) {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	export.Users, err = queryAll(ctx, db, func(rows *sql.Rows) (User, error) {
+		var u User
+		err := rows.Scan(&u.ID, &u.Username, &u.DisplayName, &u.Avatar, &u.CreatedAt)
+		return u, err
+	}, `
+		SELECT id, COALESCE(username, ''), COALESCE(display_name, ''), COALESCE(avatar, ''), created_at
+		FROM users
+		WHERE id IN (
+			SELECT user_id FROM user_guild_stats WHERE guild_id = $1
+			UNION SELECT user_id FROM guild_members WHERE guild_id = $1
+			UNION SELECT user_id FROM user_achievements WHERE guild_id = $1
+			UNION SELECT user_id FROM meow_bans WHERE guild_id = $1
+			UNION SELECT user_id FROM treat_balances WHERE guild_id = $1
+			UNION SELECT user_id FROM treat_ledger WHERE guild_id = $1
+			UNION SELECT user_id FROM user_items WHERE guild_id = $1
+			UNION SELECT user_id FROM season_stats WHERE guild_id = $1
+			UNION SELECT user_id FROM season_standings
+				WHERE season_id IN (SELECT id FROM seasons WHERE guild_id = $1)
+		)
+		ORDER BY id;
+	`, guildID)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to export users: %w", err))
+	}
+
+	export.Stats, err = queryAll(ctx, db, func(rows *sql.Rows) (UserGuildStats, error) {
+		var s UserGuildStats
+		err := rows.Scan(&s.GuildID, &s.UserID, &s.SuccessfulMeows, &s.FailedMeows, &s.TotalMeows,
+			&s.CurrentStreak, &s.HighestStreak, &s.LastMeowAt, &s.LastFailedMeowAt)
+		return s, err
+	}, `
+		SELECT guild_id, user_id, successful_meows, failed_meows, total_meows,
+			current_streak, highest_streak, last_meow_at, last_failed_meow_at
+		FROM user_guild_stats
+		WHERE guild_id = $1
+		ORDER BY user_id;
+	`, guildID)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to export stats: %w", err))
+	}
+
+	export.Members, err = queryAll(ctx, db, func(rows *sql.Rows) (GuildMember, error) {
+		var m GuildMember
+		err := rows.Scan(&m.GuildID, &m.UserID, &m.Nickname, &m.JoinedAt, &m.UpdatedAt)
+		return m, err
+	}, `
+		SELECT guild_id, user_id, COALESCE(nickname, ''), joined_at, updated_at
+		FROM guild_members
+		WHERE guild_id = $1
+		ORDER BY user_id;
+	`, guildID)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to export members: %w", err))
+	}
+
+	export.Achievements, err = queryAll(ctx, db, func(rows *sql.Rows) (UserAchievement, error) {
+		var a UserAchievement
+		err := rows.Scan(&a.GuildID, &a.UserID, &a.AchievementID, &a.UnlockedAt)
+		return a, err
+	}, `
+		SELECT guild_id, user_id, achievement_id, unlocked_at
+		FROM user_achievements
+		WHERE guild_id = $1
+		ORDER BY user_id, achievement_id;
+	`, guildID)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to export achievements: %w", err))
+	}
+
+	export.Bans, err = queryAll(ctx, db, func(rows *sql.Rows) (MeowBan, error) {
+		var b MeowBan
+		err := rows.Scan(&b.GuildID, &b.UserID, &b.Reason, &b.BannedBy, &b.DeleteMessages, &b.CreatedAt, &b.ExpiresAt)
+		return b, err
+	}, `
+		SELECT guild_id, user_id, COALESCE(reason, ''), COALESCE(banned_by, ''), delete_messages, created_at, expires_at
+		FROM meow_bans
+		WHERE guild_id = $1
+		ORDER BY user_id;
+	`, guildID)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to export bans: %w", err))
+	}
+
+	export.Balances, err = queryAll(ctx, db, func(rows *sql.Rows) (TreatBalance, error) {
+		var b TreatBalance
+		err := rows.Scan(&b.GuildID, &b.UserID, &b.Balance)
+		return b, err
+	}, `
+		SELECT guild_id, user_id, balance
+		FROM treat_balances
+		WHERE guild_id = $1
+		ORDER BY user_id;
+	`, guildID)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to export treat balances: %w", err))
+	}
+
+	export.Ledger, err = queryAll(ctx, db, func(rows *sql.Rows) (TreatLedgerEntry, error) {
+		var e TreatLedgerEntry
+		err := rows.Scan(&e.ID, &e.GuildID, &e.UserID, &e.Amount, &e.Reason, &e.BalanceAfter, &e.CreatedAt)
+		return e, err
+	}, `
+		SELECT id, guild_id, user_id, amount, reason, balance_after, created_at
+		FROM treat_ledger
+		WHERE guild_id = $1
+		ORDER BY id;
+	`, guildID)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to export treat ledger: %w", err))
+	}
+
+	export.Items, err = queryAll(ctx, db, func(rows *sql.Rows) (UserItem, error) {
+		var item UserItem
+		err := rows.Scan(&item.GuildID, &item.UserID, &item.ItemID, &item.PurchasedAt)
+		return item, err
+	}, `
+		SELECT guild_id, user_id, item_id, purchased_at
+		FROM user_items
+		WHERE guild_id = $1
+		ORDER BY user_id, item_id;
+	`, guildID)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to export items: %w", err))
+	}
+
+	export.ItemRoles, err = queryAll(ctx, db, func(rows *sql.Rows) (ItemRole, error) {
+		var r ItemRole
+		err := rows.Scan(&r.GuildID, &r.ItemID, &r.RoleID)
+		return r, err
+	}, `
+		SELECT guild_id, item_id, role_id
+		FROM item_roles
+		WHERE guild_id = $1
+		ORDER BY item_id;
+	`, guildID)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to export item roles: %w", err))
+	}
+
+	export.Seasons, err = queryAll(ctx, db, func(rows *sql.Rows) (Season, error) {
+		s, err := scanSeason(rows)
+		if err != nil {
+			return Season{}, err
+		}
+		return *s, nil
+	}, `
+		SELECT `+seasonColumns+`
+		FROM seasons
+		WHERE guild_id = $1
+		ORDER BY id;
+	`, guildID)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to export seasons: %w", err))
+	}
+
+	export.SeasonStats, err = queryAll(ctx, db, func(rows *sql.Rows) (SeasonStats, error) {
+		var s SeasonStats
+		err := rows.Scan(&s.SeasonID, &s.GuildID, &s.UserID, &s.SuccessfulMeows, &s.FailedMeows, &s.TotalMeows)
+		return s, err
+	}, `
+		SELECT season_id, guild_id, user_id, successful_meows, failed_meows, total_meows
+		FROM season_stats
+		WHERE guild_id = $1
+		ORDER BY season_id, user_id;
+	`, guildID)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to export season stats: %w", err))
+	}
+
+	export.Standings, err = queryAll(ctx, db, func(rows *sql.Rows) (SeasonStanding, error) {
+		var s SeasonStanding
+		err := rows.Scan(&s.SeasonID, &s.UserID, &s.Rank, &s.SuccessfulMeows, &s.FailedMeows, &s.TotalMeows)
+		return s, err
+	}, `
+		SELECT season_id, user_id, rank, successful_meows, failed_meows, total_meows
+		FROM season_standings
+		WHERE season_id IN (SELECT id FROM seasons WHERE guild_id = $1)
+		ORDER BY season_id, rank, user_id;
+	`, guildID)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to export season standings: %w", err))
+	}
+
+	return export, nil
+}
+
+// validate lists what keeps e from being imported.
+func (e *GuildExport) validate() []string {
+	if e.Version != GuildExportVersion {
+		return []string{fmt.Sprintf("unsupported export version %d, want %d", e.Version, GuildExportVersion)}
+	}
+	if e.Guild.ID == "" {
+		return []string{"guild has no ID"}
+	}
+
+	var problems []string
+	guild := func(what, guildID string) {
+		if guildID != "" && guildID != e.Guild.ID {
+			problems = append(problems, fmt.Sprintf("%s belongs to guild %s, not %s", what, guildID, e.Guild.ID))
+		}
+	}
+	guild("settings", e.Settings.GuildID)
+	if e.Settings.Timezone != "" {
+		if _, err := time.LoadLocation(e.Settings.Timezone); err != nil {
+			problems = append(problems, fmt.Sprintf("unknown timezone %q", e.Settings.Timezone))
+		}
+	}
+	if e.Recap != nil {
+		guild("recap settings", e.Recap.GuildID)
+		if e.Recap.Frequency == "" {
+			problems = append(problems, "recap settings have no frequency")
+		}
+	}
+	if e.Streak != nil {
+		guild("streak", e.Streak.GuildID)
+		if e.Streak.MeowCount < 0 || e.Streak.HighScore < 0 {
+			problems = append(problems, "streak has a negative count")
+		}
+	}
+
+	users := make(map[string]bool, len(e.Users))
+	for _, u := range e.Users {
+		switch {
+		case u.ID == "":
+			problems = append(problems, "user has no ID")
+		case users[u.ID]:
+			problems = append(problems, fmt.Sprintf("user %s is listed twice", u.ID))
+		}
+		users[u.ID] = true
+	}
+	player := func(what, guildID, userID string) {
+		guild(what+" of user "+userID, guildID)
+		if !users[userID] {
+			problems = append(problems, fmt.Sprintf("%s of user %s: user is not in the export", what, userID))
+		}
+	}
+
+	stats := make(map[string]bool, len(e.Stats))
+	for _, s := range e.Stats {
+		player("stats", s.GuildID, s.UserID)
+		if stats[s.UserID] {
+			problems = append(problems, fmt.Sprintf("stats of user %s are listed twice", s.UserID))
+		}
+		stats[s.UserID] = true
+		if s.SuccessfulMeows < 0 || s.FailedMeows < 0 || s.TotalMeows < 0 || s.CurrentStreak < 0 || s.HighestStreak < 0 {
+			problems = append(problems, fmt.Sprintf("stats of user %s have a negative count", s.UserID))
+		}
+	}
+	for _, m := range e.Members {
+		player("membership", m.GuildID, m.UserID)
+	}
+	for _, a := range e.Achievements {
+		player("achievement "+a.AchievementID, a.GuildID, a.UserID)
+	}
+	for _, b := range e.Bans {
+		player("ban", b.GuildID, b.UserID)
+	}
+	for _, b := range e.Balances {
+		player("treat balance", b.GuildID, b.UserID)
+		if b.Balance < 0 {
+			problems = append(problems, fmt.Sprintf("treat balance of user %s is negative", b.UserID))
+		}
+	}
+	for _, l := range e.Ledger {
+		player(fmt.Sprintf("treat ledger entry %d", l.ID), l.GuildID, l.UserID)
+	}
+	for _, item := range e.Items {
+		player("item "+item.ItemID, item.GuildID, item.UserID)
+	}
+	for _, r := range e.ItemRoles {
+		guild("role of item "+r.ItemID, r.GuildID)
+		if r.RoleID == "" {
+			problems = append(problems, fmt.Sprintf("role of item %s has no ID", r.ItemID))
+		}
+	}
+
+	seasons := make(map[int64]bool, len(e.Seasons))
+	for _, s := range e.Seasons {
+		what := fmt.Sprintf("season %d", s.ID)
+		switch {
+		case s.GuildID == nil:
+			problems = append(problems, what+" is global")
+		default:
+			guild(what, *s.GuildID)
+		}
+		if seasons[s.ID] {
+			problems = append(problems, what+" is listed twice")
+		}
+		seasons[s.ID] = true
+		if !s.EndsAt.After(s.StartsAt) {
+			problems = append(problems, what+" ends before it starts")
+		}
+	}
+	for _, s := range e.SeasonStats {
+		player(fmt.Sprintf("stats in season %d", s.SeasonID), s.GuildID, s.UserID)
+	}
+	for _, s := range e.Standings {
+		what := fmt.Sprintf("standing in season %d of user %s", s.SeasonID, s.UserID)
+		if !seasons[s.SeasonID] {
+			problems = append(problems, what+": season is not in the export")
+		}
+		if !users[s.UserID] {
+			problems = append(problems, what+": user is not in the export")
+		}
+	}
+	return problems
+}
+
+// ImportGuild replaces the guild's channel, settings, recap opt-in, streak,
+// item roles, stats, memberships, achievements, bans, treats, items and
+// seasons with those of export, and adds the users it doesn't know yet; known
+// users keep their current profile. The guild's seasons and ledger entries
+// get new IDs. Everything happens in one transaction. A dry run checks the
+// export and goes through the import, then rolls it back.
+//
+// The report lists the rows replaced and, if the export can't be imported,
+// why not; ImportGuild then fails with ErrInvalidInput and changes nothing.
+// A RankCache wrapping the store reloads its ranking after an import; other
+// replicas' caches pick it up on their next reload.
+func ImportGuild(ctx context.Context, db *sql.DB, export *GuildExport, dryRun bool) (*ImportReport, error) {
+	defer trace(ctx, "ImportGuild")()
+
+	report := newImportReport(export, dryRun)
+	if report.Problems = export.validate(); len(report.Problems) > 0 {
+		return report, fmt.Errorf("%w: guild export has %d problems", ErrInvalidInput, len(report.Problems))
+	}
+
+	guildID := export.Guild.ID
+	err := withTx(ctx, db, func(tx *sql.Tx) error {
+		for _, table := range guildTables {
+			var n int
+			err := tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s`, table.name, table.where), guildID).Scan(&n)
+			if err != nil {
+				return classify(fmt.Errorf("failed to count %s: %w", table.name, err))
+			}
+			if n > 0 {
+				report.Conflicts = append(report.Conflicts, ImportConflict{Table: table.name, Rows: n})
+			}
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s`, table.name, table.where), guildID); err != nil {
+				return classify(fmt.Errorf("failed to clear %s: %w", table.name, err))
+			}
+		}
+
+		if err := importGuildRows(ctx, tx, export, report); err != nil {
+			return err
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// importGuildRows writes the rows of export, whose guild has been cleared,
+// and counts the season counters it skips in report.
+func importGuildRows(ctx context.Context, tx *sql.Tx, export *GuildExport, report *ImportReport) error {
+	g := export.Guild
+	guildID := g.ID
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO guilds (id, name, icon, member_count, owner_id, joined_at, created_at)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, 0), NULLIF($5, ''), $6, $7)
+		ON CONFLICT (id) DO UPDATE SET
+			name = COALESCE(EXCLUDED.name, guilds.name),
+			icon = COALESCE(EXCLUDED.icon, guilds.icon),
+			member_count = COALESCE(EXCLUDED.member_count, guilds.member_count),
+			owner_id = COALESCE(EXCLUDED.owner_id, guilds.owner_id),
+			joined_at = COALESCE(guilds.joined_at, EXCLUDED.joined_at);
+	`, guildID, g.Name, g.Icon, g.MemberCount, g.OwnerID, g.JoinedAt, g.CreatedAt)
+	if err != nil {
+		return classify(fmt.Errorf("failed to import guild: %w", err))
+	}
+
+	for _, u := range export.Users {
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO users (id, username, display_name, avatar, created_at)
+			VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, ''), $5)
+			ON CONFLICT (id) DO NOTHING;
+		`, u.ID, u.Username, u.DisplayName, u.Avatar, u.CreatedAt)
+		if err != nil {
+			return classify(fmt.Errorf("failed to import user %s: %w", u.ID, err))
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return classify(err)
+		} else if n == 0 || u.Username == "" {
+			continue
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO username_history (user_id, username, changed_at)
+			VALUES ($1, $2, $3);
+		`, u.ID, u.Username, u.CreatedAt)
+		if err != nil {
+			return classify(fmt.Errorf("failed to record username of user %s: %w", u.ID, err))
+		}
+	}
+
+	if export.ChannelID != "" {
+		_, err := tx.ExecContext(ctx, `INSERT INTO guild_channels (guild_id, channel_id) VALUES ($1, $2);`, guildID, export.ChannelID)
+		if err != nil {
+			return classify(fmt.Errorf("failed to import channel: %w", err))
+		}
+	}
+	if s := export.Settings; s.Locale != "" || s.Timezone != "" {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO guild_settings (guild_id, locale, timezone)
+			VALUES ($1, NULLIF($2, ''), NULLIF($3, ''));
+		`, guildID, s.Locale, s.Timezone)
+		if err != nil {
+			return classify(fmt.Errorf("failed to import settings: %w", err))
+		}
+	}
+	if r := export.Recap; r != nil {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO recap_settings (guild_id, frequency, channel_id, last_period_end)
+			VALUES ($1, $2, NULLIF($3, ''), $4);
+		`, guildID, r.Frequency, r.ChannelID, r.LastPeriodEnd)
+		if err != nil {
+			return classify(fmt.Errorf("failed to import recap settings: %w", err))
+		}
+	}
+	if s := export.Streak; s != nil {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO guild_streaks (guild_id, meow_count, last_user_id, high_score, high_score_user_id)
+			VALUES ($1, $2, $3, $4, $5);
+		`, guildID, s.MeowCount, s.LastUserID, s.HighScore, s.HighScoreUserID)
+		if err != nil {
+			return classify(fmt.Errorf("failed to import streak: %w", err))
+		}
+	}
+
+	for _, s := range export.Stats {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO user_guild_stats (guild_id, user_id, successful_meows, failed_meows, total_meows,
+				current_streak, highest_streak, last_meow_at, last_failed_meow_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+		`, guildID, s.UserID, s.SuccessfulMeows, s.FailedMeows, s.TotalMeows,
+			s.CurrentStreak, s.HighestStreak, s.LastMeowAt, s.LastFailedMeowAt)
+		if err != nil {
+			return classify(fmt.Errorf("failed to import stats of user %s: %w", s.UserID, err))
+		}
+	}
+	for _, m := range export.Members {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO guild_members (guild_id, user_id, nickname, joined_at, updated_at)
+			VALUES ($1, $2, NULLIF($3, ''), $4, $5);
+		`, guildID, m.UserID, m.Nickname, m.JoinedAt, m.UpdatedAt)
+		if err != nil {
+			return classify(fmt.Errorf("failed to import membership of user %s: %w", m.UserID, err))
+		}
+	}
+	for _, a := range export.Achievements {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO user_achievements (guild_id, user_id, achievement_id, unlocked_at)
+			VALUES ($1, $2, $3, $4);
+		`, guildID, a.UserID, a.AchievementID, a.UnlockedAt)
+		if err != nil {
+			return classify(fmt.Errorf("failed to import achievement %s of user %s: %w", a.AchievementID, a.UserID, err))
+		}
+	}
+	for _, b := range export.Bans {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO meow_bans (guild_id, user_id, reason, banned_by, delete_messages, created_at, expires_at)
+			VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5, $6, $7);
+		`, guildID, b.UserID, b.Reason, b.BannedBy, b.DeleteMessages, b.CreatedAt, b.ExpiresAt)
+		if err != nil {
+			return classify(fmt.Errorf("failed to import ban of user %s: %w", b.UserID, err))
+		}
+	}
+
+	for _, b := range export.Balances {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO treat_balances (guild_id, user_id, balance) VALUES ($1, $2, $3);
+		`, guildID, b.UserID, b.Balance)
+		if err != nil {
+			return classify(fmt.Errorf("failed to import treat balance of user %s: %w", b.UserID, err))
+		}
+	}
+	for _, e := range export.Ledger {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO treat_ledger (guild_id, user_id, amount, reason, balance_after, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6);
+		`, guildID, e.UserID, e.Amount, e.Reason, e.BalanceAfter, e.CreatedAt)
+		if err != nil {
+			return classify(fmt.Errorf("failed to import treat ledger entry %d: %w", e.ID, err))
+		}
+	}
+	for _, item := range export.Items {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO user_items (guild_id, user_id, item_id, purchased_at) VALUES ($1, $2, $3, $4);
+		`, guildID, item.UserID, item.ItemID, item.PurchasedAt)
+		if err != nil {
+			return classify(fmt.Errorf("failed to import item %s of user %s: %w", item.ItemID, item.UserID, err))
+		}
+	}
+	for _, r := range export.ItemRoles {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO item_roles (guild_id, item_id, role_id) VALUES ($1, $2, $3);
+		`, guildID, r.ItemID, r.RoleID)
+		if err != nil {
+			return classify(fmt.Errorf("failed to import role of item %s: %w", r.ItemID, err))
+		}
+	}
+
+	seasonIDs := make(map[int64]int64, len(export.Seasons))
+	for _, s := range export.Seasons {
+		var id int64
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO seasons (guild_id, name, starts_at, ends_at, closed_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id;
+		`, guildID, s.Name, s.StartsAt, s.EndsAt, s.ClosedAt).Scan(&id)
+		if err != nil {
+			return classify(fmt.Errorf("failed to import season %d: %w", s.ID, err))
+		}
+		seasonIDs[s.ID] = id
+	}
+	for _, s := range export.SeasonStats {
+		seasonID, ok := seasonIDs[s.SeasonID]
+		if !ok {
+			// Counters of a global season: keep them only if that season is
+			// open here too.
+			var open bool
+			err := tx.QueryRowContext(ctx, `
+				SELECT EXISTS (SELECT 1 FROM seasons WHERE id = $1 AND guild_id IS NULL AND closed_at IS NULL);
+			`, s.SeasonID).Scan(&open)
+			if err != nil {
+				return classify(fmt.Errorf("failed to look up season %d: %w", s.SeasonID, err))
+			}
+			if !open {
+				report.SkippedSeasonStats++
+				continue
+			}
+			seasonID = s.SeasonID
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO season_stats (season_id, guild_id, user_id, successful_meows, failed_meows, total_meows)
+			VALUES ($1, $2, $3, $4, $5, $6);
+		`, seasonID, guildID, s.UserID, s.SuccessfulMeows, s.FailedMeows, s.TotalMeows)
+		if err != nil {
+			return classify(fmt.Errorf("failed to import stats in season %d of user %s: %w", s.SeasonID, s.UserID, err))
+		}
+	}
+	for _, s := range export.Standings {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO season_standings (season_id, user_id, rank, successful_meows, failed_meows, total_meows)
+			VALUES ($1, $2, $3, $4, $5, $6);
+		`, seasonIDs[s.SeasonID], s.UserID, s.Rank, s.SuccessfulMeows, s.FailedMeows, s.TotalMeows)
+		if err != nil {
+			return classify(fmt.Errorf("failed to import standing in season %d of user %s: %w", s.SeasonID, s.UserID, err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// Metric is a stats counter players are ranked by.
+type Metric string
+
+const (
+	MetricTotal         Metric = "total_meows"
+	MetricSuccessful    Metric = "successful_meows"
+	MetricFailed        Metric = "failed_meows"
+	MetricHighestStreak Metric = "highest_streak"
+	MetricCurrentStreak Metric = "current_streak"
+)
+
+// column returns the stats column m ranks by. Only the names listed here are
+// ever put into a query, so a Metric from outside can't reach the SQL.
+func (m Metric) column() (string, error) {
+	switch m {
+	case MetricTotal, MetricSuccessful, MetricFailed, MetricHighestStreak, MetricCurrentStreak:
+		return string(m), nil
+	}
+	return "", fmt.Errorf("%w: invalid metric: %q", ErrInvalidInput, string(m))
+}
+
+// counterColumn is column for the meow counters alone, the only metrics
+// seasons keep.
+func (m Metric) counterColumn() (string, error) {
+	switch m {
+	case MetricTotal, MetricSuccessful, MetricFailed:
+		return string(m), nil
+	}
+	return "", fmt.Errorf("%w: invalid season metric: %q", ErrInvalidInput, string(m))
+}
+
+// LeaderboardQuery selects a page of Limit entries ranked by Metric: the
+// page right after After, the one right before Before, or else page Page
+// (counting from 1), clamped to the last page. Cursors keep a reader's place
+// while other players move up or down between pages.
+type LeaderboardQuery struct {
+	Metric Metric
+	Limit  int
+	Page   int
+	After  *LeaderboardCursor
+	Before *LeaderboardCursor
+}
+
+func (q LeaderboardQuery) validate() error {
+	if q.Limit < 1 {
+		return fmt.Errorf("%w: invalid leaderboard limit: %d", ErrInvalidInput, q.Limit)
+	}
+	if q.After != nil && q.Before != nil {
+		return fmt.Errorf("%w: leaderboard query has both After and Before", ErrInvalidInput)
+	}
+	return nil
+}
+
+// skip is the number of entries before page q.Page.
+func (q LeaderboardQuery) skip() int {
+	return (max(q.Page, 1) - 1) * q.Limit
+}
+
+// rankQuery builds the queries that rank players. Every value is bound as a
+// parameter; the only text spliced into the SQL is a metric's column and the
+// stats table, both picked from fixed lists in this package.
+type rankQuery struct {
+	args []any
+}
+
+// arg binds v and returns its placeholder.
+func (q *rankQuery) arg(v any) string {
+	q.args = append(q.args, v)
+	return "$" + strconv.Itoa(len(q.args))
+}
+
+// ranked returns a WITH clause for "ranked": one row per player in the rows
+// of table matching where, with their counters summed, their value of
+// column, their rank (ties share a rank) and their position (ties broken by
+// user ID), and the number of players. GetUserRank and the leaderboards
+// all read it, so a rank on a page always matches the player's own rank.
+func (q *rankQuery) ranked(column, table, where string) string {
+	return fmt.Sprintf(`
+		WITH totals AS (
+			SELECT user_id,
+				COALESCE(SUM(successful_meows), 0) AS successful_meows,
+				COALESCE(SUM(failed_meows), 0) AS failed_meows,
+				COALESCE(SUM(total_meows), 0) AS total_meows,
+				COALESCE(SUM(%s), 0) AS value
+			FROM %s
+			%s
+			GROUP BY user_id
+		), ranked AS (
+			SELECT totals.*,
+				RANK() OVER (ORDER BY value DESC) AS rank,
+				ROW_NUMBER() OVER (ORDER BY value DESC, user_id) AS position,
+				COUNT(*) OVER () AS total
+			FROM totals
+		)`, column, table, where)
+}
+
+// page returns the query for the page lq selects from "ranked". Before pages
+// come back bottom-up and are reversed by queryLeaderboardPage.
+func (q *rankQuery) page(ranked string, lq LeaderboardQuery) string {
+	where, order := "", "r.value DESC, r.user_id"
+	switch {
+	case lq.After != nil:
+		v, u := q.arg(lq.After.Value), q.arg(lq.After.UserID)
+		where = fmt.Sprintf("r.value < %s OR (r.value = %s AND r.user_id > %s)", v, v, u)
+	case lq.Before != nil:
+		v, u := q.arg(lq.Before.Value), q.arg(lq.Before.UserID)
+		where = fmt.Sprintf("r.value > %s OR (r.value = %s AND r.user_id < %s)", v, v, u)
+		order = "r.value, r.user_id DESC"
+	default:
+		skip := q.arg(lq.skip())
+		last := fmt.Sprintf("(r.total - 1) / %s * %s", q.arg(lq.Limit), q.arg(lq.Limit))
+		where = fmt.Sprintf("r.position > CASE WHEN %s < %s THEN %s ELSE %s END", skip, last, skip, last)
+	}
+
+	return ranked + fmt.Sprintf(`
+		SELECT u.id, u.username, COALESCE(u.display_name, ''), COALESCE(u.avatar, ''), u.created_at,
+			r.successful_meows, r.failed_meows, r.total_meows,
+			r.value, r.rank, r.position, r.total
+		FROM ranked r
+		JOIN users u ON u.id = r.user_id
+		WHERE %s
+		ORDER BY %s
+		LIMIT %s
+	`, where, order, q.arg(lq.Limit))
+}
+
+// rank returns the query for userID's rank in "ranked".
+func (q *rankQuery) rank(ranked, userID string) string {
+	return ranked + ` SELECT rank FROM ranked WHERE user_id = ` + q.arg(userID)
+}
+
+// queryLeaderboardPage runs a page query built by rankQuery.page.
+func queryLeaderboardPage(ctx context.Context, db *sql.DB, query string, args []any, lq LeaderboardQuery) (page *LeaderboardPage, err error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, classify(fmt.Errorf("query leaderboard: %w", err))
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close rows: %w", closeErr)
+		}
+	}()
+
+	var ranked []rankedEntry
+	for rows.Next() {
+		var user User
+		r := rankedEntry{LeaderboardEntry: LeaderboardEntry{User: &user}}
+		if err := rows.Scan(
+			&user.ID, &user.Username, &user.DisplayName, &user.Avatar, &user.CreatedAt,
+			&r.SuccessfulMeows, &r.FailedMeows, &r.TotalMeows,
+			&r.value, &r.Rank, &r.position, &r.total,
+		); err != nil {
+			return nil, classify(fmt.Errorf("scan leaderboard row: %w", err))
+		}
+		ranked = append(ranked, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, classify(fmt.Errorf("rows error: %w", err))
+	}
+
+	if lq.Before != nil {
+		slices.Reverse(ranked)
+	}
+	return newLeaderboardPage(ranked), nil
+}
+
+// rankedEntry is a leaderboard entry with what it takes to page around it.
+type rankedEntry struct {
+	LeaderboardEntry
+	value    int
+	position int
+	total    int
+}
+
+func (r rankedEntry) cursor() *LeaderboardCursor {
+	return &LeaderboardCursor{Value: r.value, UserID: r.User.ID}
+}
+
+// newLeaderboardPage makes a page of consecutive ranked entries.
+func newLeaderboardPage(ranked []rankedEntry) *LeaderboardPage {
+	page := &LeaderboardPage{Entries: make([]LeaderboardEntry, 0, len(ranked))}
+	if len(ranked) == 0 {
+		return page
+	}
+
+	first, last := ranked[0], ranked[len(ranked)-1]
+	page.Start = first.position
+	page.Total = first.total
+	if first.position > 1 {
+		page.Prev = first.cursor()
+	}
+	if last.position < last.total {
+		page.Next = last.cursor()
+	}
+	for _, r := range ranked {
+		page.Entries = append(page.Entries, r.LeaderboardEntry)
+	}
+	return page
+}
+
+// pageRange returns the range [from, to) of the page q selects out of n
+// players in rank order, where key(i) is player i's value and user ID. It is
+// rankQuery.page for rankings held in memory.
+func pageRange(n int, q LeaderboardQuery, key func(i int) LeaderboardCursor) (from, to int) {
+	switch {
+	case q.After != nil:
+		from = sort.Search(n, func(i int) bool { return compareRanked(key(i), *q.After) > 0 })
+		to = min(from+q.Limit, n)
+	case q.Before != nil:
+		to = sort.Search(n, func(i int) bool { return compareRanked(key(i), *q.Before) >= 0 })
+		from = max(to-q.Limit, 0)
+	default:
+		if n > 0 {
+			from = min(q.skip(), (n-1)/q.Limit*q.Limit)
+		}
+		to = min(from+q.Limit, n)
+	}
+	return from, to
+}
+
+// compareRanked is negative if a ranks above b, zero if they are the same
+// player and positive if a ranks below b.
+func compareRanked(a, b LeaderboardCursor) int {
+	if r := cmp.Compare(b.Value, a.Value); r != 0 {
+		return r
+	}
+	return cmp.Compare(a.UserID, b.UserID)
+}
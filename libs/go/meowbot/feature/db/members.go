@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// UpsertGuildMember records a user's nickname and join date in a guild. The
+// guild and user must exist.
+func UpsertGuildMember(ctx context.Context, db *sql.DB, member GuildMember) error {
+	defer trace(ctx, "UpsertGuildMember")()
+
+	query := `
+		INSERT INTO guild_members (guild_id, user_id, nickname, joined_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5)
+		ON CONFLICT (guild_id, user_id) DO UPDATE SET
+			nickname = EXCLUDED.nickname,
+			joined_at = COALESCE(EXCLUDED.joined_at, guild_members.joined_at),
+			updated_at = EXCLUDED.updated_at;
+	`
+
+	_, err := db.ExecContext(ctx, query, member.GuildID, member.UserID, member.Nickname, member.JoinedAt, member.UpdatedAt)
+	if err != nil {
+		return classify(fmt.Errorf("failed to upsert guild member: %w", err))
+	}
+	return nil
+}
+
+// GetGuildMember returns a user's membership in a guild, or nil if none was
+// recorded.
+func GetGuildMember(ctx context.Context, db *sql.DB, guildID, userID string) (*GuildMember, error) {
+	defer trace(ctx, "GetGuildMember")()
+
+	query := `
+		SELECT guild_id, user_id, COALESCE(nickname, ''), joined_at, updated_at
+		FROM guild_members
+		WHERE guild_id = $1 AND user_id = $2;
+	`
+
+	var member GuildMember
+	err := db.QueryRowContext(ctx, query, guildID, userID).Scan(
+		&member.GuildID, &member.UserID, &member.Nickname, &member.JoinedAt, &member.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to get guild member: %w", err))
+	}
+	return &member, nil
+}
+
+// GetUsernameHistory returns the usernames a user has gone by, oldest first.
+func GetUsernameHistory(ctx context.Context, db *sql.DB, userID string) (history []UsernameChange, err error) {
+	defer trace(ctx, "GetUsernameHistory")()
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT username, changed_at
+		FROM username_history
+		WHERE user_id = $1
+		ORDER BY changed_at, id;
+	`, userID)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to get username history: %w", err))
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close rows: %w", closeErr)
+		}
+	}()
+
+	for rows.Next() {
+		var change UsernameChange
+		if err := rows.Scan(&change.Username, &change.ChangedAt); err != nil {
+			return nil, classify(fmt.Errorf("scan username change: %w", err))
+		}
+		history = append(history, change)
+	}
+	return history, classify(rows.Err())
+}
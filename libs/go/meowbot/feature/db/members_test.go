@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertUser_RecordsRename(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT username FROM users WHERE id = $1 FOR UPDATE`)).
+		WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"username"}).AddRow("old"))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO users`)).
+		WithArgs("user-1", "new", "New", "hash").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO username_history`)).
+		WithArgs("user-1", "new").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := UpsertUser(context.Background(), mockDB, User{ID: "user-1", Username: "new", DisplayName: "New", Avatar: "hash"})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertUser_SameUsername(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT username FROM users`)).
+		WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"username"}).AddRow("same"))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO users`)).
+		WithArgs("user-1", "same", "", "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := UpsertUser(context.Background(), mockDB, User{ID: "user-1", Username: "same"})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetGuildMember_NotFound(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM guild_members`)).
+		WithArgs("guild-foo", "user-1").
+		WillReturnError(sql.ErrNoRows)
+
+	member, err := GetGuildMember(context.Background(), mockDB, "guild-foo", "user-1")
+	require.NoError(t, err)
+	require.Nil(t, member)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUsernameHistory(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	first, second := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM username_history`)).
+		WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"username", "changed_at"}).
+			AddRow("old", first).
+			AddRow("new", second))
+
+	history, err := GetUsernameHistory(context.Background(), mockDB, "user-1")
+	require.NoError(t, err)
+	require.Equal(t, []UsernameChange{{Username: "old", ChangedAt: first}, {Username: "new", ChangedAt: second}}, history)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,708 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStore is a Store that keeps everything in memory. It mirrors the
+// Postgres queries, including their error kinds, and is safe for concurrent
+// use.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	users    map[string]User
+	guilds   map[string]Guild
+	channels map[string]string
+	settings map[string]GuildSettings
+	streaks  map[string]GuildStreak
+	stats    map[statsKey]UserGuildStats
+
+	usernames    map[string][]UsernameChange
+	members      map[statsKey]GuildMember
+	achievements map[achievementKey]UserAchievement
+
+	seasons      map[int64]Season
+	seasonStats  map[seasonKey]UserGuildStats
+	standings    map[seasonKey]UserGuildStats // GuildID empty, as standings span guilds
+	lastSeasonID int64
+
+	balances     map[statsKey]int
+	ledger       []TreatLedgerEntry
+	items        map[itemKey]UserItem
+	itemRoles    map[itemKey]string // userID empty, as roles are per guild
+	lastLedgerID int64
+
+	events []MeowEvent
+	recaps map[string]RecapSettings
+
+	prefs        map[string]notificationPrefs
+	watchedRanks map[statsKey]int
+
+	bans        map[statsKey]MeowBan
+	audit       []AuditEntry
+	lastAuditID int64
+}
+
+type statsKey struct {
+	guildID, userID string
+}
+
+type achievementKey struct {
+	guildID, userID, achievementID string
+}
+
+type seasonKey struct {
+	seasonID        int64
+	guildID, userID string
+}
+
+type itemKey struct {
+	guildID, userID, itemID string
+}
+
+// notificationPrefs is a notification_prefs row.
+type notificationPrefs struct {
+	NotificationPrefs
+	lastNotifiedAt *time.Time
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		users:    make(map[string]User),
+		guilds:   make(map[string]Guild),
+		channels: make(map[string]string),
+		settings: make(map[string]GuildSettings),
+		streaks:  make(map[string]GuildStreak),
+		stats:    make(map[statsKey]UserGuildStats),
+
+		usernames:    make(map[string][]UsernameChange),
+		members:      make(map[statsKey]GuildMember),
+		achievements: make(map[achievementKey]UserAchievement),
+		seasons:      make(map[int64]Season),
+		seasonStats:  make(map[seasonKey]UserGuildStats),
+		standings:    make(map[seasonKey]UserGuildStats),
+		balances:     make(map[statsKey]int),
+		items:        make(map[itemKey]UserItem),
+		itemRoles:    make(map[itemKey]string),
+		recaps:       make(map[string]RecapSettings),
+		prefs:        make(map[string]notificationPrefs),
+		watchedRanks: make(map[statsKey]int),
+		bans:         make(map[statsKey]MeowBan),
+	}
+}
+
+// Ping always succeeds: memory is always there.
+func (m *MemoryStore) Ping(context.Context) error {
+	return nil
+}
+
+// metricValue reads the stats column a leaderboard or rank is ordered by.
+func metricValue(s UserGuildStats, metric Metric) int {
+	switch metric {
+	case MetricSuccessful:
+		return s.SuccessfulMeows
+	case MetricFailed:
+		return s.FailedMeows
+	case MetricHighestStreak:
+		return s.HighestStreak
+	case MetricCurrentStreak:
+		return s.CurrentStreak
+	}
+	return s.TotalMeows
+}
+
+func (m *MemoryStore) UpsertUser(_ context.Context, user User) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.users[user.ID]
+	previous := existing.Username
+	if ok {
+		existing.Username = user.Username
+		existing.DisplayName = user.DisplayName
+		existing.Avatar = user.Avatar
+		m.users[user.ID] = existing
+	} else {
+		m.users[user.ID] = User{ID: user.ID, Username: user.Username, DisplayName: user.DisplayName, Avatar: user.Avatar, CreatedAt: time.Now()}
+	}
+
+	if user.Username != "" && user.Username != previous {
+		m.usernames[user.ID] = append(m.usernames[user.ID], UsernameChange{Username: user.Username, ChangedAt: time.Now()})
+	}
+	return nil
+}
+
+func (m *MemoryStore) UpsertGuild(_ context.Context, guild Guild) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.guilds[guild.ID]; !ok {
+		m.guilds[guild.ID] = Guild{ID: guild.ID, Present: true, CreatedAt: time.Now()}
+	}
+	return nil
+}
+
+func (m *MemoryStore) SyncGuild(_ context.Context, guild Guild) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g, ok := m.guilds[guild.ID]
+	if !ok {
+		g = Guild{ID: guild.ID, CreatedAt: time.Now()}
+	}
+	g.Name, g.Icon, g.OwnerID = guild.Name, guild.Icon, guild.OwnerID
+	if guild.MemberCount != 0 {
+		g.MemberCount = guild.MemberCount
+	}
+	if guild.JoinedAt != nil {
+		joined := *guild.JoinedAt
+		g.JoinedAt = &joined
+	}
+	g.Present, g.LeftAt = true, nil
+	m.guilds[guild.ID] = g
+	return nil
+}
+
+func (m *MemoryStore) LeaveGuild(_ context.Context, guildID string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if g, ok := m.guilds[guildID]; ok {
+		g.Present, g.LeftAt = false, &at
+		m.guilds[guildID] = g
+	}
+	return nil
+}
+
+func (m *MemoryStore) GetGuild(_ context.Context, guildID string) (*Guild, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	g, ok := m.guilds[guildID]
+	if !ok {
+		return nil, classify(sql.ErrNoRows)
+	}
+	return &g, nil
+}
+
+func (m *MemoryStore) GetAllUsers(_ context.Context) ([]*User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var users []*User
+	for _, u := range m.users {
+		users = append(users, &u)
+	}
+	slices.SortFunc(users, func(a, b *User) int { return cmp.Compare(a.ID, b.ID) })
+	return users, nil
+}
+
+// DeleteUserData removes the user's personal data and hands the rest to a
+// stand-in, like the SQL store.
+func (m *MemoryStore) DeleteUserData(_ context.Context, userID string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[userID]
+	if !ok {
+		return "", classify(sql.ErrNoRows)
+	}
+	anonID := newDeletedUserID()
+	m.users[anonID] = User{ID: anonID, Username: deletedUsername, CreatedAt: user.CreatedAt}
+	delete(m.users, userID)
+	delete(m.usernames, userID)
+	delete(m.prefs, userID)
+
+	for key, s := range m.stats {
+		if key.userID == userID {
+			delete(m.stats, key)
+			s.UserID = anonID
+			m.stats[statsKey{key.guildID, anonID}] = s
+		}
+	}
+	for guildID, streak := range m.streaks {
+		if streak.LastUserID != nil && *streak.LastUserID == userID {
+			streak.LastUserID = &anonID
+		}
+		if streak.HighScoreUserID != nil && *streak.HighScoreUserID == userID {
+			streak.HighScoreUserID = &anonID
+		}
+		m.streaks[guildID] = streak
+	}
+	for _, seasonStats := range []map[seasonKey]UserGuildStats{m.seasonStats, m.standings} {
+		for key, s := range seasonStats {
+			if key.userID == userID {
+				delete(seasonStats, key)
+				s.UserID = anonID
+				seasonStats[seasonKey{key.seasonID, key.guildID, anonID}] = s
+			}
+		}
+	}
+	for i, e := range m.events {
+		if e.UserID == userID {
+			m.events[i].UserID = anonID
+		}
+	}
+
+	for key := range m.members {
+		if key.userID == userID {
+			delete(m.members, key)
+		}
+	}
+	for key := range m.achievements {
+		if key.userID == userID {
+			delete(m.achievements, key)
+		}
+	}
+	for key := range m.balances {
+		if key.userID == userID {
+			delete(m.balances, key)
+		}
+	}
+	m.ledger = slices.DeleteFunc(m.ledger, func(e TreatLedgerEntry) bool { return e.UserID == userID })
+	for key := range m.items {
+		if key.userID == userID {
+			delete(m.items, key)
+		}
+	}
+	for key := range m.watchedRanks {
+		if key.userID == userID {
+			delete(m.watchedRanks, key)
+		}
+	}
+	for key, ban := range m.bans {
+		switch {
+		case key.userID == userID:
+			delete(m.bans, key)
+		case ban.BannedBy == userID:
+			ban.BannedBy = anonID
+			m.bans[key] = ban
+		}
+	}
+
+	for i, e := range m.audit {
+		if e.ActorID == userID {
+			m.audit[i].ActorID = anonID
+		}
+		if e.Target == "<@"+userID+">" {
+			m.audit[i].Target = "<@" + anonID + ">"
+			m.audit[i].Before, m.audit[i].After = "", ""
+			continue
+		}
+		m.audit[i].Before = strings.ReplaceAll(e.Before, `"`+userID+`"`, `"`+anonID+`"`)
+		m.audit[i].After = strings.ReplaceAll(e.After, `"`+userID+`"`, `"`+anonID+`"`)
+	}
+	return anonID, nil
+}
+
+func (m *MemoryStore) GetAllGuilds(_ context.Context) ([]*Guild, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var guilds []*Guild
+	for _, g := range m.guilds {
+		guilds = append(guilds, &g)
+	}
+	slices.SortFunc(guilds, func(a, b *Guild) int { return cmp.Compare(a.ID, b.ID) })
+	return guilds, nil
+}
+
+func (m *MemoryStore) UpsertGuildChannel(_ context.Context, guildID, channelID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.channels[guildID] = channelID
+	return nil
+}
+
+func (m *MemoryStore) GetChannelForGuild(_ context.Context, guildID string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.channels[guildID], nil
+}
+
+func (m *MemoryStore) GetAllGuildChannels(_ context.Context) (map[string]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	channels := make(map[string]string, len(m.channels))
+	for guildID, channelID := range m.channels {
+		channels[guildID] = channelID
+	}
+	return channels, nil
+}
+
+func (m *MemoryStore) GetGuildSettings(_ context.Context, guildID string) (*GuildSettings, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	settings, ok := m.settings[guildID]
+	if !ok {
+		settings = GuildSettings{GuildID: guildID}
+	}
+	return &settings, nil
+}
+
+func (m *MemoryStore) UpsertGuildLocale(_ context.Context, guildID, locale string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	settings := m.settings[guildID]
+	settings.GuildID = guildID
+	settings.Locale = locale
+	m.settings[guildID] = settings
+	return nil
+}
+
+func (m *MemoryStore) UpsertGuildTimezone(_ context.Context, guildID, timezone string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	settings := m.settings[guildID]
+	settings.GuildID = guildID
+	settings.Timezone = timezone
+	m.settings[guildID] = settings
+	return nil
+}
+
+func (m *MemoryStore) IncrementMeow(_ context.Context, guildID, userID string, success bool, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkReferences(guildID, userID); err != nil {
+		return err
+	}
+
+	key := statsKey{guildID, userID}
+	s, ok := m.stats[key]
+	if !ok {
+		s = UserGuildStats{GuildID: guildID, UserID: userID}
+	}
+	s.TotalMeows++
+	if success {
+		s.SuccessfulMeows++
+		s.CurrentStreak++
+		s.HighestStreak = max(s.HighestStreak, s.CurrentStreak)
+		s.LastMeowAt = &now
+	} else {
+		s.FailedMeows++
+		s.CurrentStreak = 0
+		s.LastFailedMeowAt = &now
+	}
+	m.stats[key] = s
+	return nil
+}
+
+// checkReferences fails like a foreign key violation if the guild or, when
+// given, the user doesn't exist.
+func (m *MemoryStore) checkReferences(guildID, userID string) error {
+	if _, ok := m.guilds[guildID]; !ok {
+		return fmt.Errorf("%w: unknown guild %q", ErrInvalidInput, guildID)
+	}
+	if userID != "" {
+		return m.checkUser(userID)
+	}
+	return nil
+}
+
+// checkUser fails like a foreign key violation if the user doesn't exist.
+func (m *MemoryStore) checkUser(userID string) error {
+	if _, ok := m.users[userID]; !ok {
+		return fmt.Errorf("%w: unknown user %q", ErrInvalidInput, userID)
+	}
+	return nil
+}
+
+func (m *MemoryStore) GetGuildStreak(_ context.Context, guildID string) (*GuildStreak, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	streak, ok := m.streaks[guildID]
+	if !ok {
+		return nil, classify(sql.ErrNoRows)
+	}
+	return &streak, nil
+}
+
+func (m *MemoryStore) UpsertGuildStreak(_ context.Context, streak GuildStreak) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkReferences(streak.GuildID, ""); err != nil {
+		return err
+	}
+	m.streaks[streak.GuildID] = streak
+	return nil
+}
+
+func (m *MemoryStore) GetUserStats(_ context.Context, guildID *string, userID string) (UserGuildStats, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if guildID != nil {
+		s, ok := m.stats[statsKey{*guildID, userID}]
+		if !ok {
+			return UserGuildStats{GuildID: *guildID, UserID: userID}, nil
+		}
+		return s, nil
+	}
+
+	var (
+		total UserGuildStats
+		found bool
+	)
+	for key, s := range m.stats {
+		if key.userID != userID {
+			continue
+		}
+		found = true
+		total.SuccessfulMeows += s.SuccessfulMeows
+		total.FailedMeows += s.FailedMeows
+		total.TotalMeows += s.TotalMeows
+		total.CurrentStreak = max(total.CurrentStreak, s.CurrentStreak)
+		total.HighestStreak = max(total.HighestStreak, s.HighestStreak)
+		total.LastMeowAt = latest(total.LastMeowAt, s.LastMeowAt)
+		total.LastFailedMeowAt = latest(total.LastFailedMeowAt, s.LastFailedMeowAt)
+	}
+	if !found {
+		return UserGuildStats{}, classify(fmt.Errorf("GetUserStats: %w", sql.ErrNoRows))
+	}
+	total.UserID = userID
+	return total, nil
+}
+
+func latest(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.After(*a)) {
+		return b
+	}
+	return a
+}
+
+func (m *MemoryStore) GetUserGlobalStats(_ context.Context, userID string) (UserGlobalStats, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, ok := m.users[userID]
+	if !ok {
+		return UserGlobalStats{}, classify(sql.ErrNoRows)
+	}
+
+	res := UserGlobalStats{UserID: user.ID, Username: user.Username, CreatedAt: user.CreatedAt}
+	for key, s := range m.stats {
+		if key.userID != userID {
+			continue
+		}
+		res.SuccessfulMeows += s.SuccessfulMeows
+		res.FailedMeows += s.FailedMeows
+		res.TotalMeows += s.TotalMeows
+		res.HighestStreak = max(res.HighestStreak, s.HighestStreak)
+	}
+	return res, nil
+}
+
+func (m *MemoryStore) GetUserPerGuildStats(_ context.Context, userID string) ([]UserGuildStats, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var stats []UserGuildStats
+	for key, s := range m.stats {
+		if key.userID == userID {
+			stats = append(stats, s)
+		}
+	}
+	slices.SortFunc(stats, func(a, b UserGuildStats) int { return cmp.Compare(a.GuildID, b.GuildID) })
+	return stats, nil
+}
+
+func (m *MemoryStore) GetGlobalStats(_ context.Context) (*GlobalStats, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stats := GlobalStats{TotalGuilds: len(m.guilds), TotalUsers: len(m.users)}
+	for _, s := range m.stats {
+		stats.TotalMeows += s.TotalMeows
+	}
+	return &stats, nil
+}
+
+func (m *MemoryStore) GetGuildStats(_ context.Context, guildID string) (*GuildStats, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	streak, ok := m.streaks[guildID]
+	guild, guildOK := m.guilds[guildID]
+	if !ok || !guildOK {
+		return nil, classify(fmt.Errorf("failed to scan guild stats: %w", sql.ErrNoRows))
+	}
+
+	stats := GuildStats{
+		Guild:         &guild,
+		CurrentStreak: streak.MeowCount,
+		HighScore:     streak.HighScore,
+		LastUser:      m.lookupUser(streak.LastUserID),
+		HighScoreUser: m.lookupUser(streak.HighScoreUserID),
+	}
+	for key, s := range m.stats {
+		if key.guildID != guildID {
+			continue
+		}
+		stats.TotalMeows += s.TotalMeows
+		stats.SuccessfulMeows += s.SuccessfulMeows
+		stats.FailedMeows += s.FailedMeows
+		stats.Participants++
+	}
+	return &stats, nil
+}
+
+func (m *MemoryStore) lookupUser(id *string) *User {
+	if id == nil {
+		return nil
+	}
+	user, ok := m.users[*id]
+	if !ok {
+		return nil
+	}
+	return &user
+}
+
+func (m *MemoryStore) GetGuildTopMeower(_ context.Context, guildID string) (*GuildTopMeower, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var top *GuildTopMeower
+	for key, s := range m.stats {
+		user, ok := m.users[key.userID]
+		if key.guildID != guildID || !ok {
+			continue
+		}
+		if top == nil || s.TotalMeows > top.TotalMeows || (s.TotalMeows == top.TotalMeows && user.ID < top.User.ID) {
+			top = &GuildTopMeower{User: &user, TotalMeows: s.TotalMeows}
+		}
+	}
+	return top, nil
+}
+
+func (m *MemoryStore) GetGuildRank(_ context.Context, guildID string) (int, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	streak, ok := m.streaks[guildID]
+	if !ok {
+		return 0, 0, classify(fmt.Errorf("failed to get guild rank: %w", sql.ErrNoRows))
+	}
+	rank := 1
+	for _, other := range m.streaks {
+		if other.HighScore > streak.HighScore {
+			rank++
+		}
+	}
+	return rank, len(m.streaks), nil
+}
+
+// rankedPlayers sums the guild's stats, or everyone's, per user and ranks
+// them by metric the way the SQL "ranked" clause does.
+func (m *MemoryStore) rankedPlayers(guildID *string, metric Metric) []rankedEntry {
+	var rows []UserGuildStats
+	for key, s := range m.stats {
+		if guildID == nil || key.guildID == *guildID {
+			rows = append(rows, s)
+		}
+	}
+	return m.rank(rows, metric)
+}
+
+// rank sums rows per user and ranks the users by metric.
+func (m *MemoryStore) rank(rows []UserGuildStats, metric Metric) []rankedEntry {
+	byUser := make(map[string]*rankedEntry)
+	for _, s := range rows {
+		r, ok := byUser[s.UserID]
+		if !ok {
+			user := m.users[s.UserID]
+			r = &rankedEntry{LeaderboardEntry: LeaderboardEntry{User: &user}}
+			byUser[s.UserID] = r
+		}
+		r.SuccessfulMeows += s.SuccessfulMeows
+		r.FailedMeows += s.FailedMeows
+		r.TotalMeows += s.TotalMeows
+		r.value += metricValue(s, metric)
+	}
+
+	ranked := make([]rankedEntry, 0, len(byUser))
+	for _, r := range byUser {
+		ranked = append(ranked, *r)
+	}
+	slices.SortFunc(ranked, func(a, b rankedEntry) int {
+		return compareRanked(*a.cursor(), *b.cursor())
+	})
+	for i := range ranked {
+		ranked[i].position = i + 1
+		ranked[i].total = len(ranked)
+		ranked[i].Rank = i + 1
+		if i > 0 && ranked[i].value == ranked[i-1].value {
+			ranked[i].Rank = ranked[i-1].Rank
+		}
+	}
+	return ranked
+}
+
+// leaderboardPage selects the page q asks for from ranked, like
+// rankQuery.page does in SQL.
+func leaderboardPage(ranked []rankedEntry, q LeaderboardQuery) *LeaderboardPage {
+	from, to := pageRange(len(ranked), q, func(i int) LeaderboardCursor {
+		return *ranked[i].cursor()
+	})
+	return newLeaderboardPage(ranked[from:to])
+}
+
+func (m *MemoryStore) GetLeaderboard3(_ context.Context, limit int) ([]LeaderboardEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var entries []LeaderboardEntry
+	for _, r := range m.rankedPlayers(nil, MetricTotal) {
+		if len(entries) == limit {
+			break
+		}
+		entries = append(entries, LeaderboardEntry{User: r.User, TotalMeows: r.TotalMeows})
+	}
+	return entries, nil
+}
+
+func (m *MemoryStore) GetLeaderboard(_ context.Context, guildID *string, q LeaderboardQuery) (*LeaderboardPage, error) {
+	if _, err := q.Metric.column(); err != nil {
+		return nil, err
+	}
+	if err := q.validate(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return leaderboardPage(m.rankedPlayers(guildID, q.Metric), q), nil
+}
+
+func (m *MemoryStore) GetUserRank(_ context.Context, userID string, guildID *string, metric Metric) (int, error) {
+	if _, err := metric.column(); err != nil {
+		return 0, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, r := range m.rankedPlayers(guildID, metric) {
+		if r.User.ID == userID {
+			return r.Rank, nil
+		}
+	}
+	return 0, classify(sql.ErrNoRows)
+}
@@ -0,0 +1,531 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// rowOf turns a model into a column-value map by its JSON field names, with
+// extra columns the model doesn't carry.
+func rowOf(v any, extra map[string]any) map[string]any {
+	row := make(map[string]any)
+	if b, err := json.Marshal(v); err == nil {
+		_ = json.Unmarshal(b, &row)
+	}
+	maps.Copy(row, extra)
+	return row
+}
+
+// ExportUserData collects what the store keeps about the user under the same
+// keys as the SQL store. The rows hold the fields of the models rather than
+// the table columns.
+func (m *MemoryStore) ExportUserData(_ context.Context, userID string) (*UserData, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	data := &UserData{UserID: userID, ExportedAt: time.Now().UTC(), Tables: make(map[string][]map[string]any)}
+	add := func(table string, v any, extra map[string]any) {
+		data.Tables[table] = append(data.Tables[table], rowOf(v, extra))
+	}
+
+	if user, ok := m.users[userID]; ok {
+		add("users", user, nil)
+	}
+	for _, change := range m.usernames[userID] {
+		add("username_history", change, map[string]any{"user_id": userID})
+	}
+	for key, member := range m.members {
+		if key.userID == userID {
+			add("guild_members", member, nil)
+		}
+	}
+	for key, s := range m.stats {
+		if key.userID == userID {
+			add("user_guild_stats", s, nil)
+		}
+	}
+	for _, streak := range m.streaks {
+		if streak.LastUserID != nil && *streak.LastUserID == userID {
+			add("guild_streaks.last_user_id", streak, nil)
+		}
+		if streak.HighScoreUserID != nil && *streak.HighScoreUserID == userID {
+			add("guild_streaks.high_score_user_id", streak, nil)
+		}
+	}
+	for key, a := range m.achievements {
+		if key.userID == userID {
+			add("user_achievements", a, nil)
+		}
+	}
+	for key, s := range m.seasonStats {
+		if key.userID == userID {
+			add("season_stats", s, map[string]any{"season_id": key.seasonID})
+		}
+	}
+	for key, s := range m.standings {
+		if key.userID == userID {
+			add("season_standings", s, map[string]any{"season_id": key.seasonID})
+		}
+	}
+	for key, balance := range m.balances {
+		if key.userID == userID {
+			add("treat_balances", struct{}{}, map[string]any{"guild_id": key.guildID, "user_id": userID, "balance": balance})
+		}
+	}
+	for _, e := range m.ledger {
+		if e.UserID == userID {
+			add("treat_ledger", e, nil)
+		}
+	}
+	for key, item := range m.items {
+		if key.userID == userID {
+			add("user_items", item, nil)
+		}
+	}
+	for _, e := range m.events {
+		if e.UserID == userID {
+			add("meow_events", e, nil)
+		}
+	}
+	if prefs, ok := m.prefs[userID]; ok {
+		add("notification_prefs", prefs.NotificationPrefs, map[string]any{"last_notified_at": prefs.lastNotifiedAt})
+	}
+	for key, rank := range m.watchedRanks {
+		if key.userID == userID {
+			add("notification_ranks", struct{}{}, map[string]any{"guild_id": key.guildID, "user_id": userID, "rank": rank})
+		}
+	}
+	for key, ban := range m.bans {
+		if key.userID == userID {
+			add("meow_bans", ban, nil)
+		}
+		if ban.BannedBy == userID {
+			add("meow_bans.banned_by", ban, nil)
+		}
+	}
+	for _, e := range m.audit {
+		if e.ActorID == userID {
+			add("audit_log.actor_id", e, nil)
+		}
+		if e.Target == "<@"+userID+">" {
+			add("audit_log.target", e, nil)
+		}
+	}
+
+	if len(data.Tables) == 0 {
+		return nil, classify(sql.ErrNoRows)
+	}
+	return data, nil
+}
+
+func (m *MemoryStore) ExportGuild(ctx context.Context, guildID string) (*GuildExport, error) {
+	guild, err := m.GetGuild(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+	export := &GuildExport{Version: GuildExportVersion, ExportedAt: time.Now().UTC(), Guild: *guild}
+	if export.ChannelID, err = m.GetChannelForGuild(ctx, guildID); err != nil {
+		return nil, err
+	}
+	settings, err := m.GetGuildSettings(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+	export.Settings = *settings
+	if export.Recap, err = m.GetGuildRecapSettings(ctx, guildID); err != nil {
+		return nil, err
+	}
+	export.Streak, err = m.GetGuildStreak(ctx, guildID)
+	if errors.Is(err, ErrNotFound) {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	players := make(map[string]bool)
+	export.Stats = []UserGuildStats{}
+	for key, s := range m.stats {
+		if key.guildID == guildID {
+			export.Stats = append(export.Stats, s)
+			players[key.userID] = true
+		}
+	}
+	slices.SortFunc(export.Stats, func(a, b UserGuildStats) int { return cmp.Compare(a.UserID, b.UserID) })
+
+	export.Members = []GuildMember{}
+	for key, member := range m.members {
+		if key.guildID == guildID {
+			export.Members = append(export.Members, member)
+			players[key.userID] = true
+		}
+	}
+	slices.SortFunc(export.Members, func(a, b GuildMember) int { return cmp.Compare(a.UserID, b.UserID) })
+
+	export.Achievements = []UserAchievement{}
+	for key, a := range m.achievements {
+		if key.guildID == guildID {
+			export.Achievements = append(export.Achievements, a)
+			players[key.userID] = true
+		}
+	}
+	slices.SortFunc(export.Achievements, func(a, b UserAchievement) int {
+		if c := cmp.Compare(a.UserID, b.UserID); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.AchievementID, b.AchievementID)
+	})
+
+	export.Bans = []MeowBan{}
+	for key, ban := range m.bans {
+		if key.guildID == guildID {
+			export.Bans = append(export.Bans, ban)
+			players[key.userID] = true
+		}
+	}
+	slices.SortFunc(export.Bans, func(a, b MeowBan) int { return cmp.Compare(a.UserID, b.UserID) })
+
+	export.Balances = []TreatBalance{}
+	for key, balance := range m.balances {
+		if key.guildID == guildID {
+			export.Balances = append(export.Balances, TreatBalance{GuildID: guildID, UserID: key.userID, Balance: balance})
+			players[key.userID] = true
+		}
+	}
+	slices.SortFunc(export.Balances, func(a, b TreatBalance) int { return cmp.Compare(a.UserID, b.UserID) })
+
+	export.Ledger = []TreatLedgerEntry{}
+	for _, e := range m.ledger {
+		if e.GuildID == guildID {
+			export.Ledger = append(export.Ledger, e)
+			players[e.UserID] = true
+		}
+	}
+
+	export.Items = []UserItem{}
+	for key, item := range m.items {
+		if key.guildID == guildID {
+			export.Items = append(export.Items, item)
+			players[key.userID] = true
+		}
+	}
+	slices.SortFunc(export.Items, func(a, b UserItem) int {
+		if c := cmp.Compare(a.UserID, b.UserID); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ItemID, b.ItemID)
+	})
+
+	export.ItemRoles = []ItemRole{}
+	for key, roleID := range m.itemRoles {
+		if key.guildID == guildID {
+			export.ItemRoles = append(export.ItemRoles, ItemRole{GuildID: guildID, ItemID: key.itemID, RoleID: roleID})
+		}
+	}
+	slices.SortFunc(export.ItemRoles, func(a, b ItemRole) int { return cmp.Compare(a.ItemID, b.ItemID) })
+
+	export.Seasons = []Season{}
+	export.Standings = []SeasonStanding{}
+	for _, id := range slices.Sorted(maps.Keys(m.seasons)) {
+		season := m.seasons[id]
+		if season.GuildID == nil || *season.GuildID != guildID {
+			continue
+		}
+		export.Seasons = append(export.Seasons, season)
+		if season.ClosedAt == nil {
+			continue
+		}
+		// Standings are ranked by total meows, as CloseSeason ranks them.
+		for _, r := range m.rankedSeason(&season, MetricTotal) {
+			export.Standings = append(export.Standings, SeasonStanding{
+				SeasonID:        id,
+				UserID:          r.User.ID,
+				Rank:            r.Rank,
+				SuccessfulMeows: r.SuccessfulMeows,
+				FailedMeows:     r.FailedMeows,
+				TotalMeows:      r.TotalMeows,
+			})
+			players[r.User.ID] = true
+		}
+	}
+
+	export.SeasonStats = []SeasonStats{}
+	for key, s := range m.seasonStats {
+		if key.guildID == guildID {
+			export.SeasonStats = append(export.SeasonStats, SeasonStats{
+				SeasonID:        key.seasonID,
+				GuildID:         guildID,
+				UserID:          key.userID,
+				SuccessfulMeows: s.SuccessfulMeows,
+				FailedMeows:     s.FailedMeows,
+				TotalMeows:      s.TotalMeows,
+			})
+			players[key.userID] = true
+		}
+	}
+	slices.SortFunc(export.SeasonStats, func(a, b SeasonStats) int {
+		if c := cmp.Compare(a.SeasonID, b.SeasonID); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.UserID, b.UserID)
+	})
+
+	export.Users = []User{}
+	for _, userID := range slices.Sorted(maps.Keys(players)) {
+		if user, ok := m.users[userID]; ok {
+			export.Users = append(export.Users, user)
+		}
+	}
+	return export, nil
+}
+
+// guildRows counts the guild's rows in each of guildTables.
+func (m *MemoryStore) guildRows(guildID string) map[string]int {
+	rows := make(map[string]int, len(guildTables))
+	if _, ok := m.channels[guildID]; ok {
+		rows["guild_channels"]++
+	}
+	if _, ok := m.settings[guildID]; ok {
+		rows["guild_settings"]++
+	}
+	if _, ok := m.recaps[guildID]; ok {
+		rows["recap_settings"]++
+	}
+	if _, ok := m.streaks[guildID]; ok {
+		rows["guild_streaks"]++
+	}
+	for key := range m.stats {
+		if key.guildID == guildID {
+			rows["user_guild_stats"]++
+		}
+	}
+	for key := range m.members {
+		if key.guildID == guildID {
+			rows["guild_members"]++
+		}
+	}
+	for key := range m.achievements {
+		if key.guildID == guildID {
+			rows["user_achievements"]++
+		}
+	}
+	for key := range m.bans {
+		if key.guildID == guildID {
+			rows["meow_bans"]++
+		}
+	}
+	for key := range m.balances {
+		if key.guildID == guildID {
+			rows["treat_balances"]++
+		}
+	}
+	for _, e := range m.ledger {
+		if e.GuildID == guildID {
+			rows["treat_ledger"]++
+		}
+	}
+	for key := range m.items {
+		if key.guildID == guildID {
+			rows["user_items"]++
+		}
+	}
+	for key := range m.itemRoles {
+		if key.guildID == guildID {
+			rows["item_roles"]++
+		}
+	}
+	for key := range m.standings {
+		if m.guildSeason(key.seasonID, guildID) {
+			rows["season_standings"]++
+		}
+	}
+	for key := range m.seasonStats {
+		if key.guildID == guildID {
+			rows["season_stats"]++
+		}
+	}
+	for id := range m.seasons {
+		if m.guildSeason(id, guildID) {
+			rows["seasons"]++
+		}
+	}
+	return rows
+}
+
+// guildSeason reports whether the season is the guild's own.
+func (m *MemoryStore) guildSeason(seasonID int64, guildID string) bool {
+	season, ok := m.seasons[seasonID]
+	return ok && season.GuildID != nil && *season.GuildID == guildID
+}
+
+// clearGuild deletes the guild's rows in each of guildTables.
+func (m *MemoryStore) clearGuild(guildID string) {
+	delete(m.channels, guildID)
+	delete(m.settings, guildID)
+	delete(m.recaps, guildID)
+	delete(m.streaks, guildID)
+	maps.DeleteFunc(m.stats, func(key statsKey, _ UserGuildStats) bool { return key.guildID == guildID })
+	maps.DeleteFunc(m.members, func(key statsKey, _ GuildMember) bool { return key.guildID == guildID })
+	maps.DeleteFunc(m.achievements, func(key achievementKey, _ UserAchievement) bool { return key.guildID == guildID })
+	maps.DeleteFunc(m.bans, func(key statsKey, _ MeowBan) bool { return key.guildID == guildID })
+	maps.DeleteFunc(m.balances, func(key statsKey, _ int) bool { return key.guildID == guildID })
+	m.ledger = slices.DeleteFunc(m.ledger, func(e TreatLedgerEntry) bool { return e.GuildID == guildID })
+	maps.DeleteFunc(m.items, func(key itemKey, _ UserItem) bool { return key.guildID == guildID })
+	maps.DeleteFunc(m.itemRoles, func(key itemKey, _ string) bool { return key.guildID == guildID })
+	maps.DeleteFunc(m.standings, func(key seasonKey, _ UserGuildStats) bool { return m.guildSeason(key.seasonID, guildID) })
+	maps.DeleteFunc(m.seasonStats, func(key seasonKey, _ UserGuildStats) bool { return key.guildID == guildID })
+	maps.DeleteFunc(m.seasons, func(id int64, _ Season) bool { return m.guildSeason(id, guildID) })
+}
+
+// openGlobalSeason reports whether the season is global and still open.
+func (m *MemoryStore) openGlobalSeason(seasonID int64) bool {
+	season, ok := m.seasons[seasonID]
+	return ok && season.GuildID == nil && season.ClosedAt == nil
+}
+
+// ImportGuild replaces the guild's rows like the SQL store. A dry run only
+// checks the export and counts the conflicts.
+func (m *MemoryStore) ImportGuild(_ context.Context, export *GuildExport, dryRun bool) (*ImportReport, error) {
+	report := newImportReport(export, dryRun)
+	if report.Problems = export.validate(); len(report.Problems) > 0 {
+		return report, fmt.Errorf("%w: guild export has %d problems", ErrInvalidInput, len(report.Problems))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	guildID := export.Guild.ID
+	rows := m.guildRows(guildID)
+	for _, table := range guildTables {
+		if rows[table.name] > 0 {
+			report.Conflicts = append(report.Conflicts, ImportConflict{Table: table.name, Rows: rows[table.name]})
+		}
+	}
+	exported := make(map[int64]bool, len(export.Seasons))
+	for _, s := range export.Seasons {
+		exported[s.ID] = true
+	}
+	for _, s := range export.SeasonStats {
+		if !exported[s.SeasonID] && !m.openGlobalSeason(s.SeasonID) {
+			report.SkippedSeasonStats++
+		}
+	}
+	if dryRun {
+		return report, nil
+	}
+	m.clearGuild(guildID)
+
+	g := export.Guild
+	guild, ok := m.guilds[guildID]
+	if !ok {
+		guild = Guild{ID: guildID, Present: true, JoinedAt: g.JoinedAt, CreatedAt: g.CreatedAt}
+	}
+	guild.Name = cmp.Or(g.Name, guild.Name)
+	guild.Icon = cmp.Or(g.Icon, guild.Icon)
+	guild.MemberCount = cmp.Or(g.MemberCount, guild.MemberCount)
+	guild.OwnerID = cmp.Or(g.OwnerID, guild.OwnerID)
+	if guild.JoinedAt == nil {
+		guild.JoinedAt = g.JoinedAt
+	}
+	m.guilds[guildID] = guild
+
+	for _, u := range export.Users {
+		if _, ok := m.users[u.ID]; ok {
+			continue
+		}
+		m.users[u.ID] = u
+		if u.Username != "" {
+			m.usernames[u.ID] = append(m.usernames[u.ID], UsernameChange{Username: u.Username, ChangedAt: u.CreatedAt})
+		}
+	}
+
+	if export.ChannelID != "" {
+		m.channels[guildID] = export.ChannelID
+	}
+	if s := export.Settings; s.Locale != "" || s.Timezone != "" {
+		m.settings[guildID] = GuildSettings{GuildID: guildID, Locale: s.Locale, Timezone: s.Timezone}
+	}
+	if r := export.Recap; r != nil {
+		m.recaps[guildID] = RecapSettings{GuildID: guildID, Frequency: r.Frequency, ChannelID: r.ChannelID, LastPeriodEnd: r.LastPeriodEnd}
+	}
+	if s := export.Streak; s != nil {
+		streak := *s
+		streak.GuildID = guildID
+		m.streaks[guildID] = streak
+	}
+	for _, s := range export.Stats {
+		s.GuildID = guildID
+		m.stats[statsKey{guildID, s.UserID}] = s
+	}
+	for _, member := range export.Members {
+		member.GuildID = guildID
+		m.members[statsKey{guildID, member.UserID}] = member
+	}
+	for _, a := range export.Achievements {
+		a.GuildID = guildID
+		m.achievements[achievementKey{guildID, a.UserID, a.AchievementID}] = a
+	}
+	for _, ban := range export.Bans {
+		ban.GuildID = guildID
+		m.bans[statsKey{guildID, ban.UserID}] = ban
+	}
+
+	for _, b := range export.Balances {
+		m.balances[statsKey{guildID, b.UserID}] = b.Balance
+	}
+	for _, e := range export.Ledger {
+		m.lastLedgerID++
+		e.ID = m.lastLedgerID
+		e.GuildID = guildID
+		m.ledger = append(m.ledger, e)
+	}
+	for _, item := range export.Items {
+		item.GuildID = guildID
+		m.items[itemKey{guildID, item.UserID, item.ItemID}] = item
+	}
+	for _, r := range export.ItemRoles {
+		m.itemRoles[itemKey{guildID, "", r.ItemID}] = r.RoleID
+	}
+
+	seasonIDs := make(map[int64]int64, len(export.Seasons))
+	for _, s := range export.Seasons {
+		m.lastSeasonID++
+		seasonIDs[s.ID] = m.lastSeasonID
+		m.seasons[m.lastSeasonID] = Season{ID: m.lastSeasonID, GuildID: &guildID, Name: s.Name, StartsAt: s.StartsAt, EndsAt: s.EndsAt, ClosedAt: s.ClosedAt}
+	}
+	for _, s := range export.SeasonStats {
+		seasonID, ok := seasonIDs[s.SeasonID]
+		if !ok {
+			if !m.openGlobalSeason(s.SeasonID) {
+				continue
+			}
+			seasonID = s.SeasonID
+		}
+		m.seasonStats[seasonKey{seasonID, guildID, s.UserID}] = UserGuildStats{
+			GuildID:         guildID,
+			UserID:          s.UserID,
+			SuccessfulMeows: s.SuccessfulMeows,
+			FailedMeows:     s.FailedMeows,
+			TotalMeows:      s.TotalMeows,
+		}
+	}
+	for _, s := range export.Standings {
+		m.standings[seasonKey{seasonIDs[s.SeasonID], "", s.UserID}] = UserGuildStats{
+			UserID:          s.UserID,
+			SuccessfulMeows: s.SuccessfulMeows,
+			FailedMeows:     s.FailedMeows,
+			TotalMeows:      s.TotalMeows,
+		}
+	}
+	return report, nil
+}
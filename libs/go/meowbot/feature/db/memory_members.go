@@ -0,0 +1,152 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+)
+
+func (m *MemoryStore) UpsertGuildMember(_ context.Context, member GuildMember) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkReferences(member.GuildID, member.UserID); err != nil {
+		return err
+	}
+	key := statsKey{member.GuildID, member.UserID}
+	if previous, ok := m.members[key]; ok && member.JoinedAt == nil {
+		member.JoinedAt = previous.JoinedAt
+	}
+	m.members[key] = member
+	return nil
+}
+
+func (m *MemoryStore) GetGuildMember(_ context.Context, guildID, userID string) (*GuildMember, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	member, ok := m.members[statsKey{guildID, userID}]
+	if !ok {
+		return nil, nil
+	}
+	return &member, nil
+}
+
+func (m *MemoryStore) GetUsernameHistory(_ context.Context, userID string) ([]UsernameChange, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return slices.Clone(m.usernames[userID]), nil
+}
+
+func (m *MemoryStore) UnlockAchievement(_ context.Context, guildID, userID, achievementID string, at time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkReferences(guildID, userID); err != nil {
+		return false, err
+	}
+	key := achievementKey{guildID, userID, achievementID}
+	if _, ok := m.achievements[key]; ok {
+		return false, nil
+	}
+	m.achievements[key] = UserAchievement{GuildID: guildID, UserID: userID, AchievementID: achievementID, UnlockedAt: at}
+	return true, nil
+}
+
+func (m *MemoryStore) GetUserAchievements(_ context.Context, userID string, guildID *string) ([]UserAchievement, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	first := make(map[string]UserAchievement)
+	var achievements []UserAchievement
+	for key, a := range m.achievements {
+		switch {
+		case key.userID != userID:
+		case guildID != nil:
+			if key.guildID == *guildID {
+				achievements = append(achievements, a)
+			}
+		default:
+			if earlier, ok := first[key.achievementID]; !ok || a.UnlockedAt.Before(earlier.UnlockedAt) {
+				a.GuildID = ""
+				first[key.achievementID] = a
+			}
+		}
+	}
+	if guildID == nil {
+		for _, a := range first {
+			achievements = append(achievements, a)
+		}
+	}
+	slices.SortFunc(achievements, func(a, b UserAchievement) int {
+		if c := a.UnlockedAt.Compare(b.UnlockedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.AchievementID, b.AchievementID)
+	})
+	return achievements, nil
+}
+
+func (m *MemoryStore) GetNotificationPrefs(_ context.Context, userID string) (*NotificationPrefs, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	prefs, ok := m.prefs[userID]
+	if !ok {
+		return &NotificationPrefs{UserID: userID}, nil
+	}
+	return &prefs.NotificationPrefs, nil
+}
+
+func (m *MemoryStore) UpsertNotificationPrefs(_ context.Context, prefs NotificationPrefs) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkUser(prefs.UserID); err != nil {
+		return err
+	}
+	row := m.prefs[prefs.UserID]
+	row.NotificationPrefs = prefs
+	m.prefs[prefs.UserID] = row
+	return nil
+}
+
+func (m *MemoryStore) GetRankWatchers(_ context.Context, guildID string) ([]RankWatcher, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var watchers []RankWatcher
+	for _, r := range m.rankedPlayers(&guildID, MetricTotal) {
+		if prefs, ok := m.prefs[r.User.ID]; ok && prefs.RankChanges {
+			watchers = append(watchers, RankWatcher{UserID: r.User.ID, LastRank: m.watchedRanks[statsKey{guildID, r.User.ID}], Rank: r.Rank})
+		}
+	}
+	slices.SortFunc(watchers, func(a, b RankWatcher) int { return cmp.Compare(a.UserID, b.UserID) })
+	return watchers, nil
+}
+
+func (m *MemoryStore) SetWatchedRank(_ context.Context, guildID, userID string, rank int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkReferences(guildID, userID); err != nil {
+		return err
+	}
+	m.watchedRanks[statsKey{guildID, userID}] = rank
+	return nil
+}
+
+func (m *MemoryStore) ClaimNotification(_ context.Context, userID string, now time.Time, minInterval time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prefs, ok := m.prefs[userID]
+	if !ok || (prefs.lastNotifiedAt != nil && prefs.lastNotifiedAt.After(now.Add(-minInterval))) {
+		return false, nil
+	}
+	prefs.lastNotifiedAt = &now
+	m.prefs[userID] = prefs
+	return true, nil
+}
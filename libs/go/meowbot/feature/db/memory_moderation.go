@@ -0,0 +1,103 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+)
+
+func (m *MemoryStore) BanUser(_ context.Context, ban MeowBan) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkReferences(ban.GuildID, ban.UserID); err != nil {
+		return err
+	}
+	m.bans[statsKey{ban.GuildID, ban.UserID}] = ban
+	return nil
+}
+
+func (m *MemoryStore) UnbanUser(_ context.Context, guildID, userID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := statsKey{guildID, userID}
+	_, ok := m.bans[key]
+	delete(m.bans, key)
+	return ok, nil
+}
+
+// activeAt reports whether the ban hasn't expired by at.
+func (b MeowBan) activeAt(at time.Time) bool {
+	return b.ExpiresAt == nil || b.ExpiresAt.After(at)
+}
+
+func (m *MemoryStore) GetActiveBan(_ context.Context, guildID, userID string, at time.Time) (*MeowBan, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ban, ok := m.bans[statsKey{guildID, userID}]
+	if !ok || !ban.activeAt(at) {
+		return nil, nil
+	}
+	return &ban, nil
+}
+
+func (m *MemoryStore) ListBans(_ context.Context, guildID *string, at time.Time) ([]MeowBan, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	bans := []MeowBan{}
+	for key, ban := range m.bans {
+		if ban.activeAt(at) && (guildID == nil || key.guildID == *guildID) {
+			bans = append(bans, ban)
+		}
+	}
+	slices.SortFunc(bans, func(a, b MeowBan) int {
+		if c := cmp.Compare(a.GuildID, b.GuildID); c != 0 {
+			return c
+		}
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return bans, nil
+}
+
+func (m *MemoryStore) RecordAudit(_ context.Context, entry AuditEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastAuditID++
+	entry.ID = m.lastAuditID
+	m.audit = append(m.audit, entry)
+	return nil
+}
+
+func (m *MemoryStore) GetAuditLog(_ context.Context, filter AuditFilter) ([]AuditEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var matches []AuditEntry
+	for _, e := range m.audit {
+		if (filter.GuildID == "" || e.GuildID == filter.GuildID) &&
+			(filter.ActorID == "" || e.ActorID == filter.ActorID) &&
+			(filter.Since.IsZero() || !e.CreatedAt.Before(filter.Since)) &&
+			(filter.Until.IsZero() || e.CreatedAt.Before(filter.Until)) {
+			matches = append(matches, e)
+		}
+	}
+	slices.SortFunc(matches, func(a, b AuditEntry) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.ID, a.ID)
+	})
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+	from := min(max(filter.Offset, 0), len(matches))
+	to := min(from+limit, len(matches))
+	return append([]AuditEntry{}, matches[from:to]...), nil
+}
@@ -0,0 +1,136 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+)
+
+func (m *MemoryStore) RecordMeowEvent(_ context.Context, event MeowEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkReferences(event.GuildID, event.UserID); err != nil {
+		return err
+	}
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *MemoryStore) UpsertRecapSettings(_ context.Context, settings RecapSettings) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkReferences(settings.GuildID, ""); err != nil {
+		return err
+	}
+	if previous, ok := m.recaps[settings.GuildID]; ok {
+		settings.LastPeriodEnd = latest(previous.LastPeriodEnd, settings.LastPeriodEnd)
+	}
+	m.recaps[settings.GuildID] = settings
+	return nil
+}
+
+func (m *MemoryStore) DeleteRecapSettings(_ context.Context, guildID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.recaps[guildID]
+	delete(m.recaps, guildID)
+	return ok, nil
+}
+
+func (m *MemoryStore) GetGuildRecapSettings(_ context.Context, guildID string) (*RecapSettings, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	settings, ok := m.recaps[guildID]
+	if !ok {
+		return nil, nil
+	}
+	return &settings, nil
+}
+
+func (m *MemoryStore) GetRecapSettings(_ context.Context) ([]RecapSettings, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var settings []RecapSettings
+	for _, rs := range m.recaps {
+		settings = append(settings, rs)
+	}
+	slices.SortFunc(settings, func(a, b RecapSettings) int { return cmp.Compare(a.GuildID, b.GuildID) })
+	return settings, nil
+}
+
+func (m *MemoryStore) ClaimRecapPeriod(_ context.Context, guildID string, periodEnd time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	settings, ok := m.recaps[guildID]
+	if !ok || (settings.LastPeriodEnd != nil && !settings.LastPeriodEnd.Before(periodEnd)) {
+		return false, nil
+	}
+	settings.LastPeriodEnd = &periodEnd
+	m.recaps[guildID] = settings
+	return true, nil
+}
+
+func (m *MemoryStore) GetGuildRecap(_ context.Context, guildID string, start, end time.Time, topN int) (*GuildRecap, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	recap := &GuildRecap{Start: start, End: end}
+	successful := make(map[string]int)
+	var breaker *MeowEvent
+	// A record counts once per chain, where the previous event wasn't a high
+	// score.
+	events := slices.Clone(m.events)
+	slices.SortStableFunc(events, func(a, b MeowEvent) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	continuesRecord := false
+	for _, e := range events {
+		if e.GuildID != guildID || !e.CreatedAt.Before(end) {
+			continue
+		}
+		newRecord := e.HighScore && !continuesRecord
+		continuesRecord = e.HighScore
+		if e.CreatedAt.Before(start) {
+			continue
+		}
+		recap.TotalMeows++
+		recap.BestStreak = max(recap.BestStreak, e.Chain)
+		if newRecord {
+			recap.NewRecords++
+		}
+		if e.Success {
+			recap.SuccessfulMeows++
+			successful[e.UserID]++
+		}
+		if e.Broken > 0 && (breaker == nil || e.Broken > breaker.Broken ||
+			(e.Broken == breaker.Broken && e.CreatedAt.Before(breaker.CreatedAt))) {
+			breaker = &e
+		}
+	}
+
+	for userID, n := range successful {
+		if user, ok := m.users[userID]; ok {
+			recap.TopContributors = append(recap.TopContributors, LeaderboardEntry{User: &user, SuccessfulMeows: n})
+		}
+	}
+	slices.SortFunc(recap.TopContributors, func(a, b LeaderboardEntry) int {
+		if c := cmp.Compare(b.SuccessfulMeows, a.SuccessfulMeows); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.User.ID, b.User.ID)
+	})
+	recap.TopContributors = recap.TopContributors[:min(max(topN, 0), len(recap.TopContributors))]
+
+	if breaker != nil {
+		recap.Breaker = m.lookupUser(&breaker.UserID)
+		if recap.Breaker != nil {
+			recap.BrokenStreak = breaker.Broken
+		}
+	}
+	return recap, nil
+}
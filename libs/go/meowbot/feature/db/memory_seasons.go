@@ -0,0 +1,228 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// sameScope reports whether two seasons belong to the same guild, or are
+// both global.
+func sameScope(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// inScope reports whether a season applies to the guild: it is the guild's
+// own season or a global one.
+func inScope(season Season, guildID *string) bool {
+	return season.GuildID == nil || (guildID != nil && *season.GuildID == *guildID)
+}
+
+func (m *MemoryStore) CreateSeason(_ context.Context, season Season) (*Season, error) {
+	if !season.EndsAt.After(season.StartsAt) {
+		return nil, fmt.Errorf("%w: season must end after it starts", ErrInvalidInput)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if season.GuildID != nil {
+		if err := m.checkReferences(*season.GuildID, ""); err != nil {
+			return nil, err
+		}
+	}
+	for _, other := range m.seasons {
+		if sameScope(other.GuildID, season.GuildID) && other.ClosedAt == nil &&
+			other.StartsAt.Before(season.EndsAt) && other.EndsAt.After(season.StartsAt) {
+			return nil, fmt.Errorf("%w: season overlaps an existing season", ErrInvalidInput)
+		}
+	}
+
+	m.lastSeasonID++
+	created := Season{ID: m.lastSeasonID, GuildID: season.GuildID, Name: season.Name, StartsAt: season.StartsAt, EndsAt: season.EndsAt}
+	m.seasons[created.ID] = created
+	return &created, nil
+}
+
+func (m *MemoryStore) GetSeason(_ context.Context, id int64) (*Season, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	season, ok := m.seasons[id]
+	if !ok {
+		return nil, classify(fmt.Errorf("failed to get season: %w", sql.ErrNoRows))
+	}
+	return &season, nil
+}
+
+// running reports whether the season is open at the given time.
+func running(season Season, at time.Time) bool {
+	return season.ClosedAt == nil && !season.StartsAt.After(at) && season.EndsAt.After(at)
+}
+
+func (m *MemoryStore) GetActiveSeason(_ context.Context, guildID *string, at time.Time) (*Season, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var active *Season
+	for _, season := range m.seasons {
+		if !inScope(season, guildID) || !running(season, at) {
+			continue
+		}
+		if active == nil || active.GuildID == nil {
+			active = &season
+		}
+	}
+	return active, nil
+}
+
+// sortSeasons orders seasons like ListSeasons: newest first.
+func sortSeasons(seasons []Season) {
+	slices.SortFunc(seasons, func(a, b Season) int {
+		if c := b.StartsAt.Compare(a.StartsAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.ID, a.ID)
+	})
+}
+
+func (m *MemoryStore) ListSeasons(_ context.Context, guildID *string) ([]Season, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var seasons []Season
+	for _, season := range m.seasons {
+		if inScope(season, guildID) {
+			seasons = append(seasons, season)
+		}
+	}
+	sortSeasons(seasons)
+	return seasons, nil
+}
+
+func (m *MemoryStore) GetDueSeasons(_ context.Context, now time.Time) ([]Season, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var seasons []Season
+	for _, season := range m.seasons {
+		if season.ClosedAt == nil && !season.EndsAt.After(now) {
+			seasons = append(seasons, season)
+		}
+	}
+	slices.SortFunc(seasons, func(a, b Season) int {
+		if c := a.EndsAt.Compare(b.EndsAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return seasons, nil
+}
+
+func (m *MemoryStore) IncrementSeasonMeow(_ context.Context, guildID, userID string, success bool, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, season := range m.seasons {
+		if !inScope(season, &guildID) || !running(season, now) {
+			continue
+		}
+		if err := m.checkReferences(guildID, userID); err != nil {
+			return err
+		}
+
+		key := seasonKey{season.ID, guildID, userID}
+		s := m.seasonStats[key]
+		s.GuildID, s.UserID = guildID, userID
+		s.TotalMeows++
+		if success {
+			s.SuccessfulMeows++
+		} else {
+			s.FailedMeows++
+		}
+		m.seasonStats[key] = s
+	}
+	return nil
+}
+
+// CloseSeason archives the standings the way the SQL store does: one row per
+// user, summed across guilds.
+func (m *MemoryStore) CloseSeason(_ context.Context, seasonID int64, now time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	season, ok := m.seasons[seasonID]
+	if !ok || season.ClosedAt != nil {
+		return false, nil
+	}
+	season.ClosedAt = &now
+	m.seasons[seasonID] = season
+
+	for key, s := range m.seasonStats {
+		if key.seasonID != seasonID {
+			continue
+		}
+		standing := seasonKey{seasonID, "", key.userID}
+		total := m.standings[standing]
+		total.UserID = key.userID
+		total.SuccessfulMeows += s.SuccessfulMeows
+		total.FailedMeows += s.FailedMeows
+		total.TotalMeows += s.TotalMeows
+		m.standings[standing] = total
+		delete(m.seasonStats, key)
+	}
+	return true, nil
+}
+
+// rankedSeason ranks the season's players by metric, from its live counters
+// while it is open and its archived standings once it is closed.
+func (m *MemoryStore) rankedSeason(season *Season, metric Metric) []rankedEntry {
+	source := m.seasonStats
+	if season.ClosedAt != nil {
+		source = m.standings
+	}
+
+	var rows []UserGuildStats
+	for key, s := range source {
+		if key.seasonID == season.ID {
+			rows = append(rows, s)
+		}
+	}
+	return m.rank(rows, metric)
+}
+
+func (m *MemoryStore) GetSeasonLeaderboard(_ context.Context, season *Season, q LeaderboardQuery) (*LeaderboardPage, error) {
+	if _, err := q.Metric.counterColumn(); err != nil {
+		return nil, err
+	}
+	if err := q.validate(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return leaderboardPage(m.rankedSeason(season, q.Metric), q), nil
+}
+
+func (m *MemoryStore) GetSeasonUserRank(_ context.Context, season *Season, userID string, metric Metric) (int, error) {
+	if _, err := metric.counterColumn(); err != nil {
+		return 0, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, r := range m.rankedSeason(season, metric) {
+		if r.User.ID == userID {
+			return r.Rank, nil
+		}
+	}
+	return 0, classify(sql.ErrNoRows)
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// applyTreats changes the user's balance by amount and records it in the
+// ledger, like applyTreats does in SQL.
+func (m *MemoryStore) applyTreats(guildID, userID string, amount int, reason string, at time.Time) int {
+	key := statsKey{guildID, userID}
+	balance := m.balances[key] + amount
+	m.balances[key] = balance
+
+	m.lastLedgerID++
+	m.ledger = append(m.ledger, TreatLedgerEntry{
+		ID:           m.lastLedgerID,
+		GuildID:      guildID,
+		UserID:       userID,
+		Amount:       amount,
+		Reason:       reason,
+		BalanceAfter: balance,
+		CreatedAt:    at,
+	})
+	return balance
+}
+
+func (m *MemoryStore) AddTreats(_ context.Context, guildID, userID string, amount int, reason string, at time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkReferences(guildID, userID); err != nil {
+		return 0, err
+	}
+	current := m.balances[statsKey{guildID, userID}]
+	applied := max(amount, -current)
+	if applied == 0 {
+		m.balances[statsKey{guildID, userID}] = current
+		return current, nil
+	}
+	return m.applyTreats(guildID, userID, applied, reason, at), nil
+}
+
+func (m *MemoryStore) PurchaseItem(_ context.Context, guildID, userID, itemID string, price int, at time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkReferences(guildID, userID); err != nil {
+		return 0, err
+	}
+	if m.balances[statsKey{guildID, userID}] < price {
+		return 0, ErrInsufficientTreats
+	}
+	key := itemKey{guildID, userID, itemID}
+	if _, ok := m.items[key]; ok {
+		return 0, ErrAlreadyOwned
+	}
+
+	m.items[key] = UserItem{GuildID: guildID, UserID: userID, ItemID: itemID, PurchasedAt: at}
+	return m.applyTreats(guildID, userID, -price, "purchase:"+itemID, at), nil
+}
+
+func (m *MemoryStore) RefundItem(_ context.Context, guildID, userID, itemID string, price int, at time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := itemKey{guildID, userID, itemID}
+	if _, ok := m.items[key]; !ok {
+		return 0, fmt.Errorf("%w: item not owned", ErrNotFound)
+	}
+
+	delete(m.items, key)
+	return m.applyTreats(guildID, userID, price, "refund:"+itemID, at), nil
+}
+
+func (m *MemoryStore) GetTreatBalance(_ context.Context, guildID, userID string) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.balances[statsKey{guildID, userID}], nil
+}
+
+func (m *MemoryStore) GetTreatLedger(_ context.Context, guildID, userID string, limit int) ([]TreatLedgerEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var entries []TreatLedgerEntry
+	for _, e := range slices.Backward(m.ledger) {
+		if len(entries) == limit {
+			break
+		}
+		if e.GuildID == guildID && e.UserID == userID {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+func (m *MemoryStore) GetUserItems(_ context.Context, guildID, userID string) ([]UserItem, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var items []UserItem
+	for key, item := range m.items {
+		if key.guildID == guildID && key.userID == userID {
+			items = append(items, item)
+		}
+	}
+	slices.SortFunc(items, func(a, b UserItem) int {
+		if c := a.PurchasedAt.Compare(b.PurchasedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ItemID, b.ItemID)
+	})
+	return items, nil
+}
+
+func (m *MemoryStore) GetItemRole(_ context.Context, guildID, itemID string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.itemRoles[itemKey{guildID, "", itemID}], nil
+}
+
+func (m *MemoryStore) SetItemRole(_ context.Context, guildID, itemID, roleID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkReferences(guildID, ""); err != nil {
+		return err
+	}
+	m.itemRoles[itemKey{guildID, "", itemID}] = roleID
+	return nil
+}
@@ -0,0 +1,284 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"libs/go/meowbot/util"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// migrations/ holds the Postgres migrations and migrations/sqlite/ their
+// SQLite counterparts, one for one.
+//
+//go:embed migrations/*.sql migrations/sqlite/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID keys the advisory lock that keeps replicas from migrating
+// the same database at once.
+const migrationLockID = 0x6d656f77 // "meow"
+
+// ErrSchemaIncompatible means the database schema isn't the version this
+// build expects, e.g. because a newer build already migrated it.
+var ErrSchemaIncompatible = errors.New("incompatible schema version")
+
+// Migration is one versioned schema change, read from
+// migrations/<version>_<name>.up.sql and its .down.sql counterpart.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+var migrationFileRegex = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migrations returns the embedded migrations for db's dialect in version
+// order.
+func Migrations(db *sql.DB) ([]Migration, error) {
+	return migrationsFor(dialectOf(db))
+}
+
+func migrationsFor(d dialect) ([]Migration, error) {
+	return loadMigrations(migrationFiles, d.pick("migrations", "migrations/sqlite"))
+}
+
+// loadMigrations reads the migrations in dir. Versions must start at 1 and
+// have no gaps, and every migration needs both an up and a down script.
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFileRegex.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file %q", entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		body, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %q: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			return nil, fmt.Errorf("migration %d is missing", i+1)
+		}
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down script", m.Version, m.Name)
+		}
+	}
+	return migrations, nil
+}
+
+// Migrate applies every pending migration, each in its own transaction. It
+// refuses to touch a database that is ahead of this build.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	defer trace(ctx, "Migrate")()
+
+	migrations, err := Migrations(db)
+	if err != nil {
+		return err
+	}
+	return migrateUp(ctx, db, migrations)
+}
+
+func migrateUp(ctx context.Context, db *sql.DB, migrations []Migration) error {
+	return withMigrationLock(ctx, db, func(conn *sql.Conn) error {
+		current, err := currentVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		latest := len(migrations)
+		if current > latest {
+			return fmt.Errorf("%w: database is at version %d, but this build only knows up to %d", ErrSchemaIncompatible, current, latest)
+		}
+
+		for _, m := range migrations[current:] {
+			err := applyMigration(ctx, conn, m.Up, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2);`, m.Version, m.Name)
+			if err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", m.Version, m.Name, err)
+			}
+			util.LoggerFrom(ctx).Info("🗄️ Applied migration", "version", m.Version, "name", m.Name)
+		}
+		return nil
+	})
+}
+
+// MigrateDown reverts migrations until the database is at version target.
+func MigrateDown(ctx context.Context, db *sql.DB, target int) error {
+	defer trace(ctx, "MigrateDown")()
+
+	migrations, err := Migrations(db)
+	if err != nil {
+		return err
+	}
+	if target < 0 {
+		return fmt.Errorf("%w: target version must not be negative", ErrInvalidInput)
+	}
+
+	return withMigrationLock(ctx, db, func(conn *sql.Conn) error {
+		current, err := currentVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if current > len(migrations) {
+			return fmt.Errorf("%w: database is at version %d, but this build only knows up to %d", ErrSchemaIncompatible, current, len(migrations))
+		}
+
+		for v := current; v > target; v-- {
+			m := migrations[v-1]
+			err := applyMigration(ctx, conn, m.Down, `DELETE FROM schema_migrations WHERE version = $1;`, m.Version)
+			if err != nil {
+				return fmt.Errorf("failed to revert migration %d_%s: %w", m.Version, m.Name, err)
+			}
+			util.LoggerFrom(ctx).Info("🗄️ Reverted migration", "version", m.Version, "name", m.Name)
+		}
+		return nil
+	})
+}
+
+// SchemaVersion returns the version the database was migrated to, or 0 if
+// it was never migrated.
+func SchemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	defer trace(ctx, "SchemaVersion")()
+
+	query := dialectOf(db).pick(
+		`SELECT to_regclass('schema_migrations') IS NOT NULL;`,
+		`SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations');`,
+	)
+	var exists bool
+	if err := db.QueryRowContext(ctx, query).Scan(&exists); err != nil {
+		return 0, classify(fmt.Errorf("failed to check schema_migrations: %w", err))
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	var version int
+	err := db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations;`).Scan(&version)
+	return version, classify(err)
+}
+
+// CheckSchema fails with ErrSchemaIncompatible unless the database is at the
+// latest version this build knows.
+func CheckSchema(ctx context.Context, db *sql.DB) error {
+	migrations, err := Migrations(db)
+	if err != nil {
+		return err
+	}
+	version, err := SchemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	if version != len(migrations) {
+		return fmt.Errorf("%w: database is at version %d, this build needs %d", ErrSchemaIncompatible, version, len(migrations))
+	}
+	return nil
+}
+
+// withMigrationLock runs fn on a single connection holding the migration
+// advisory lock, after making sure schema_migrations exists. SQLite has no
+// advisory locks; two processes migrating the same file at once can't both
+// record a version, so the slower one fails and rolls back instead.
+func withMigrationLock(ctx context.Context, db *sql.DB, fn func(conn *sql.Conn) error) (err error) {
+	d := dialectOf(db)
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return classify(fmt.Errorf("failed to get connection: %w", err))
+	}
+	defer conn.Close()
+
+	if d == dialectPostgres {
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1);`, migrationLockID); err != nil {
+			return classify(fmt.Errorf("failed to acquire migration lock: %w", err))
+		}
+		defer func() {
+			if _, unlockErr := conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1);`, migrationLockID); unlockErr != nil && err == nil {
+				err = classify(fmt.Errorf("failed to release migration lock: %w", unlockErr))
+			}
+		}()
+	}
+
+	// Postgres creates applied_at without a zone, like the tables of the
+	// early migrations; migration 14 turns it into TIMESTAMPTZ.
+	_, err = conn.ExecContext(ctx, d.pick(`
+		CREATE TABLE IF NOT EXISTS schema_migrations
+		(
+			version    INT PRIMARY KEY,
+			name       TEXT NOT NULL,
+			applied_at TIMESTAMP DEFAULT NOW()
+		);
+	`, `
+		CREATE TABLE IF NOT EXISTS schema_migrations
+		(
+			version    INT PRIMARY KEY,
+			name       TEXT NOT NULL,
+			applied_at TIMESTAMP DEFAULT (CAST(unixepoch('subsec') * 1000000000 AS INTEGER))
+		);
+	`))
+	if err != nil {
+		return classify(fmt.Errorf("failed to create schema_migrations: %w", err))
+	}
+	return fn(conn)
+}
+
+func currentVersion(ctx context.Context, conn *sql.Conn) (int, error) {
+	var version int
+	err := conn.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations;`).Scan(&version)
+	if err != nil {
+		return 0, classify(fmt.Errorf("failed to read schema version: %w", err))
+	}
+	return version, nil
+}
+
+// applyMigration runs script and records it with bookkeeping in one
+// transaction.
+func applyMigration(ctx context.Context, conn *sql.Conn, script, bookkeeping string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return classify(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return classify(err)
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		return classify(err)
+	}
+	return classify(tx.Commit())
+}
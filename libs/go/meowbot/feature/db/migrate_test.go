@@ -0,0 +1,192 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations_Embedded(t *testing.T) {
+	postgres, err := migrationsFor(dialectPostgres)
+	require.NoError(t, err)
+	require.NotEmpty(t, postgres)
+	for i, m := range postgres {
+		require.Equal(t, i+1, m.Version)
+		require.NotEmpty(t, m.Up, "migration %d has no up script", m.Version)
+		require.NotEmpty(t, m.Down, "migration %d has no down script", m.Version)
+	}
+
+	// Every Postgres migration needs its SQLite counterpart.
+	sqlite, err := migrationsFor(dialectSQLite)
+	require.NoError(t, err)
+	require.Len(t, sqlite, len(postgres))
+	for i, m := range sqlite {
+		require.Equal(t, postgres[i].Name, m.Name, "migration %d", m.Version)
+	}
+}
+
+func TestLoadMigrations_Invalid(t *testing.T) {
+	file := func(body string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(body)} }
+
+	tests := map[string]fstest.MapFS{
+		"missing down": {
+			"m/0001_init.up.sql": file("CREATE TABLE a (id INT);"),
+		},
+		"gap": {
+			"m/0001_init.up.sql":    file("CREATE TABLE a (id INT);"),
+			"m/0001_init.down.sql":  file("DROP TABLE a;"),
+			"m/0003_later.up.sql":   file("CREATE TABLE b (id INT);"),
+			"m/0003_later.down.sql": file("DROP TABLE b;"),
+		},
+		"bad name": {
+			"m/init.sql": file("CREATE TABLE a (id INT);"),
+		},
+	}
+	for name, fsys := range tests {
+		if _, err := loadMigrations(fsys, "m"); err == nil {
+			t.Errorf("%s: loadMigrations() should fail", name)
+		}
+	}
+}
+
+func expectMigrationLock(mock sqlmock.Sqlmock, version int) {
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_lock($1)`)).
+		WithArgs(migrationLockID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS schema_migrations`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(version))
+}
+
+func expectMigrationUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_unlock($1)`)).
+		WithArgs(migrationLockID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestMigrate_AppliesPending(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	migrations := []Migration{
+		{Version: 1, Name: "init", Up: "CREATE TABLE a (id INT);", Down: "DROP TABLE a;"},
+		{Version: 2, Name: "b", Up: "CREATE TABLE b (id INT);", Down: "DROP TABLE b;"},
+	}
+
+	expectMigrationLock(mock, 1)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE b (id INT);`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO schema_migrations (version, name)`)).
+		WithArgs(2, "b").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectMigrationUnlock(mock)
+
+	require.NoError(t, migrateUp(context.Background(), mockDB, migrations))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrate_FailedMigrationRollsBack(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	migrations := []Migration{{Version: 1, Name: "init", Up: "CREATE TABLE a (id INT);", Down: "DROP TABLE a;"}}
+
+	expectMigrationLock(mock, 0)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE a (id INT);`)).
+		WillReturnError(errors.New("syntax error"))
+	mock.ExpectRollback()
+	expectMigrationUnlock(mock)
+
+	err := migrateUp(context.Background(), mockDB, migrations)
+	require.ErrorContains(t, err, "failed to apply migration 1_init")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrate_RefusesNewerSchema(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	migrations, err := Migrations(mockDB)
+	require.NoError(t, err)
+
+	expectMigrationLock(mock, len(migrations)+1)
+	expectMigrationUnlock(mock)
+
+	err = Migrate(context.Background(), mockDB)
+	require.ErrorIs(t, err, ErrSchemaIncompatible)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCheckSchema(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	migrations, err := Migrations(mockDB)
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`to_regclass('schema_migrations')`)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(len(migrations)))
+	require.NoError(t, CheckSchema(context.Background(), mockDB))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`to_regclass('schema_migrations')`)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	require.ErrorIs(t, CheckSchema(context.Background(), mockDB), ErrSchemaIncompatible)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrate_TimestampsHaveZones(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *sql.DB) {
+		if dialectOf(db) != dialectPostgres {
+			t.Skip("SQLite timestamps are Unix nanoseconds")
+		}
+
+		var columns []string
+		rows, err := db.QueryContext(context.Background(), `
+			SELECT table_name || '.' || column_name
+			FROM information_schema.columns
+			WHERE table_schema = current_schema() AND data_type = 'timestamp without time zone'
+			ORDER BY 1;
+		`)
+		require.NoError(t, err)
+		defer rows.Close()
+		for rows.Next() {
+			var column string
+			require.NoError(t, rows.Scan(&column))
+			columns = append(columns, column)
+		}
+		require.NoError(t, rows.Err())
+		require.Empty(t, columns, "every timestamp, schema_migrations.applied_at included, is TIMESTAMPTZ")
+	})
+}
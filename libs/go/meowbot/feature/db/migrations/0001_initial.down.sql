@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS guild_channels;
+DROP TABLE IF EXISTS guild_streaks;
+DROP TABLE IF EXISTS user_guild_stats;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS guilds;
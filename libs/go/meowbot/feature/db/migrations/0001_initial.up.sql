@@ -1,19 +1,17 @@
--- CREATE DATABASE meowbot;
-
-CREATE TABLE guilds
+CREATE TABLE IF NOT EXISTS guilds
 (
     id         TEXT PRIMARY KEY,
     created_at TIMESTAMP DEFAULT NOW()
 );
 
-CREATE TABLE users
+CREATE TABLE IF NOT EXISTS users
 (
     id         TEXT PRIMARY KEY,
     username   TEXT,
     created_at TIMESTAMP DEFAULT NOW()
 );
 
-CREATE TABLE user_guild_stats
+CREATE TABLE IF NOT EXISTS user_guild_stats
 (
     guild_id            TEXT REFERENCES guilds (id) ON DELETE CASCADE,
     user_id             TEXT REFERENCES users (id) ON DELETE CASCADE,
@@ -27,10 +25,10 @@ CREATE TABLE user_guild_stats
     PRIMARY KEY (guild_id, user_id)
 );
 
-CREATE INDEX idx_user_guild_stats_user_id ON user_guild_stats (user_id);
-CREATE INDEX idx_user_guild_stats_guild_id ON user_guild_stats (guild_id);
+CREATE INDEX IF NOT EXISTS idx_user_guild_stats_user_id ON user_guild_stats (user_id);
+CREATE INDEX IF NOT EXISTS idx_user_guild_stats_guild_id ON user_guild_stats (guild_id);
 
-CREATE TABLE guild_streaks
+CREATE TABLE IF NOT EXISTS guild_streaks
 (
     guild_id           TEXT PRIMARY KEY REFERENCES guilds (id) ON DELETE CASCADE,
     meow_count         INT DEFAULT 0,
@@ -44,4 +42,3 @@ CREATE TABLE IF NOT EXISTS guild_channels
     guild_id   TEXT PRIMARY KEY,
     channel_id TEXT NOT NULL
 );
-
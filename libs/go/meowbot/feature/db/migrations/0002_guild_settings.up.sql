@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS guild_settings
+(
+    guild_id TEXT PRIMARY KEY,
+    locale   TEXT
+);
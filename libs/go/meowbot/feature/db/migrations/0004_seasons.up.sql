@@ -0,0 +1,37 @@
+-- A season with a NULL guild_id is global and spans every guild.
+CREATE TABLE IF NOT EXISTS seasons
+(
+    id        SERIAL PRIMARY KEY,
+    guild_id  TEXT REFERENCES guilds (id) ON DELETE CASCADE,
+    name      TEXT      NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at   TIMESTAMP NOT NULL,
+    closed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_seasons_guild_id ON seasons (guild_id);
+
+-- Counters for seasons that are still open. Rows are removed once the season
+-- closes and its standings are archived.
+CREATE TABLE IF NOT EXISTS season_stats
+(
+    season_id        INT REFERENCES seasons (id) ON DELETE CASCADE,
+    guild_id         TEXT REFERENCES guilds (id) ON DELETE CASCADE,
+    user_id          TEXT REFERENCES users (id) ON DELETE CASCADE,
+    successful_meows INT DEFAULT 0,
+    failed_meows     INT DEFAULT 0,
+    total_meows      INT DEFAULT 0,
+    PRIMARY KEY (season_id, guild_id, user_id)
+);
+
+-- Final standings of closed seasons.
+CREATE TABLE IF NOT EXISTS season_standings
+(
+    season_id        INT REFERENCES seasons (id) ON DELETE CASCADE,
+    user_id          TEXT REFERENCES users (id) ON DELETE CASCADE,
+    rank             INT NOT NULL,
+    successful_meows INT DEFAULT 0,
+    failed_meows     INT DEFAULT 0,
+    total_meows      INT DEFAULT 0,
+    PRIMARY KEY (season_id, user_id)
+);
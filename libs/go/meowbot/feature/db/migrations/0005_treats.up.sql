@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS treat_balances
+(
+    guild_id TEXT REFERENCES guilds (id) ON DELETE CASCADE,
+    user_id  TEXT REFERENCES users (id) ON DELETE CASCADE,
+    balance  INT NOT NULL DEFAULT 0 CHECK (balance >= 0),
+    PRIMARY KEY (guild_id, user_id)
+);
+
+-- Every change to a treat balance, with the balance it left behind.
+CREATE TABLE IF NOT EXISTS treat_ledger
+(
+    id            BIGSERIAL PRIMARY KEY,
+    guild_id      TEXT REFERENCES guilds (id) ON DELETE CASCADE,
+    user_id       TEXT REFERENCES users (id) ON DELETE CASCADE,
+    amount        INT  NOT NULL,
+    reason        TEXT NOT NULL,
+    balance_after INT  NOT NULL,
+    created_at    TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_treat_ledger_guild_user ON treat_ledger (guild_id, user_id, id);
+
+CREATE TABLE IF NOT EXISTS user_items
+(
+    guild_id     TEXT REFERENCES guilds (id) ON DELETE CASCADE,
+    user_id      TEXT REFERENCES users (id) ON DELETE CASCADE,
+    item_id      TEXT NOT NULL,
+    purchased_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (guild_id, user_id, item_id)
+);
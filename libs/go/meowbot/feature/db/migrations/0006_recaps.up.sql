@@ -0,0 +1,26 @@
+ALTER TABLE guild_settings ADD COLUMN IF NOT EXISTS timezone TEXT;
+
+-- One row per processed meow, so activity can be summarized over any period.
+CREATE TABLE IF NOT EXISTS meow_events
+(
+    id         BIGSERIAL PRIMARY KEY,
+    guild_id   TEXT REFERENCES guilds (id) ON DELETE CASCADE,
+    user_id    TEXT REFERENCES users (id) ON DELETE CASCADE,
+    success    BOOLEAN NOT NULL,
+    chain      INT     NOT NULL DEFAULT 0,
+    broken     INT     NOT NULL DEFAULT 0,
+    high_score BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_meow_events_guild_created ON meow_events (guild_id, created_at);
+
+-- Guilds that opted in to recap posts. last_period_end is the end of the last
+-- period a recap was claimed for, so no period is posted twice.
+CREATE TABLE IF NOT EXISTS recap_settings
+(
+    guild_id        TEXT PRIMARY KEY REFERENCES guilds (id) ON DELETE CASCADE,
+    frequency       TEXT NOT NULL,
+    channel_id      TEXT,
+    last_period_end TIMESTAMP
+);
@@ -0,0 +1,17 @@
+-- Per-user opt-ins for DM notifications. last_notified_at throttles them.
+CREATE TABLE IF NOT EXISTS notification_prefs
+(
+    user_id           TEXT PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    rank_changes      BOOLEAN NOT NULL DEFAULT FALSE,
+    streak_milestones BOOLEAN NOT NULL DEFAULT FALSE,
+    last_notified_at  TIMESTAMP
+);
+
+-- Last guild rank seen for users watching their rank, to detect being passed.
+CREATE TABLE IF NOT EXISTS notification_ranks
+(
+    guild_id TEXT REFERENCES guilds (id) ON DELETE CASCADE,
+    user_id  TEXT REFERENCES users (id) ON DELETE CASCADE,
+    rank     INT NOT NULL,
+    PRIMARY KEY (guild_id, user_id)
+);
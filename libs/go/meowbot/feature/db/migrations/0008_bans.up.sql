@@ -0,0 +1,12 @@
+-- Users banned from the meow game of a guild. expires_at NULL means permanent.
+CREATE TABLE IF NOT EXISTS meow_bans
+(
+    guild_id        TEXT REFERENCES guilds (id) ON DELETE CASCADE,
+    user_id         TEXT REFERENCES users (id) ON DELETE CASCADE,
+    reason          TEXT,
+    banned_by       TEXT,
+    delete_messages BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at      TIMESTAMP DEFAULT NOW(),
+    expires_at      TIMESTAMP,
+    PRIMARY KEY (guild_id, user_id)
+);
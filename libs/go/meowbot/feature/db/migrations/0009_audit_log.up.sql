@@ -0,0 +1,14 @@
+-- Administrative actions, with the value before and after the change.
+CREATE TABLE IF NOT EXISTS audit_log
+(
+    id           BIGSERIAL PRIMARY KEY,
+    guild_id     TEXT NOT NULL,
+    actor_id     TEXT NOT NULL,
+    action       TEXT NOT NULL,
+    target       TEXT,
+    before_value TEXT,
+    after_value  TEXT,
+    created_at   TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_guild_created ON audit_log (guild_id, created_at);
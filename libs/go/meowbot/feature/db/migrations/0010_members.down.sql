@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS username_history;
+DROP TABLE IF EXISTS guild_members;
+ALTER TABLE users DROP COLUMN IF EXISTS avatar;
+ALTER TABLE users DROP COLUMN IF EXISTS display_name;
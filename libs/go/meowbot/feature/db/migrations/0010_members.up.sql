@@ -0,0 +1,31 @@
+-- Discord profile of each user: their global display name and avatar hash.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS display_name TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar TEXT;
+
+-- Users' membership in each guild, with their nickname there.
+CREATE TABLE IF NOT EXISTS guild_members
+(
+    guild_id   TEXT REFERENCES guilds (id) ON DELETE CASCADE,
+    user_id    TEXT REFERENCES users (id) ON DELETE CASCADE,
+    nickname   TEXT,
+    joined_at  TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (guild_id, user_id)
+);
+
+-- Every username a user has gone by, starting with the first one seen.
+CREATE TABLE IF NOT EXISTS username_history
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    username   TEXT NOT NULL,
+    changed_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_username_history_user_changed ON username_history (user_id, changed_at);
+
+-- Users seen before this migration start their history with their current name.
+INSERT INTO username_history (user_id, username, changed_at)
+SELECT id, username, created_at
+FROM users
+WHERE username IS NOT NULL;
@@ -0,0 +1,7 @@
+ALTER TABLE guilds DROP COLUMN IF EXISTS left_at;
+ALTER TABLE guilds DROP COLUMN IF EXISTS joined_at;
+ALTER TABLE guilds DROP COLUMN IF EXISTS present;
+ALTER TABLE guilds DROP COLUMN IF EXISTS owner_id;
+ALTER TABLE guilds DROP COLUMN IF EXISTS member_count;
+ALTER TABLE guilds DROP COLUMN IF EXISTS icon;
+ALTER TABLE guilds DROP COLUMN IF EXISTS name;
@@ -0,0 +1,8 @@
+-- Guild details from Discord, and whether the bot is still in the guild.
+ALTER TABLE guilds ADD COLUMN IF NOT EXISTS name TEXT;
+ALTER TABLE guilds ADD COLUMN IF NOT EXISTS icon TEXT;
+ALTER TABLE guilds ADD COLUMN IF NOT EXISTS member_count INTEGER;
+ALTER TABLE guilds ADD COLUMN IF NOT EXISTS owner_id TEXT;
+ALTER TABLE guilds ADD COLUMN IF NOT EXISTS present BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE guilds ADD COLUMN IF NOT EXISTS joined_at TIMESTAMP;
+ALTER TABLE guilds ADD COLUMN IF NOT EXISTS left_at TIMESTAMP;
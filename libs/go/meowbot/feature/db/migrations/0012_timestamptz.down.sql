@@ -0,0 +1,21 @@
+ALTER TABLE username_history ALTER COLUMN changed_at TYPE TIMESTAMP USING changed_at AT TIME ZONE 'UTC';
+ALTER TABLE guild_members ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'UTC';
+ALTER TABLE guild_members ALTER COLUMN joined_at TYPE TIMESTAMP USING joined_at AT TIME ZONE 'UTC';
+ALTER TABLE audit_log ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'UTC';
+ALTER TABLE meow_bans ALTER COLUMN expires_at TYPE TIMESTAMP USING expires_at AT TIME ZONE 'UTC';
+ALTER TABLE meow_bans ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'UTC';
+ALTER TABLE notification_prefs ALTER COLUMN last_notified_at TYPE TIMESTAMP USING last_notified_at AT TIME ZONE 'UTC';
+ALTER TABLE recap_settings ALTER COLUMN last_period_end TYPE TIMESTAMP USING last_period_end AT TIME ZONE 'UTC';
+ALTER TABLE meow_events ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'UTC';
+ALTER TABLE user_items ALTER COLUMN purchased_at TYPE TIMESTAMP USING purchased_at AT TIME ZONE 'UTC';
+ALTER TABLE treat_ledger ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'UTC';
+ALTER TABLE seasons ALTER COLUMN closed_at TYPE TIMESTAMP USING closed_at AT TIME ZONE 'UTC';
+ALTER TABLE seasons ALTER COLUMN ends_at TYPE TIMESTAMP USING ends_at AT TIME ZONE 'UTC';
+ALTER TABLE seasons ALTER COLUMN starts_at TYPE TIMESTAMP USING starts_at AT TIME ZONE 'UTC';
+ALTER TABLE user_achievements ALTER COLUMN unlocked_at TYPE TIMESTAMP USING unlocked_at AT TIME ZONE 'UTC';
+ALTER TABLE user_guild_stats ALTER COLUMN last_failed_meow_at TYPE TIMESTAMP USING last_failed_meow_at AT TIME ZONE 'UTC';
+ALTER TABLE user_guild_stats ALTER COLUMN last_meow_at TYPE TIMESTAMP USING last_meow_at AT TIME ZONE 'UTC';
+ALTER TABLE users ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'UTC';
+ALTER TABLE guilds ALTER COLUMN left_at TYPE TIMESTAMP USING left_at AT TIME ZONE 'UTC';
+ALTER TABLE guilds ALTER COLUMN joined_at TYPE TIMESTAMP USING joined_at AT TIME ZONE 'UTC';
+ALTER TABLE guilds ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'UTC';
@@ -0,0 +1,24 @@
+-- Timestamps were stored without a zone, as the UTC wall-clock times a bot
+-- and database running in UTC write. Store them as instants instead, so they
+-- read the same whatever the session's TimeZone.
+ALTER TABLE guilds ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+ALTER TABLE guilds ALTER COLUMN joined_at TYPE TIMESTAMPTZ USING joined_at AT TIME ZONE 'UTC';
+ALTER TABLE guilds ALTER COLUMN left_at TYPE TIMESTAMPTZ USING left_at AT TIME ZONE 'UTC';
+ALTER TABLE users ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+ALTER TABLE user_guild_stats ALTER COLUMN last_meow_at TYPE TIMESTAMPTZ USING last_meow_at AT TIME ZONE 'UTC';
+ALTER TABLE user_guild_stats ALTER COLUMN last_failed_meow_at TYPE TIMESTAMPTZ USING last_failed_meow_at AT TIME ZONE 'UTC';
+ALTER TABLE user_achievements ALTER COLUMN unlocked_at TYPE TIMESTAMPTZ USING unlocked_at AT TIME ZONE 'UTC';
+ALTER TABLE seasons ALTER COLUMN starts_at TYPE TIMESTAMPTZ USING starts_at AT TIME ZONE 'UTC';
+ALTER TABLE seasons ALTER COLUMN ends_at TYPE TIMESTAMPTZ USING ends_at AT TIME ZONE 'UTC';
+ALTER TABLE seasons ALTER COLUMN closed_at TYPE TIMESTAMPTZ USING closed_at AT TIME ZONE 'UTC';
+ALTER TABLE treat_ledger ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+ALTER TABLE user_items ALTER COLUMN purchased_at TYPE TIMESTAMPTZ USING purchased_at AT TIME ZONE 'UTC';
+ALTER TABLE meow_events ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+ALTER TABLE recap_settings ALTER COLUMN last_period_end TYPE TIMESTAMPTZ USING last_period_end AT TIME ZONE 'UTC';
+ALTER TABLE notification_prefs ALTER COLUMN last_notified_at TYPE TIMESTAMPTZ USING last_notified_at AT TIME ZONE 'UTC';
+ALTER TABLE meow_bans ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+ALTER TABLE meow_bans ALTER COLUMN expires_at TYPE TIMESTAMPTZ USING expires_at AT TIME ZONE 'UTC';
+ALTER TABLE audit_log ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+ALTER TABLE guild_members ALTER COLUMN joined_at TYPE TIMESTAMPTZ USING joined_at AT TIME ZONE 'UTC';
+ALTER TABLE guild_members ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE 'UTC';
+ALTER TABLE username_history ALTER COLUMN changed_at TYPE TIMESTAMPTZ USING changed_at AT TIME ZONE 'UTC';
@@ -0,0 +1,9 @@
+-- The role the bot created in each guild for a shop item that grants one.
+-- Roles are granted by this ID only, never by name.
+CREATE TABLE IF NOT EXISTS item_roles
+(
+    guild_id TEXT REFERENCES guilds (id) ON DELETE CASCADE,
+    item_id  TEXT,
+    role_id  TEXT NOT NULL,
+    PRIMARY KEY (guild_id, item_id)
+);
@@ -0,0 +1,3 @@
+-- schema_migrations is created by the migrator rather than a migration, so
+-- 0012 left its timestamp without a zone.
+ALTER TABLE schema_migrations ALTER COLUMN applied_at TYPE TIMESTAMPTZ USING applied_at AT TIME ZONE 'UTC';
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS user_achievements
+(
+    guild_id       TEXT REFERENCES guilds (id) ON DELETE CASCADE,
+    user_id        TEXT REFERENCES users (id) ON DELETE CASCADE,
+    achievement_id TEXT NOT NULL,
+    unlocked_at    TIMESTAMP DEFAULT (CAST(unixepoch('subsec') * 1000000000 AS INTEGER)),
+    PRIMARY KEY (guild_id, user_id, achievement_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_achievements_user_id ON user_achievements (user_id);
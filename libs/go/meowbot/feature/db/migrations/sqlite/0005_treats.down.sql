@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_items;
+DROP TABLE IF EXISTS treat_ledger;
+DROP TABLE IF EXISTS treat_balances;
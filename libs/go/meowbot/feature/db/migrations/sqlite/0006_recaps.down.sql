@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS recap_settings;
+DROP TABLE IF EXISTS meow_events;
+ALTER TABLE guild_settings DROP COLUMN timezone;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS notification_ranks;
+DROP TABLE IF EXISTS notification_prefs;
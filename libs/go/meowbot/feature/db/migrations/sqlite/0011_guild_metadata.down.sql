@@ -0,0 +1,7 @@
+ALTER TABLE guilds DROP COLUMN left_at;
+ALTER TABLE guilds DROP COLUMN joined_at;
+ALTER TABLE guilds DROP COLUMN present;
+ALTER TABLE guilds DROP COLUMN owner_id;
+ALTER TABLE guilds DROP COLUMN member_count;
+ALTER TABLE guilds DROP COLUMN icon;
+ALTER TABLE guilds DROP COLUMN name;
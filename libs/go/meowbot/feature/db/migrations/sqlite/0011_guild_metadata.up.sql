@@ -0,0 +1,8 @@
+-- Guild details from Discord, and whether the bot is still in the guild.
+ALTER TABLE guilds ADD COLUMN name TEXT;
+ALTER TABLE guilds ADD COLUMN icon TEXT;
+ALTER TABLE guilds ADD COLUMN member_count INTEGER;
+ALTER TABLE guilds ADD COLUMN owner_id TEXT;
+ALTER TABLE guilds ADD COLUMN present BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE guilds ADD COLUMN joined_at TIMESTAMP;
+ALTER TABLE guilds ADD COLUMN left_at TIMESTAMP;
@@ -0,0 +1,3 @@
+-- SQLite stores timestamps as Unix nanoseconds, which are already instants;
+-- only the Postgres schema needed TIMESTAMPTZ.
+SELECT 1;
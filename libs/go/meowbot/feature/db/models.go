@@ -4,15 +4,53 @@ import (
 	"time"
 )
 
+// Guild is a Discord guild. Its details come from Discord's guild events and
+// are empty for guilds only seen through meows. Present is false once the
+// bot has left, at LeftAt.
 type Guild struct {
-	ID        string    `json:"id"`
-	CreatedAt time.Time `json:"created_at"`
+	ID          string     `json:"id"`
+	Name        string     `json:"name,omitempty"`
+	Icon        string     `json:"icon,omitempty"`
+	MemberCount int        `json:"member_count,omitempty"`
+	OwnerID     string     `json:"owner_id,omitempty"`
+	Present     bool       `json:"present"`
+	JoinedAt    *time.Time `json:"joined_at,omitempty"`
+	LeftAt      *time.Time `json:"left_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
 }
 
+// User is a Discord user. DisplayName is their global display name and
+// Avatar their avatar hash, both empty if they have none.
 type User struct {
-	ID        string    `json:"id"`
+	ID          string    `json:"id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name,omitempty"`
+	Avatar      string    `json:"avatar,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Name is the name to show for u: their display name, or else their username.
+func (u User) Name() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	return u.Username
+}
+
+// GuildMember is a user's membership in a guild. JoinedAt is nil if Discord
+// didn't say when they joined.
+type GuildMember struct {
+	GuildID   string     `json:"guild_id"`
+	UserID    string     `json:"user_id"`
+	Nickname  string     `json:"nickname,omitempty"`
+	JoinedAt  *time.Time `json:"joined_at,omitempty"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// UsernameChange is a username a user took on at ChangedAt.
+type UsernameChange struct {
 	Username  string    `json:"username"`
-	CreatedAt time.Time `json:"created_at"`
+	ChangedAt time.Time `json:"changed_at"`
 }
 
 type UserGuildStats struct {
@@ -35,6 +73,162 @@ type GuildStreak struct {
 	HighScoreUserID *string `json:"high_score_user_id,omitempty"`
 }
 
+type GuildSettings struct {
+	GuildID  string `json:"guild_id"`
+	Locale   string `json:"locale,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+type MeowEvent struct {
+	GuildID   string    `json:"guild_id"`
+	UserID    string    `json:"user_id"`
+	Success   bool      `json:"success"`
+	Chain     int       `json:"chain"`
+	Broken    int       `json:"broken"`
+	HighScore bool      `json:"high_score"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RecapSettings struct {
+	GuildID       string     `json:"guild_id"`
+	Frequency     string     `json:"frequency"`
+	ChannelID     string     `json:"channel_id,omitempty"`
+	LastPeriodEnd *time.Time `json:"last_period_end,omitempty"`
+}
+
+// AuditEntry records an administrative action. Before and After hold the
+// changed value, serialized as text, and are empty if there was none.
+type AuditEntry struct {
+	ID        int64     `json:"id"`
+	GuildID   string    `json:"guild_id"`
+	ActorID   string    `json:"actor_id"`
+	Action    string    `json:"action"`
+	Target    string    `json:"target,omitempty"`
+	Before    string    `json:"before,omitempty"`
+	After     string    `json:"after,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuditFilter narrows GetAuditLog. Zero fields don't filter.
+type AuditFilter struct {
+	GuildID string
+	ActorID string
+	Since   time.Time
+	Until   time.Time
+	Limit   int
+	Offset  int
+}
+
+// MeowBan keeps a user out of a guild's meow game until ExpiresAt, or for
+// good if it is nil.
+type MeowBan struct {
+	GuildID        string     `json:"guild_id"`
+	UserID         string     `json:"user_id"`
+	Reason         string     `json:"reason,omitempty"`
+	BannedBy       string     `json:"banned_by,omitempty"`
+	DeleteMessages bool       `json:"delete_messages"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+}
+
+type NotificationPrefs struct {
+	UserID           string `json:"user_id"`
+	RankChanges      bool   `json:"rank_changes"`
+	StreakMilestones bool   `json:"streak_milestones"`
+}
+
+// RankWatcher is a user with rank notifications on, their last seen guild
+// rank (0 if none was recorded yet) and their current one.
+type RankWatcher struct {
+	UserID   string `json:"user_id"`
+	LastRank int    `json:"last_rank"`
+	Rank     int    `json:"rank"`
+}
+
+// GuildRecap summarizes a guild's activity between Start and End. NewRecords
+// counts the chains that beat the guild's high score, not their meows.
+type GuildRecap struct {
+	Start           time.Time          `json:"start"`
+	End             time.Time          `json:"end"`
+	TotalMeows      int                `json:"total_meows"`
+	SuccessfulMeows int                `json:"successful_meows"`
+	BestStreak      int                `json:"best_streak"`
+	NewRecords      int                `json:"new_records"`
+	TopContributors []LeaderboardEntry `json:"top_contributors,omitempty"`
+	Breaker         *User              `json:"breaker,omitempty"`
+	BrokenStreak    int                `json:"broken_streak"`
+}
+
+type UserAchievement struct {
+	GuildID       string    `json:"guild_id,omitempty"`
+	UserID        string    `json:"user_id"`
+	AchievementID string    `json:"achievement_id"`
+	UnlockedAt    time.Time `json:"unlocked_at"`
+}
+
+// Season is a period with its own leaderboard. A nil GuildID means the season
+// is global.
+type Season struct {
+	ID       int64      `json:"id"`
+	GuildID  *string    `json:"guild_id,omitempty"`
+	Name     string     `json:"name"`
+	StartsAt time.Time  `json:"starts_at"`
+	EndsAt   time.Time  `json:"ends_at"`
+	ClosedAt *time.Time `json:"closed_at,omitempty"`
+}
+
+// SeasonStats are a user's counters in a guild for a season that is still
+// open.
+type SeasonStats struct {
+	SeasonID        int64  `json:"season_id"`
+	GuildID         string `json:"guild_id"`
+	UserID          string `json:"user_id"`
+	SuccessfulMeows int    `json:"successful_meows"`
+	FailedMeows     int    `json:"failed_meows"`
+	TotalMeows      int    `json:"total_meows"`
+}
+
+// SeasonStanding is a user's final place in a closed season, summed across
+// guilds.
+type SeasonStanding struct {
+	SeasonID        int64  `json:"season_id"`
+	UserID          string `json:"user_id"`
+	Rank            int    `json:"rank"`
+	SuccessfulMeows int    `json:"successful_meows"`
+	FailedMeows     int    `json:"failed_meows"`
+	TotalMeows      int    `json:"total_meows"`
+}
+
+type TreatBalance struct {
+	GuildID string `json:"guild_id"`
+	UserID  string `json:"user_id"`
+	Balance int    `json:"balance"`
+}
+
+type TreatLedgerEntry struct {
+	ID           int64     `json:"id"`
+	GuildID      string    `json:"guild_id"`
+	UserID       string    `json:"user_id"`
+	Amount       int       `json:"amount"`
+	Reason       string    `json:"reason"`
+	BalanceAfter int       `json:"balance_after"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type UserItem struct {
+	GuildID     string    `json:"guild_id"`
+	UserID      string    `json:"user_id"`
+	ItemID      string    `json:"item_id"`
+	PurchasedAt time.Time `json:"purchased_at"`
+}
+
+// ItemRole is the role the bot created in a guild for a shop item.
+type ItemRole struct {
+	GuildID string `json:"guild_id"`
+	ItemID  string `json:"item_id"`
+	RoleID  string `json:"role_id"`
+}
+
 type GlobalStats struct {
 	TotalGuilds int `json:"total_guilds"`
 	TotalUsers  int `json:"total_users"`
@@ -43,11 +237,29 @@ type GlobalStats struct {
 
 type LeaderboardEntry struct {
 	User            *User `json:"user"`
+	Rank            int   `json:"rank,omitempty"`
 	TotalMeows      int   `json:"total_meows"`
 	SuccessfulMeows int   `json:"successful_meows"`
 	FailedMeows     int   `json:"failed_meows"`
 }
 
+// LeaderboardPage is a page of a leaderboard. Start is the position of its
+// first entry, counting from 1, and Total the number of players, both 0 if
+// the page is empty. Prev and Next are nil at the top and the bottom.
+type LeaderboardPage struct {
+	Entries []LeaderboardEntry `json:"entries"`
+	Start   int                `json:"start"`
+	Total   int                `json:"total"`
+	Prev    *LeaderboardCursor `json:"prev,omitempty"`
+	Next    *LeaderboardCursor `json:"next,omitempty"`
+}
+
+// LeaderboardCursor marks an entry of a leaderboard to page from.
+type LeaderboardCursor struct {
+	Value  int    `json:"value"`
+	UserID string `json:"user_id"`
+}
+
 type GuildStats struct {
 	Guild           *Guild `json:"guild"`
 	CurrentStreak   int    `json:"current_streak"`
@@ -57,15 +269,22 @@ type GuildStats struct {
 	TotalMeows      int    `json:"total_meows"`
 	SuccessfulMeows int    `json:"successful_meows"`
 	FailedMeows     int    `json:"failed_meows"`
+	Participants    int    `json:"participants"`
+}
+
+type GuildTopMeower struct {
+	User       *User `json:"user"`
+	TotalMeows int   `json:"total_meows"`
 }
 
 type UserGlobalStats struct {
-	UserID          string           `json:"user_id"`
-	Username        string           `json:"username"`
-	CreatedAt       time.Time        `json:"created_at"`
-	SuccessfulMeows int              `json:"successful_meows"`
-	FailedMeows     int              `json:"failed_meows"`
-	TotalMeows      int              `json:"total_meows"`
-	HighestStreak   int              `json:"highest_streak"`
-	GuildStats      []UserGuildStats `json:"guild_stats,omitempty"`
+	UserID          string            `json:"user_id"`
+	Username        string            `json:"username"`
+	CreatedAt       time.Time         `json:"created_at"`
+	SuccessfulMeows int               `json:"successful_meows"`
+	FailedMeows     int               `json:"failed_meows"`
+	TotalMeows      int               `json:"total_meows"`
+	HighestStreak   int               `json:"highest_streak"`
+	GuildStats      []UserGuildStats  `json:"guild_stats,omitempty"`
+	Achievements    []UserAchievement `json:"achievements,omitempty"`
 }
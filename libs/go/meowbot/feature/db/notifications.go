@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// GetNotificationPrefs returns the user's notification opt-ins. Users who
+// never opted in get everything off.
+func GetNotificationPrefs(ctx context.Context, db *sql.DB, userID string) (*NotificationPrefs, error) {
+	defer trace(ctx, "GetNotificationPrefs")()
+
+	prefs := &NotificationPrefs{UserID: userID}
+	err := db.QueryRowContext(ctx, `
+		SELECT rank_changes, streak_milestones FROM notification_prefs WHERE user_id = $1;
+	`, userID).Scan(&prefs.RankChanges, &prefs.StreakMilestones)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, classify(fmt.Errorf("failed to get notification prefs: %w", err))
+	}
+	return prefs, nil
+}
+
+func UpsertNotificationPrefs(ctx context.Context, db *sql.DB, prefs NotificationPrefs) error {
+	defer trace(ctx, "UpsertNotificationPrefs")()
+
+	query := `
+		INSERT INTO notification_prefs (user_id, rank_changes, streak_milestones)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET
+			rank_changes = EXCLUDED.rank_changes,
+			streak_milestones = EXCLUDED.streak_milestones;
+	`
+
+	_, err := db.ExecContext(ctx, query, prefs.UserID, prefs.RankChanges, prefs.StreakMilestones)
+	if err != nil {
+		return classify(fmt.Errorf("failed to upsert notification prefs: %w", err))
+	}
+	return nil
+}
+
+// GetRankWatchers returns the users with rank notifications on who have
+// stats in the guild, with their current rank on the guild's total meows
+// leaderboard, all in one query.
+func GetRankWatchers(ctx context.Context, db *sql.DB, guildID string) ([]RankWatcher, error) {
+	defer trace(ctx, "GetRankWatchers")()
+
+	var rq rankQuery
+	guild := rq.arg(guildID)
+	query := rq.ranked(string(MetricTotal), "user_guild_stats", "WHERE guild_id = "+guild) + `
+		SELECT p.user_id, COALESCE(nr.rank, 0), r.rank
+		FROM notification_prefs p
+		JOIN ranked r ON r.user_id = p.user_id
+		LEFT JOIN notification_ranks nr ON nr.user_id = p.user_id AND nr.guild_id = ` + guild + `
+		WHERE p.rank_changes
+		ORDER BY p.user_id;
+	`
+
+	rows, err := db.QueryContext(ctx, query, rq.args...)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to get rank watchers: %w", err))
+	}
+	defer rows.Close()
+
+	var watchers []RankWatcher
+	for rows.Next() {
+		var w RankWatcher
+		if err := rows.Scan(&w.UserID, &w.LastRank, &w.Rank); err != nil {
+			return nil, classify(err)
+		}
+		watchers = append(watchers, w)
+	}
+	return watchers, classify(rows.Err())
+}
+
+// SetWatchedRank records the guild rank last seen for a rank watcher.
+func SetWatchedRank(ctx context.Context, db *sql.DB, guildID, userID string, rank int) error {
+	defer trace(ctx, "SetWatchedRank")()
+
+	query := `
+		INSERT INTO notification_ranks (guild_id, user_id, rank)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (guild_id, user_id) DO UPDATE SET
+			rank = EXCLUDED.rank;
+	`
+
+	_, err := db.ExecContext(ctx, query, guildID, userID, rank)
+	if err != nil {
+		return classify(fmt.Errorf("failed to set watched rank: %w", err))
+	}
+	return nil
+}
+
+// ClaimNotification reserves a DM to the user at now. It reports false if the
+// user was notified less than minInterval ago.
+func ClaimNotification(ctx context.Context, db *sql.DB, userID string, now time.Time, minInterval time.Duration) (bool, error) {
+	defer trace(ctx, "ClaimNotification")()
+
+	res, err := db.ExecContext(ctx, `
+		UPDATE notification_prefs SET last_notified_at = $2
+		WHERE user_id = $1 AND (last_notified_at IS NULL OR last_notified_at <= $3);
+	`, userID, now, now.Add(-minInterval))
+	if err != nil {
+		return false, classify(fmt.Errorf("failed to claim notification: %w", err))
+	}
+	n, err := res.RowsAffected()
+	return n > 0, classify(err)
+}
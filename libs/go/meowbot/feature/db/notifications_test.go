@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNotificationPrefs_DefaultsOff(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM notification_prefs`)).
+		WithArgs("user-1").
+		WillReturnError(sql.ErrNoRows)
+
+	prefs, err := GetNotificationPrefs(context.Background(), mockDB, "user-1")
+	require.NoError(t, err)
+	require.Equal(t, &NotificationPrefs{UserID: "user-1"}, prefs)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimNotification_Throttled(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	now := time.Date(2025, 6, 11, 12, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE notification_prefs SET last_notified_at = $2`)).
+		WithArgs("user-1", now, now.Add(-10*time.Minute)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	claimed, err := ClaimNotification(context.Background(), mockDB, "user-1", now, 10*time.Minute)
+	require.NoError(t, err)
+	require.False(t, claimed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetRankWatchers(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE p.rank_changes`)).
+		WithArgs("guild-foo").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "last_rank", "rank"}).
+			AddRow("user-1", 2, 3).
+			AddRow("user-2", 0, 1))
+
+	watchers, err := GetRankWatchers(context.Background(), mockDB, "guild-foo")
+	require.NoError(t, err)
+	require.Equal(t, []RankWatcher{{UserID: "user-1", LastRank: 2, Rank: 3}, {UserID: "user-2", Rank: 1}}, watchers)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,194 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// userDataColumns lists every column that holds a user ID, by table. Only
+// the names listed here are ever put into a query. Kept rows count toward
+// guild and season totals or record what happened; DeleteUserData hands
+// them to a stand-in instead of deleting them.
+var userDataColumns = []struct {
+	table, column string
+	keep          bool
+}{
+	{"users", "id", false},
+	{"username_history", "user_id", false},
+	{"guild_members", "user_id", false},
+	{"user_guild_stats", "user_id", true},
+	{"guild_streaks", "last_user_id", true},
+	{"guild_streaks", "high_score_user_id", true},
+	{"user_achievements", "user_id", false},
+	{"season_stats", "user_id", true},
+	{"season_standings", "user_id", true},
+	{"treat_balances", "user_id", false},
+	{"treat_ledger", "user_id", false},
+	{"user_items", "user_id", false},
+	{"meow_events", "user_id", true},
+	{"notification_prefs", "user_id", false},
+	{"notification_ranks", "user_id", false},
+	{"meow_bans", "user_id", false},
+	{"meow_bans", "banned_by", true},
+	{"audit_log", "actor_id", true},
+}
+
+// UserData is everything stored about a user: the rows of each table that
+// reference them, column by column. Tables are keyed by name, or by
+// "table.column" where the user is referenced by another column than the
+// table's user ID.
+type UserData struct {
+	UserID     string                      `json:"user_id"`
+	ExportedAt time.Time                   `json:"exported_at"`
+	Tables     map[string][]map[string]any `json:"tables"`
+}
+
+// ExportUserData collects every row that references userID. It fails with
+// ErrNotFound if there are none.
+func ExportUserData(ctx context.Context, db *sql.DB, userID string) (*UserData, error) {
+	defer trace(ctx, "ExportUserData")()
+
+	data := &UserData{UserID: userID, ExportedAt: time.Now().UTC(), Tables: make(map[string][]map[string]any)}
+	for _, c := range userDataColumns {
+		rows, err := queryRows(ctx, db, fmt.Sprintf(`SELECT * FROM %s WHERE %s = $1`, c.table, c.column), userID)
+		if err != nil {
+			return nil, classify(fmt.Errorf("failed to export %s: %w", c.table, err))
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		key := c.table
+		if c.column != "id" && c.column != "user_id" {
+			key += "." + c.column
+		}
+		data.Tables[key] = rows
+	}
+
+	mention := "<@" + userID + ">"
+	targeted, err := queryRows(ctx, db, `SELECT * FROM audit_log WHERE target = $1`, mention)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to export audit_log: %w", err))
+	}
+	if len(targeted) > 0 {
+		data.Tables["audit_log.target"] = targeted
+	}
+
+	if len(data.Tables) == 0 {
+		return nil, classify(sql.ErrNoRows)
+	}
+	return data, nil
+}
+
+// queryRows runs query and returns its rows as column-value maps.
+func queryRows(ctx context.Context, db *sql.DB, query string, args ...any) (result []map[string]any, err error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close rows: %w", closeErr)
+		}
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		values := make([]any, len(columns))
+		ptrs := make([]any, len(columns))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		row := make(map[string]any, len(columns))
+		for i, col := range columns {
+			if b, ok := values[i].([]byte); ok {
+				values[i] = string(b)
+			}
+			row[col] = values[i]
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// deletedUsername is the username of the stand-ins DeleteUserData creates.
+const deletedUsername = "Deleted user"
+
+// newDeletedUserID makes the ID of a stand-in for a deleted user. It is
+// random, so it can't be traced back to the user.
+func newDeletedUserID() string {
+	return "deleted-" + strings.ToLower(rand.Text())
+}
+
+// DeleteUserData removes a user's personal data: their profile, username
+// history, memberships, preferences, treats, items, achievements and bans.
+// Their meows can't be removed without changing guild and season totals,
+// so their stats, meow events, standings and the streaks and admin actions
+// they are named in are handed to a new anonymous stand-in user instead,
+// whose ID is returned. The before and after values of admin actions taken
+// against the user, such as their ban and its reason, are erased, and the
+// user's ID is replaced in those of other actions. It fails with ErrNotFound
+// for an unknown user.
+func DeleteUserData(ctx context.Context, db *sql.DB, userID string) (string, error) {
+	defer trace(ctx, "DeleteUserData")()
+
+	anonID := newDeletedUserID()
+	err := withTx(ctx, db, func(tx *sql.Tx) error {
+		var createdAt *time.Time
+		err := tx.QueryRowContext(ctx, `SELECT created_at FROM users WHERE id = $1`, userID).Scan(&createdAt)
+		if err != nil {
+			return classify(fmt.Errorf("failed to get user: %w", err))
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO users (id, username, created_at)
+			VALUES ($1, $2, $3);
+		`, anonID, deletedUsername, createdAt)
+		if err != nil {
+			return classify(fmt.Errorf("failed to create stand-in user: %w", err))
+		}
+
+		for _, c := range userDataColumns {
+			var err error
+			switch {
+			case c.table == "users":
+				continue
+			case c.keep:
+				_, err = tx.ExecContext(ctx, fmt.Sprintf(`UPDATE %s SET %s = $1 WHERE %s = $2`, c.table, c.column, c.column), anonID, userID)
+			default:
+				_, err = tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s = $1`, c.table, c.column), userID)
+			}
+			if err != nil {
+				return classify(fmt.Errorf("failed to delete from %s: %w", c.table, err))
+			}
+		}
+
+		// Values are stored as JSON, where the ID is always a quoted string.
+		_, err = tx.ExecContext(ctx, `
+			UPDATE audit_log
+			SET before_value = CASE WHEN target = $1 THEN NULL ELSE REPLACE(before_value, $3, $4) END,
+				after_value  = CASE WHEN target = $1 THEN NULL ELSE REPLACE(after_value, $3, $4) END,
+				target       = CASE WHEN target = $1 THEN $2 ELSE target END
+			WHERE target = $1 OR before_value LIKE $5 OR after_value LIKE $5;
+		`, "<@"+userID+">", "<@"+anonID+">", `"`+userID+`"`, `"`+anonID+`"`, `%"`+userID+`"%`)
+		if err != nil {
+			return classify(fmt.Errorf("failed to anonymize audit_log: %w", err))
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, userID); err != nil {
+			return classify(fmt.Errorf("failed to delete user: %w", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return anonID, nil
+}
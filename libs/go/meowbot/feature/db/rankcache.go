@@ -0,0 +1,452 @@
+package db
+
+import (
+	"context"
+	"libs/go/meowbot/util"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// rankCacheMetrics are the metrics RankCache keeps a ranking for.
+var rankCacheMetrics = []Metric{MetricTotal, MetricSuccessful, MetricFailed}
+
+// RankCache is a Store that answers global leaderboards and ranks by the
+// meow counters from a ranking held in memory, instead of summing
+// user_guild_stats across every guild on each call.
+//
+// The ranking is loaded from the wrapped store on first use and kept current
+// with the meows counted through IncrementMeow; ImportGuild, whose bulk
+// changes it can't follow, reloads it. Once it is older than maxAge, the next
+// read reloads it in the background while still answering from the old one; that is when changes made without the cache, such as meows counted
+// by another replica, show up. Guild scopes and streak metrics go straight to
+// the wrapped store.
+type RankCache struct {
+	Store
+	maxAge time.Duration
+
+	load   sync.Mutex   // held while the ranking is (re)loaded
+	writes sync.RWMutex // held by meows being counted; see reload
+
+	mu       sync.RWMutex
+	ranking  *ranking        // nil until first loaded
+	profiles map[string]User // users upserted before their first meow
+	dirty    map[string]bool // players who meowed during a reload
+	loadedAt time.Time
+	stale    bool
+	loading  bool
+}
+
+var _ Store = (*RankCache)(nil)
+
+func NewRankCache(store Store, maxAge time.Duration) *RankCache {
+	return &RankCache{Store: store, maxAge: maxAge, profiles: make(map[string]User)}
+}
+
+// ranking holds players in rank order by each of rankCacheMetrics.
+type ranking struct {
+	players map[string]*LeaderboardEntry
+	orders  map[Metric][]*LeaderboardEntry
+}
+
+// newRanking ranks players.
+func newRanking(players map[string]*LeaderboardEntry) *ranking {
+	r := &ranking{players: players, orders: make(map[Metric][]*LeaderboardEntry, len(rankCacheMetrics))}
+	for _, m := range rankCacheMetrics {
+		order := slices.Collect(maps.Values(players))
+		slices.SortFunc(order, func(a, b *LeaderboardEntry) int {
+			return compareRanked(rankKey(a, m), rankKey(b, m))
+		})
+		r.orders[m] = order
+	}
+	return r
+}
+
+// counterOf reads the meow counter m of a player.
+func counterOf(e *LeaderboardEntry, m Metric) int {
+	switch m {
+	case MetricSuccessful:
+		return e.SuccessfulMeows
+	case MetricFailed:
+		return e.FailedMeows
+	}
+	return e.TotalMeows
+}
+
+func rankKey(e *LeaderboardEntry, m Metric) LeaderboardCursor {
+	return LeaderboardCursor{Value: counterOf(e, m), UserID: e.User.ID}
+}
+
+// index finds where p is, or belongs, in the order by m.
+func (r *ranking) index(m Metric, p *LeaderboardEntry) int {
+	order := r.orders[m]
+	key := rankKey(p, m)
+	return sort.Search(len(order), func(i int) bool { return compareRanked(rankKey(order[i], m), key) >= 0 })
+}
+
+// rank is the rank of the player at index i of the order by m. Players with
+// the same value share the rank of the first of them.
+func (r *ranking) rank(m Metric, i int) int {
+	order := r.orders[m]
+	value := counterOf(order[i], m)
+	return sort.Search(i, func(k int) bool { return counterOf(order[k], m) <= value }) + 1
+}
+
+// promote moves the player at index i of the order by m up to where their
+// value, which only ever grows, now puts them.
+func (r *ranking) promote(m Metric, i int) {
+	order := r.orders[m]
+	p := order[i]
+	key := rankKey(p, m)
+	j := sort.Search(i, func(k int) bool { return compareRanked(rankKey(order[k], m), key) > 0 })
+	copy(order[j+1:i+1], order[j:i])
+	order[j] = p
+}
+
+// put adds p to the ranking, replacing the player with the same ID.
+func (r *ranking) put(p *LeaderboardEntry) {
+	r.remove(p.User.ID)
+	r.players[p.User.ID] = p
+	for _, m := range rankCacheMetrics {
+		r.orders[m] = slices.Insert(r.orders[m], r.index(m, p), p)
+	}
+}
+
+// remove takes the player with ID userID, if any, out of the ranking.
+func (r *ranking) remove(userID string) {
+	p, ok := r.players[userID]
+	if !ok {
+		return
+	}
+	for _, m := range rankCacheMetrics {
+		i := r.index(m, p)
+		r.orders[m] = slices.Delete(r.orders[m], i, i+1)
+	}
+	delete(r.players, userID)
+}
+
+// ready makes sure there is a ranking to read, loading it if there is none
+// yet and starting a reload in the background if it is stale.
+func (c *RankCache) ready(ctx context.Context) error {
+	c.mu.Lock()
+	loaded := c.ranking != nil
+	refresh := loaded && !c.loading && (c.stale || time.Since(c.loadedAt) > c.maxAge)
+	if refresh {
+		c.loading = true
+	}
+	c.mu.Unlock()
+
+	if !loaded {
+		c.load.Lock()
+		defer c.load.Unlock()
+
+		c.mu.RLock()
+		loaded = c.ranking != nil
+		c.mu.RUnlock()
+		if loaded {
+			return nil
+		}
+		return c.reload(ctx)
+	}
+
+	if refresh {
+		ctx := context.WithoutCancel(ctx)
+		go func() {
+			c.load.Lock()
+			defer c.load.Unlock()
+
+			if err := c.reload(ctx); err != nil {
+				util.LoggerFrom(ctx).Warn("⚠ Failed to reload the rank cache", "error", err)
+			}
+			c.mu.Lock()
+			c.loading = false
+			c.mu.Unlock()
+		}()
+	}
+	return nil
+}
+
+// reload replaces the ranking with a fresh one from the wrapped store. The
+// store may or may not have counted the meows that come in meanwhile, so the
+// players behind them keep the counters the old ranking has for them, and
+// meows counted after the swap go to the new one. Likewise, users deleted
+// meanwhile stay out. There is no old ranking the first time, so meows wait
+// for that load.
+func (c *RankCache) reload(ctx context.Context) error {
+	defer trace(ctx, "RankCache.reload")()
+
+	c.writes.Lock()
+	c.mu.Lock()
+	first := c.ranking == nil
+	c.dirty = make(map[string]bool)
+	c.mu.Unlock()
+	if first {
+		defer c.writes.Unlock()
+	} else {
+		c.writes.Unlock()
+	}
+
+	fresh, err := c.fetch(ctx)
+	if err != nil {
+		c.mu.Lock()
+		c.dirty = nil
+		c.mu.Unlock()
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stale = false
+	for userID := range c.dirty {
+		var old *LeaderboardEntry
+		if c.ranking != nil {
+			old = c.ranking.players[userID]
+		}
+		if old == nil {
+			fresh.remove(userID)
+			continue
+		}
+		p, user := *old, *old.User
+		p.User = &user
+		if fresh.players[userID] == nil {
+			c.stale = true
+		}
+		fresh.put(&p)
+	}
+
+	c.ranking = fresh
+	c.dirty = nil
+	c.profiles = make(map[string]User)
+	c.loadedAt = time.Now()
+	return nil
+}
+
+// fetch ranks everyone from the wrapped store.
+func (c *RankCache) fetch(ctx context.Context) (*ranking, error) {
+	everyone, err := c.Store.GetLeaderboard(ctx, nil, LeaderboardQuery{Metric: MetricTotal, Limit: math.MaxInt32})
+	if err != nil {
+		return nil, err
+	}
+
+	players := make(map[string]*LeaderboardEntry, len(everyone.Entries))
+	for _, e := range everyone.Entries {
+		e.Rank = 0
+		players[e.User.ID] = &e
+	}
+	return newRanking(players), nil
+}
+
+func (c *RankCache) UpsertUser(ctx context.Context, user User) error {
+	if err := c.Store.UpsertUser(ctx, user); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ranking == nil {
+		return nil
+	}
+	if p, ok := c.ranking.players[user.ID]; ok {
+		p.User.Username = user.Username
+		p.User.DisplayName = user.DisplayName
+		p.User.Avatar = user.Avatar
+	} else {
+		c.profiles[user.ID] = user
+	}
+	return nil
+}
+
+// DeleteUserData hands the user's place in the ranking to the stand-in the
+// wrapped store created.
+func (c *RankCache) DeleteUserData(ctx context.Context, userID string) (string, error) {
+	c.writes.RLock()
+	defer c.writes.RUnlock()
+
+	anonID, err := c.Store.DeleteUserData(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.profiles, userID)
+	if c.dirty != nil {
+		c.dirty[userID] = true
+		c.dirty[anonID] = true
+	}
+	if c.ranking == nil {
+		return anonID, nil
+	}
+	if p, ok := c.ranking.players[userID]; ok {
+		c.ranking.remove(userID)
+		stand := *p
+		stand.User = &User{ID: anonID, Username: deletedUsername, CreatedAt: p.User.CreatedAt}
+		c.ranking.put(&stand)
+	}
+	return anonID, nil
+}
+
+// ImportGuild reloads the ranking after a guild import, which can lower
+// counters; the ranking can't follow that, as it only moves players up. Meows
+// and other reloads wait for both, so none is lost in between. If the reload
+// fails, the ranking is marked stale for the next read to retry.
+func (c *RankCache) ImportGuild(ctx context.Context, export *GuildExport, dryRun bool) (*ImportReport, error) {
+	if dryRun {
+		return c.Store.ImportGuild(ctx, export, dryRun)
+	}
+
+	c.load.Lock()
+	defer c.load.Unlock()
+	c.writes.Lock()
+	defer c.writes.Unlock()
+
+	report, err := c.Store.ImportGuild(ctx, export, dryRun)
+	if err != nil {
+		return report, err
+	}
+
+	c.mu.RLock()
+	loaded := c.ranking != nil
+	c.mu.RUnlock()
+	if !loaded {
+		return report, nil
+	}
+
+	fresh, err := c.fetch(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		util.LoggerFrom(ctx).Warn("⚠ Failed to reload the rank cache after a guild import", "error", err)
+		c.stale = true
+		return report, nil
+	}
+	c.ranking = fresh
+	c.profiles = make(map[string]User)
+	c.loadedAt = time.Now()
+	c.stale = false
+	return report, nil
+}
+
+func (c *RankCache) IncrementMeow(ctx context.Context, guildID, userID string, success bool, now time.Time) error {
+	c.writes.RLock()
+	defer c.writes.RUnlock()
+
+	c.mu.Lock()
+	if c.dirty != nil {
+		c.dirty[userID] = true
+	}
+	c.mu.Unlock()
+
+	if err := c.Store.IncrementMeow(ctx, guildID, userID, success, now); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ranking == nil {
+		return nil
+	}
+
+	r := c.ranking
+	p, ok := r.players[userID]
+	if !ok {
+		// A newcomer ranks last until their meow moves them up. The next
+		// reload fills in the rest of their user row.
+		profile := c.profiles[userID]
+		p = &LeaderboardEntry{User: &User{ID: userID, Username: profile.Username, DisplayName: profile.DisplayName, Avatar: profile.Avatar}}
+		delete(c.profiles, userID)
+		r.put(p)
+		c.stale = true
+	}
+
+	counter := MetricFailed
+	if success {
+		counter = MetricSuccessful
+	}
+	total, single := r.index(MetricTotal, p), r.index(counter, p)
+	p.TotalMeows++
+	if success {
+		p.SuccessfulMeows++
+	} else {
+		p.FailedMeows++
+	}
+	r.promote(MetricTotal, total)
+	r.promote(counter, single)
+	return nil
+}
+
+func (c *RankCache) GetLeaderboard3(ctx context.Context, limit int) ([]LeaderboardEntry, error) {
+	if err := c.ready(ctx); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	order := c.ranking.orders[MetricTotal]
+	var entries []LeaderboardEntry
+	for _, p := range order[:min(limit, len(order))] {
+		user := *p.User
+		entries = append(entries, LeaderboardEntry{User: &user, TotalMeows: p.TotalMeows})
+	}
+	return entries, nil
+}
+
+func (c *RankCache) GetLeaderboard(ctx context.Context, guildID *string, q LeaderboardQuery) (*LeaderboardPage, error) {
+	if _, err := q.Metric.counterColumn(); guildID != nil || err != nil {
+		return c.Store.GetLeaderboard(ctx, guildID, q)
+	}
+	if err := q.validate(); err != nil {
+		return nil, err
+	}
+	if err := c.ready(ctx); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	r := c.ranking
+	order := r.orders[q.Metric]
+	from, to := pageRange(len(order), q, func(i int) LeaderboardCursor { return rankKey(order[i], q.Metric) })
+	ranked := make([]rankedEntry, 0, to-from)
+	for i := from; i < to; i++ {
+		entry, user := *order[i], *order[i].User
+		entry.User = &user
+		entry.Rank = r.rank(q.Metric, i)
+		ranked = append(ranked, rankedEntry{
+			LeaderboardEntry: entry,
+			value:            counterOf(&entry, q.Metric),
+			position:         i + 1,
+			total:            len(order),
+		})
+	}
+	return newLeaderboardPage(ranked), nil
+}
+
+func (c *RankCache) GetUserRank(ctx context.Context, userID string, guildID *string, metric Metric) (int, error) {
+	if _, err := metric.counterColumn(); guildID != nil || err != nil {
+		return c.Store.GetUserRank(ctx, userID, guildID, metric)
+	}
+	if err := c.ready(ctx); err != nil {
+		return 0, err
+	}
+
+	c.mu.RLock()
+	p, ok := c.ranking.players[userID]
+	var rank int
+	if ok {
+		rank = c.ranking.rank(metric, c.ranking.index(metric, p))
+	}
+	c.mu.RUnlock()
+
+	if !ok {
+		// Not seen by this replica yet
+		return c.Store.GetUserRank(ctx, userID, guildID, metric)
+	}
+	return rank, nil
+}
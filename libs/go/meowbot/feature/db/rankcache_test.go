@@ -0,0 +1,249 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// standings walks the whole global leaderboard by metric page by page and
+// lists each entry's user, rank and counters.
+func standings(t *testing.T, s Store, metric Metric) []string {
+	t.Helper()
+	var lines []string
+	q := LeaderboardQuery{Metric: metric, Limit: 3}
+	for {
+		page, err := s.GetLeaderboard(context.Background(), nil, q)
+		require.NoError(t, err)
+		for _, e := range page.Entries {
+			lines = append(lines, fmt.Sprintf("%s #%d %d/%d/%d", e.User.ID, e.Rank, e.TotalMeows, e.SuccessfulMeows, e.FailedMeows))
+		}
+		if page.Next == nil {
+			return lines
+		}
+		q.After = page.Next
+	}
+}
+
+func TestRankCache_FollowsMeows(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	cache := NewRankCache(store, time.Minute)
+	seedStore(t, cache)
+	meow(t, cache, "g1", "alice", true, 1)
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	users := []string{"alice", "bob", "carol", "dave", "erin", "frank"}
+	for i := range 200 {
+		user := users[rng.IntN(len(users))]
+		require.NoError(t, cache.UpsertUser(ctx, User{ID: user, Username: user}))
+		meow(t, cache, []string{"g1", "g2"}[rng.IntN(2)], user, rng.IntN(4) > 0, 1)
+
+		if i%20 == 0 {
+			for _, m := range rankCacheMetrics {
+				require.Equal(t, standings(t, store, m), standings(t, cache, m), "metric %s after %d meows", m, i)
+			}
+		}
+	}
+
+	for _, user := range users {
+		for _, m := range rankCacheMetrics {
+			want, err := store.GetUserRank(ctx, user, nil, m)
+			require.NoError(t, err)
+			got, err := cache.GetUserRank(ctx, user, nil, m)
+			require.NoError(t, err)
+			require.Equal(t, want, got, "%s by %s", user, m)
+		}
+	}
+
+	top, err := cache.GetLeaderboard3(ctx, 2)
+	require.NoError(t, err)
+	want, err := store.GetLeaderboard3(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, want, top)
+}
+
+func TestRankCache_MeowsDuringReloads(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	cache := NewRankCache(store, 0) // every read reloads
+	seedStore(t, cache)
+
+	var wg sync.WaitGroup
+	for _, user := range []string{"alice", "bob", "carol"} {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			meow(t, cache, "g1", user, true, 100)
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				_, _ = cache.GetLeaderboard(ctx, nil, LeaderboardQuery{Metric: MetricTotal, Limit: 3})
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The last reload may still be running; any that follows counts the same.
+	for _, user := range []string{"alice", "bob", "carol"} {
+		require.Eventually(t, func() bool {
+			rank, err := cache.GetUserRank(ctx, user, nil, MetricTotal)
+			return err == nil && rank == 1
+		}, time.Second, time.Millisecond)
+	}
+	require.Equal(t, standings(t, store, MetricTotal), standings(t, cache, MetricTotal))
+}
+
+func TestRankCache_ReloadsWhenStale(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	cache := NewRankCache(store, time.Nanosecond)
+	seedStore(t, cache)
+	meow(t, cache, "g1", "alice", true, 2)
+	meow(t, cache, "g1", "bob", true, 1)
+
+	page, err := cache.GetLeaderboard(ctx, nil, LeaderboardQuery{Metric: MetricTotal, Limit: 1})
+	require.NoError(t, err)
+	require.Equal(t, "alice", page.Entries[0].User.ID)
+
+	// Meows the cache doesn't see, as if counted by another replica
+	meow(t, store, "g2", "carol", true, 5)
+
+	require.Eventually(t, func() bool {
+		page, err := cache.GetLeaderboard(ctx, nil, LeaderboardQuery{Metric: MetricTotal, Limit: 1})
+		return err == nil && page.Entries[0].User.ID == "carol"
+	}, time.Second, time.Millisecond)
+}
+
+func TestRankCache_PassesThrough(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	cache := NewRankCache(store, time.Minute)
+	seedStore(t, cache)
+	meow(t, cache, "g1", "alice", true, 2)
+	meow(t, cache, "g2", "bob", true, 3)
+
+	// Guild scopes and streaks aren't cached.
+	g1 := "g1"
+	page, err := cache.GetLeaderboard(ctx, &g1, LeaderboardQuery{Metric: MetricTotal, Limit: 5})
+	require.NoError(t, err)
+	require.Equal(t, 1, page.Total)
+	rank, err := cache.GetUserRank(ctx, "alice", nil, MetricHighestStreak)
+	require.NoError(t, err)
+	require.Equal(t, 2, rank)
+	_, err = cache.GetLeaderboard(ctx, nil, LeaderboardQuery{Metric: "username", Limit: 5})
+	require.ErrorIs(t, err, ErrInvalidInput)
+
+	// A player the cache hasn't seen yet is ranked by the store.
+	_, err = cache.GetLeaderboard(ctx, nil, LeaderboardQuery{Metric: MetricTotal, Limit: 5})
+	require.NoError(t, err)
+	meow(t, store, "g1", "carol", true, 9)
+	rank, err = cache.GetUserRank(ctx, "carol", nil, MetricTotal)
+	require.NoError(t, err)
+	require.Equal(t, 1, rank)
+	_, err = cache.GetUserRank(ctx, "nobody", nil, MetricTotal)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestRankCache_ImportGuild(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	cache := NewRankCache(store, time.Minute)
+	seedStore(t, cache)
+	meow(t, cache, "g1", "alice", true, 1)
+	meow(t, cache, "g1", "bob", true, 2)
+	export, err := cache.ExportGuild(ctx, "g1")
+	require.NoError(t, err)
+
+	meow(t, cache, "g1", "alice", true, 5)
+
+	// A dry run leaves the ranking alone...
+	_, err = cache.ImportGuild(ctx, export, true)
+	require.NoError(t, err)
+	require.Equal(t, "alice #1 6/6/0", standings(t, cache, MetricTotal)[0])
+
+	// ...while an import that lowers counters shows up at once.
+	_, err = cache.ImportGuild(ctx, export, false)
+	require.NoError(t, err)
+	for _, m := range rankCacheMetrics {
+		require.Equal(t, standings(t, store, m), standings(t, cache, m), "metric %s after the import", m)
+	}
+	require.Equal(t, "bob #1 2/2/0", standings(t, cache, MetricTotal)[0])
+
+	meow(t, cache, "g1", "alice", false, 2)
+	for _, m := range rankCacheMetrics {
+		require.Equal(t, standings(t, store, m), standings(t, cache, m), "metric %s after more meows", m)
+	}
+}
+
+// seedBenchmark fills db with players meowing in a few guilds each.
+func seedBenchmark(b *testing.B, db *sql.DB, players, guilds int) {
+	b.Helper()
+	ctx := context.Background()
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(b, err)
+	defer tx.Rollback()
+	for g := range guilds {
+		_, err := tx.ExecContext(ctx, `INSERT INTO guilds (id) VALUES ($1)`, fmt.Sprintf("guild-%d", g))
+		require.NoError(b, err)
+	}
+	for p := range players {
+		userID := fmt.Sprintf("user-%06d", p)
+		_, err := tx.ExecContext(ctx, `INSERT INTO users (id, username) VALUES ($1, $1)`, userID)
+		require.NoError(b, err)
+		for _, g := range rng.Perm(guilds)[:3] {
+			successful, failed := rng.IntN(500), rng.IntN(50)
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO user_guild_stats (guild_id, user_id, successful_meows, failed_meows, total_meows)
+				VALUES ($1, $2, $3, $4, $5)
+			`, fmt.Sprintf("guild-%d", g), userID, successful, failed, successful+failed)
+			require.NoError(b, err)
+		}
+	}
+	require.NoError(b, tx.Commit())
+}
+
+// BenchmarkGlobalRanking compares the global leaderboard and rank queries
+// with the same reads answered by a RankCache.
+func BenchmarkGlobalRanking(b *testing.B) {
+	ctx := context.Background()
+	db, err := OpenSQLite(ctx, ":memory:")
+	require.NoError(b, err)
+	defer db.Close()
+	require.NoError(b, Migrate(ctx, db))
+	seedBenchmark(b, db, 10000, 50)
+
+	for _, bench := range []struct {
+		name  string
+		store Store
+	}{
+		{"query", NewSQLStore(db)},
+		{"cached", NewRankCache(NewSQLStore(db), time.Hour)},
+	} {
+		name, s := bench.name, bench.store
+		b.Run(name+"/page", func(b *testing.B) {
+			q := LeaderboardQuery{Metric: MetricTotal, Limit: 10, Page: 42}
+			for b.Loop() {
+				if _, err := s.GetLeaderboard(ctx, nil, q); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(name+"/rank", func(b *testing.B) {
+			for b.Loop() {
+				if _, err := s.GetUserRank(ctx, "user-004242", nil, MetricSuccessful); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RecordMeowEvent stores a processed meow for later recaps.
+func RecordMeowEvent(ctx context.Context, db *sql.DB, event MeowEvent) error {
+	defer trace(ctx, "RecordMeowEvent")()
+
+	query := `
+		INSERT INTO meow_events (guild_id, user_id, success, chain, broken, high_score, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);
+	`
+
+	_, err := db.ExecContext(ctx, query, event.GuildID, event.UserID, event.Success, event.Chain, event.Broken, event.HighScore, event.CreatedAt)
+	if err != nil {
+		return classify(fmt.Errorf("failed to record meow event: %w", err))
+	}
+	return nil
+}
+
+// UpsertRecapSettings opts a guild in to recaps, or changes its frequency or
+// channel. lastPeriodEnd marks the periods before it as already posted.
+func UpsertRecapSettings(ctx context.Context, db *sql.DB, settings RecapSettings) error {
+	defer trace(ctx, "UpsertRecapSettings")()
+
+	// GREATEST skips NULLs where SQLite's MAX returns NULL, hence the COALESCEs.
+	query := dialectOf(db).pick(`
+		INSERT INTO recap_settings (guild_id, frequency, channel_id, last_period_end)
+		VALUES ($1, $2, NULLIF($3, ''), $4)
+		ON CONFLICT (guild_id) DO UPDATE SET
+			frequency = EXCLUDED.frequency,
+			channel_id = EXCLUDED.channel_id,
+			last_period_end = GREATEST(recap_settings.last_period_end, EXCLUDED.last_period_end);
+	`, `
+		INSERT INTO recap_settings (guild_id, frequency, channel_id, last_period_end)
+		VALUES ($1, $2, NULLIF($3, ''), $4)
+		ON CONFLICT (guild_id) DO UPDATE SET
+			frequency = EXCLUDED.frequency,
+			channel_id = EXCLUDED.channel_id,
+			last_period_end = MAX(
+				COALESCE(recap_settings.last_period_end, EXCLUDED.last_period_end),
+				COALESCE(EXCLUDED.last_period_end, recap_settings.last_period_end)
+			);
+	`)
+
+	_, err := db.ExecContext(ctx, query, settings.GuildID, settings.Frequency, settings.ChannelID, settings.LastPeriodEnd)
+	if err != nil {
+		return classify(fmt.Errorf("failed to upsert recap settings: %w", err))
+	}
+	return nil
+}
+
+// DeleteRecapSettings opts a guild out of recaps. It reports false if the
+// guild hadn't opted in.
+func DeleteRecapSettings(ctx context.Context, db *sql.DB, guildID string) (bool, error) {
+	defer trace(ctx, "DeleteRecapSettings")()
+
+	res, err := db.ExecContext(ctx, `DELETE FROM recap_settings WHERE guild_id = $1;`, guildID)
+	if err != nil {
+		return false, classify(fmt.Errorf("failed to delete recap settings: %w", err))
+	}
+	n, err := res.RowsAffected()
+	return n > 0, classify(err)
+}
+
+// GetGuildRecapSettings returns the guild's recap settings, or nil if it
+// hasn't opted in.
+func GetGuildRecapSettings(ctx context.Context, db *sql.DB, guildID string) (*RecapSettings, error) {
+	defer trace(ctx, "GetGuildRecapSettings")()
+
+	var rs RecapSettings
+	err := db.QueryRowContext(ctx, `
+		SELECT guild_id, frequency, COALESCE(channel_id, ''), last_period_end
+		FROM recap_settings
+		WHERE guild_id = $1;
+	`, guildID).Scan(&rs.GuildID, &rs.Frequency, &rs.ChannelID, &rs.LastPeriodEnd)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to get recap settings: %w", err))
+	}
+	return &rs, nil
+}
+
+// GetRecapSettings returns every guild's recap settings.
+func GetRecapSettings(ctx context.Context, db *sql.DB) ([]RecapSettings, error) {
+	defer trace(ctx, "GetRecapSettings")()
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT guild_id, frequency, COALESCE(channel_id, ''), last_period_end
+		FROM recap_settings
+		ORDER BY guild_id;
+	`)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to get recap settings: %w", err))
+	}
+	defer rows.Close()
+
+	var settings []RecapSettings
+	for rows.Next() {
+		var rs RecapSettings
+		if err := rows.Scan(&rs.GuildID, &rs.Frequency, &rs.ChannelID, &rs.LastPeriodEnd); err != nil {
+			return nil, classify(err)
+		}
+		settings = append(settings, rs)
+	}
+	return settings, classify(rows.Err())
+}
+
+// ClaimRecapPeriod marks the recap for the period ending at periodEnd as
+// posted. It reports false if that period (or a later one) was already
+// claimed, so a recap is never posted twice, even across restarts or by
+// several bot instances.
+func ClaimRecapPeriod(ctx context.Context, db *sql.DB, guildID string, periodEnd time.Time) (bool, error) {
+	defer trace(ctx, "ClaimRecapPeriod")()
+
+	res, err := db.ExecContext(ctx, `
+		UPDATE recap_settings SET last_period_end = $2
+		WHERE guild_id = $1 AND (last_period_end IS NULL OR last_period_end < $2);
+	`, guildID, periodEnd)
+	if err != nil {
+		return false, classify(fmt.Errorf("failed to claim recap period: %w", err))
+	}
+	n, err := res.RowsAffected()
+	return n > 0, classify(err)
+}
+
+// GetGuildRecap summarizes the guild's meows in [start, end).
+func GetGuildRecap(ctx context.Context, db *sql.DB, guildID string, start, end time.Time, topN int) (*GuildRecap, error) {
+	defer trace(ctx, "GetGuildRecap")()
+
+	recap := &GuildRecap{Start: start, End: end}
+
+	// Every meow of a chain past the record is a high score, so a record is
+	// only counted where the guild's previous event wasn't one: where the
+	// chain first beat it, even if that chain began before start.
+	err := db.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE success),
+			COALESCE(MAX(chain), 0),
+			(
+				SELECT COUNT(*)
+				FROM (
+					SELECT created_at, high_score,
+						LAG(high_score, 1, FALSE) OVER (ORDER BY created_at, id) AS continues_record
+					FROM meow_events
+					WHERE guild_id = $1 AND created_at < $3
+				) r
+				WHERE r.high_score AND NOT r.continues_record AND r.created_at >= $2
+			)
+		FROM meow_events
+		WHERE guild_id = $1 AND created_at >= $2 AND created_at < $3;
+	`, guildID, start, end).Scan(&recap.TotalMeows, &recap.SuccessfulMeows, &recap.BestStreak, &recap.NewRecords)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to summarize meows: %w", err))
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT u.id, u.username, COALESCE(u.display_name, ''), COALESCE(u.avatar, ''), u.created_at, COUNT(*) AS successful
+		FROM meow_events e
+		JOIN users u ON u.id = e.user_id
+		WHERE e.guild_id = $1 AND e.created_at >= $2 AND e.created_at < $3 AND e.success
+		GROUP BY u.id, u.username, u.display_name, u.avatar, u.created_at
+		ORDER BY successful DESC, u.id
+		LIMIT $4;
+	`, guildID, start, end, topN)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to get top contributors: %w", err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var user User
+		entry := LeaderboardEntry{User: &user}
+		if err := rows.Scan(&user.ID, &user.Username, &user.DisplayName, &user.Avatar, &user.CreatedAt, &entry.SuccessfulMeows); err != nil {
+			return nil, classify(err)
+		}
+		recap.TopContributors = append(recap.TopContributors, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, classify(err)
+	}
+
+	var breaker User
+	err = db.QueryRowContext(ctx, `
+		SELECT u.id, u.username, COALESCE(u.display_name, ''), COALESCE(u.avatar, ''), u.created_at, e.broken
+		FROM meow_events e
+		JOIN users u ON u.id = e.user_id
+		WHERE e.guild_id = $1 AND e.created_at >= $2 AND e.created_at < $3 AND e.broken > 0
+		ORDER BY e.broken DESC, e.created_at
+		LIMIT 1;
+	`, guildID, start, end).Scan(&breaker.ID, &breaker.Username, &breaker.DisplayName, &breaker.Avatar, &breaker.CreatedAt, &recap.BrokenStreak)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return nil, classify(fmt.Errorf("failed to get biggest breaker: %w", err))
+	default:
+		recap.Breaker = &breaker
+	}
+
+	return recap, nil
+}
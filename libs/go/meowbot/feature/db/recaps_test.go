@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimRecapPeriod(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	end := time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE recap_settings SET last_period_end = $2`)).
+		WithArgs("guild-foo", end).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE recap_settings SET last_period_end = $2`)).
+		WithArgs("guild-foo", end).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	claimed, err := ClaimRecapPeriod(context.Background(), mockDB, "guild-foo", end)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	// A second claim for the same period must not post the recap again.
+	claimed, err = ClaimRecapPeriod(context.Background(), mockDB, "guild-foo", end)
+	require.NoError(t, err)
+	require.False(t, claimed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetGuildRecap(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM meow_events`)).
+		WithArgs("guild-foo", start, end).
+		WillReturnRows(sqlmock.NewRows([]string{"total", "success", "best", "records"}).AddRow(30, 27, 12, 2))
+	mock.ExpectQuery(regexp.QuoteMeta(`GROUP BY u.id`)).
+		WithArgs("guild-foo", start, end, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "display_name", "avatar", "created_at", "successful"}).
+			AddRow("user-1", "alice", "Alice", "", now, 15).
+			AddRow("user-2", "bob", "", "", now, 12))
+	mock.ExpectQuery(regexp.QuoteMeta(`e.broken > 0`)).
+		WithArgs("guild-foo", start, end).
+		WillReturnError(sql.ErrNoRows)
+
+	recap, err := GetGuildRecap(context.Background(), mockDB, "guild-foo", start, end, 3)
+	require.NoError(t, err)
+	require.Equal(t, 30, recap.TotalMeows)
+	require.Equal(t, 12, recap.BestStreak)
+	require.Equal(t, 2, recap.NewRecords)
+	require.Len(t, recap.TopContributors, 2)
+	require.Equal(t, "alice", recap.TopContributors[0].User.Username)
+	require.Equal(t, "Alice", recap.TopContributors[0].User.Name())
+	require.Equal(t, "bob", recap.TopContributors[1].User.Name())
+	require.Nil(t, recap.Breaker)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
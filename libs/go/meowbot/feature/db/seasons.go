@@ -0,0 +1,257 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const seasonColumns = `id, guild_id, name, starts_at, ends_at, closed_at`
+
+func scanSeason(row interface{ Scan(...any) error }) (*Season, error) {
+	var s Season
+	if err := row.Scan(&s.ID, &s.GuildID, &s.Name, &s.StartsAt, &s.EndsAt, &s.ClosedAt); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// CreateSeason creates a season. It fails with ErrInvalidInput if the season
+// ends before it starts or overlaps another open season in the same scope.
+func CreateSeason(ctx context.Context, db *sql.DB, season Season) (*Season, error) {
+	defer trace(ctx, "CreateSeason")()
+
+	if !season.EndsAt.After(season.StartsAt) {
+		return nil, fmt.Errorf("%w: season must end after it starts", ErrInvalidInput)
+	}
+
+	// Postgres needs the casts to type the parameters of INSERT ... SELECT;
+	// SQLite has no such syntax and doesn't need it.
+	query := dialectOf(db).pick(`
+		INSERT INTO seasons (guild_id, name, starts_at, ends_at)
+		SELECT $1::text, $2::text, $3::timestamptz, $4::timestamptz
+		WHERE NOT EXISTS (
+			SELECT 1 FROM seasons
+			WHERE guild_id IS NOT DISTINCT FROM $1::text
+			  AND closed_at IS NULL
+			  AND starts_at < $4::timestamptz
+			  AND ends_at > $3::timestamptz
+		)
+		RETURNING `+seasonColumns, `
+		INSERT INTO seasons (guild_id, name, starts_at, ends_at)
+		SELECT $1, $2, $3, $4
+		WHERE NOT EXISTS (
+			SELECT 1 FROM seasons
+			WHERE guild_id IS NOT DISTINCT FROM $1
+			  AND closed_at IS NULL
+			  AND starts_at < $4
+			  AND ends_at > $3
+		)
+		RETURNING `+seasonColumns)
+
+	created, err := scanSeason(db.QueryRowContext(ctx, query, season.GuildID, season.Name, season.StartsAt, season.EndsAt))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: season overlaps an existing season", ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to create season: %w", err))
+	}
+	return created, nil
+}
+
+// GetSeason returns the season with the given ID.
+func GetSeason(ctx context.Context, db *sql.DB, id int64) (*Season, error) {
+	defer trace(ctx, "GetSeason")()
+
+	query := `SELECT ` + seasonColumns + ` FROM seasons WHERE id = $1`
+
+	season, err := scanSeason(db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to get season: %w", err))
+	}
+	return season, nil
+}
+
+// GetActiveSeason returns the open season running at the given time for a
+// guild, preferring the guild's own season over a global one. A nil guildID
+// only considers global seasons. It returns nil if no season is running.
+func GetActiveSeason(ctx context.Context, db *sql.DB, guildID *string, at time.Time) (*Season, error) {
+	defer trace(ctx, "GetActiveSeason")()
+
+	query := `
+		SELECT ` + seasonColumns + `
+		FROM seasons
+		WHERE (guild_id = $1 OR guild_id IS NULL)
+		  AND closed_at IS NULL
+		  AND starts_at <= $2 AND ends_at > $2
+		ORDER BY guild_id NULLS LAST
+		LIMIT 1
+	`
+
+	season, err := scanSeason(db.QueryRowContext(ctx, query, guildID, at))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to get active season: %w", err))
+	}
+	return season, nil
+}
+
+// ListSeasons lists a guild's seasons and the global ones, newest first. A nil
+// guildID lists only global seasons.
+func ListSeasons(ctx context.Context, db *sql.DB, guildID *string) ([]Season, error) {
+	defer trace(ctx, "ListSeasons")()
+
+	query := `
+		SELECT ` + seasonColumns + `
+		FROM seasons
+		WHERE guild_id = $1 OR guild_id IS NULL
+		ORDER BY starts_at DESC, id DESC
+	`
+	return querySeasons(ctx, db, query, guildID)
+}
+
+// GetDueSeasons lists open seasons whose end has passed.
+func GetDueSeasons(ctx context.Context, db *sql.DB, now time.Time) ([]Season, error) {
+	defer trace(ctx, "GetDueSeasons")()
+
+	query := `
+		SELECT ` + seasonColumns + `
+		FROM seasons
+		WHERE closed_at IS NULL AND ends_at <= $1
+		ORDER BY ends_at, id
+	`
+	return querySeasons(ctx, db, query, now)
+}
+
+func querySeasons(ctx context.Context, db *sql.DB, query string, args ...any) ([]Season, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to query seasons: %w", err))
+	}
+	defer rows.Close()
+
+	var seasons []Season
+	for rows.Next() {
+		season, err := scanSeason(rows)
+		if err != nil {
+			return nil, classify(err)
+		}
+		seasons = append(seasons, *season)
+	}
+	return seasons, classify(rows.Err())
+}
+
+// IncrementSeasonMeow counts a meow towards every season running in the guild
+// at the given time, i.e. the guild's own season and any global one.
+func IncrementSeasonMeow(ctx context.Context, db *sql.DB, guildID, userID string, success bool, now time.Time) error {
+	defer trace(ctx, "IncrementSeasonMeow")()
+
+	query := `
+		INSERT INTO season_stats (season_id, guild_id, user_id, successful_meows, failed_meows, total_meows)
+		SELECT s.id, $1, $2,
+			CASE WHEN $4 THEN 1 ELSE 0 END,
+			CASE WHEN $4 THEN 0 ELSE 1 END,
+			1
+		FROM seasons s
+		WHERE (s.guild_id = $1 OR s.guild_id IS NULL)
+		  AND s.closed_at IS NULL
+		  AND s.starts_at <= $3 AND s.ends_at > $3
+		ON CONFLICT (season_id, guild_id, user_id) DO UPDATE SET
+			successful_meows = season_stats.successful_meows + EXCLUDED.successful_meows,
+			failed_meows = season_stats.failed_meows + EXCLUDED.failed_meows,
+			total_meows = season_stats.total_meows + 1;
+	`
+
+	_, err := db.ExecContext(ctx, query, guildID, userID, now, success)
+	if err != nil {
+		return classify(fmt.Errorf("failed to increment season meow: %w", err))
+	}
+	return nil
+}
+
+// CloseSeason archives the season's final standings, ranked by total meows,
+// and resets its counters. It reports false if the season was already closed,
+// so concurrent callers only close a season once.
+func CloseSeason(ctx context.Context, db *sql.DB, seasonID int64, now time.Time) (closed bool, err error) {
+	defer trace(ctx, "CloseSeason")()
+
+	err = withTx(ctx, db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `UPDATE seasons SET closed_at = $2 WHERE id = $1 AND closed_at IS NULL`, seasonID, now)
+		if err != nil {
+			return classify(fmt.Errorf("failed to close season: %w", err))
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			return classify(err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO season_standings (season_id, user_id, rank, successful_meows, failed_meows, total_meows)
+			SELECT season_id, user_id,
+				RANK() OVER (ORDER BY SUM(total_meows) DESC),
+				SUM(successful_meows), SUM(failed_meows), SUM(total_meows)
+			FROM season_stats
+			WHERE season_id = $1
+			GROUP BY season_id, user_id
+		`, seasonID)
+		if err != nil {
+			return classify(fmt.Errorf("failed to archive season standings: %w", err))
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM season_stats WHERE season_id = $1`, seasonID); err != nil {
+			return classify(fmt.Errorf("failed to reset season stats: %w", err))
+		}
+
+		closed = true
+		return nil
+	})
+	return closed, err
+}
+
+// seasonSource is the table holding a season's counters: the live counters
+// while it is open, the archived standings once it is closed.
+func seasonSource(season *Season) string {
+	if season.ClosedAt != nil {
+		return "season_standings"
+	}
+	return "season_stats"
+}
+
+// GetSeasonLeaderboard returns the page of a season's leaderboard that q
+// selects. Seasons only keep the meow counters, so q.Metric is one of
+// MetricTotal, MetricSuccessful and MetricFailed.
+func GetSeasonLeaderboard(ctx context.Context, db *sql.DB, season *Season, q LeaderboardQuery) (*LeaderboardPage, error) {
+	defer trace(ctx, "GetSeasonLeaderboard")()
+
+	column, err := q.Metric.counterColumn()
+	if err != nil {
+		return nil, err
+	}
+	if err := q.validate(); err != nil {
+		return nil, err
+	}
+
+	var rq rankQuery
+	query := rq.page(rq.ranked(column, seasonSource(season), "WHERE season_id = "+rq.arg(season.ID)), q)
+	return queryLeaderboardPage(ctx, db, query, rq.args, q)
+}
+
+// GetSeasonUserRank returns the user's rank by metric in the season.
+func GetSeasonUserRank(ctx context.Context, db *sql.DB, season *Season, userID string, metric Metric) (int, error) {
+	defer trace(ctx, "GetSeasonUserRank")()
+
+	column, err := metric.counterColumn()
+	if err != nil {
+		return 0, err
+	}
+
+	var rq rankQuery
+	query := rq.rank(rq.ranked(column, seasonSource(season), "WHERE season_id = "+rq.arg(season.ID)), userID)
+
+	var rank int
+	err = db.QueryRowContext(ctx, query, rq.args...).Scan(&rank)
+	return rank, classify(err)
+}
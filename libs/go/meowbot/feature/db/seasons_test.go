@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSeason_InvalidDates(t *testing.T) {
+	now := time.Now()
+	_, err := CreateSeason(context.Background(), nil, Season{Name: "S1", StartsAt: now, EndsAt: now})
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestCreateSeason_Overlap(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	guildID := "guild-foo"
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO seasons (guild_id, name, starts_at, ends_at)`)).
+		WithArgs(&guildID, "June", start, end).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "guild_id", "name", "starts_at", "ends_at", "closed_at"}))
+
+	_, err := CreateSeason(context.Background(), mockDB, Season{GuildID: &guildID, Name: "June", StartsAt: start, EndsAt: end})
+	require.ErrorIs(t, err, ErrInvalidInput)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCloseSeason(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE seasons SET closed_at = $2 WHERE id = $1 AND closed_at IS NULL`)).
+		WithArgs(int64(7), now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO season_standings`)).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM season_stats WHERE season_id = $1`)).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectCommit()
+
+	closed, err := CloseSeason(context.Background(), mockDB, 7, now)
+	require.NoError(t, err)
+	require.True(t, closed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCloseSeason_AlreadyClosed(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE seasons SET closed_at = $2 WHERE id = $1 AND closed_at IS NULL`)).
+		WithArgs(int64(7), now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	closed, err := CloseSeason(context.Background(), mockDB, 7, now)
+	require.NoError(t, err)
+	require.False(t, closed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSeasonLeaderboard_ClosedSeasonReadsArchive(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	closedAt := time.Now()
+	season := &Season{ID: 7, Name: "June", ClosedAt: &closedAt}
+
+	rows := sqlmock.NewRows([]string{"id", "username", "display_name", "avatar", "created_at", "successful", "failed", "total", "value", "rank", "position", "total"}).
+		AddRow("user-1", "kitty", "", "", time.Now(), 40, 2, 42, 42, 1, 1, 1)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM season_standings`)).
+		WithArgs(int64(7), 0, 5, 5, 5).
+		WillReturnRows(rows)
+
+	page, err := GetSeasonLeaderboard(context.Background(), mockDB, season, LeaderboardQuery{Metric: MetricTotal, Limit: 5})
+	require.NoError(t, err)
+	require.Equal(t, 1, page.Total)
+	require.Len(t, page.Entries, 1)
+	require.Equal(t, 42, page.Entries[0].TotalMeows)
+	require.Equal(t, 1, page.Entries[0].Rank)
+	require.Nil(t, page.Next)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSeasonLeaderboard_InvalidMetric(t *testing.T) {
+	_, err := GetSeasonLeaderboard(context.Background(), nil, &Season{ID: 7}, LeaderboardQuery{Metric: "username; DROP TABLE users", Limit: 5})
+	require.ErrorIs(t, err, ErrInvalidInput)
+	_, err = GetSeasonUserRank(context.Background(), nil, &Season{ID: 7}, "user-1", MetricHighestStreak)
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
@@ -8,18 +8,63 @@ import (
 	"time"
 )
 
+// UpsertUser creates or updates a user's profile and records their username
+// in username_history when it is new or has changed.
 func UpsertUser(ctx context.Context, db *sql.DB, user User) error {
-	query := `
-		INSERT INTO users (id, username)
-		VALUES ($1, $2)
-		ON CONFLICT (id) DO UPDATE
-		SET username = EXCLUDED.username;
-	`
-	_, err := db.ExecContext(ctx, query, user.ID, user.Username)
-	return err
+	defer trace(ctx, "UpsertUser")()
+
+	d := dialectOf(db)
+	return withTx(ctx, db, func(tx *sql.Tx) error {
+		var previous sql.NullString
+		err := tx.QueryRowContext(ctx, d.pick(`
+			SELECT username FROM users WHERE id = $1 FOR UPDATE;
+		`, `
+			SELECT username FROM users WHERE id = $1;
+		`), user.ID).Scan(&previous)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return classify(fmt.Errorf("failed to read user: %w", err))
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO users (id, username, display_name, avatar)
+			VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''))
+			ON CONFLICT (id) DO UPDATE
+			SET username = EXCLUDED.username,
+				display_name = EXCLUDED.display_name,
+				avatar = EXCLUDED.avatar;
+		`, user.ID, user.Username, user.DisplayName, user.Avatar)
+		if err != nil {
+			return classify(fmt.Errorf("failed to upsert user: %w", err))
+		}
+
+		if user.Username == "" || user.Username == previous.String {
+			return nil
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO username_history (user_id, username)
+			VALUES ($1, $2);
+		`, user.ID, user.Username)
+		if err != nil {
+			return classify(fmt.Errorf("failed to record username: %w", err))
+		}
+		return nil
+	})
 }
 
+const guildColumns = `id, COALESCE(name, ''), COALESCE(icon, ''), COALESCE(member_count, 0), COALESCE(owner_id, ''), present, joined_at, left_at, created_at`
+
+func scanGuild(row interface{ Scan(...any) error }) (*Guild, error) {
+	var g Guild
+	if err := row.Scan(&g.ID, &g.Name, &g.Icon, &g.MemberCount, &g.OwnerID, &g.Present, &g.JoinedAt, &g.LeftAt, &g.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// UpsertGuild makes sure a guild exists, leaving an existing one as it is.
 func UpsertGuild(ctx context.Context, db *sql.DB, guild Guild) error {
+	defer trace(ctx, "UpsertGuild")()
+
 	query := `
 		INSERT INTO guilds (id)
 		VALUES ($1)
@@ -27,10 +72,61 @@ func UpsertGuild(ctx context.Context, db *sql.DB, guild Guild) error {
 	`
 
 	_, err := db.ExecContext(ctx, query, guild.ID)
-	return err
+	return classify(err)
+}
+
+// SyncGuild stores the details Discord sent for a guild the bot is in. A
+// zero MemberCount or nil JoinedAt, which guild updates leave out, keeps the
+// stored value.
+func SyncGuild(ctx context.Context, db *sql.DB, guild Guild) error {
+	defer trace(ctx, "SyncGuild")()
+
+	query := `
+		INSERT INTO guilds (id, name, icon, member_count, owner_id, present, joined_at, left_at)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, 0), NULLIF($5, ''), TRUE, $6, NULL)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			icon = EXCLUDED.icon,
+			member_count = COALESCE(EXCLUDED.member_count, guilds.member_count),
+			owner_id = EXCLUDED.owner_id,
+			present = TRUE,
+			joined_at = COALESCE(EXCLUDED.joined_at, guilds.joined_at),
+			left_at = NULL;
+	`
+
+	_, err := db.ExecContext(ctx, query, guild.ID, guild.Name, guild.Icon, guild.MemberCount, guild.OwnerID, guild.JoinedAt)
+	if err != nil {
+		return classify(fmt.Errorf("failed to sync guild: %w", err))
+	}
+	return nil
+}
+
+// LeaveGuild records that the bot left a guild at at. Guilds that were never
+// stored are ignored.
+func LeaveGuild(ctx context.Context, db *sql.DB, guildID string, at time.Time) error {
+	defer trace(ctx, "LeaveGuild")()
+
+	_, err := db.ExecContext(ctx, `UPDATE guilds SET present = FALSE, left_at = $2 WHERE id = $1;`, guildID, at)
+	if err != nil {
+		return classify(fmt.Errorf("failed to leave guild: %w", err))
+	}
+	return nil
+}
+
+// GetGuild returns a guild, or ErrNotFound.
+func GetGuild(ctx context.Context, db *sql.DB, guildID string) (*Guild, error) {
+	defer trace(ctx, "GetGuild")()
+
+	g, err := scanGuild(db.QueryRowContext(ctx, `SELECT `+guildColumns+` FROM guilds WHERE id = $1`, guildID))
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to get guild: %w", err))
+	}
+	return g, nil
 }
 
 func UpsertGuildChannel(ctx context.Context, db *sql.DB, guildID, channelID string) error {
+	defer trace(ctx, "UpsertGuildChannel")()
+
 	query := `
 		INSERT INTO guild_channels (guild_id, channel_id)
 		VALUES ($1, $2)
@@ -40,12 +136,14 @@ func UpsertGuildChannel(ctx context.Context, db *sql.DB, guildID, channelID stri
 
 	_, err := db.ExecContext(ctx, query, guildID, channelID)
 	if err != nil {
-		return fmt.Errorf("failed to upsert guild channel: %w", err)
+		return classify(fmt.Errorf("failed to upsert guild channel: %w", err))
 	}
 	return nil
 }
 
 func GetChannelForGuild(ctx context.Context, db *sql.DB, guildID string) (string, error) {
+	defer trace(ctx, "GetChannelForGuild")()
+
 	query := `SELECT channel_id FROM guild_channels WHERE guild_id = $1;`
 
 	var channelID string
@@ -55,13 +153,90 @@ func GetChannelForGuild(ctx context.Context, db *sql.DB, guildID string) (string
 		return "", nil
 	}
 	if err != nil {
-		return "", fmt.Errorf("failed to get guild channel: %w", err)
+		return "", classify(fmt.Errorf("failed to get guild channel: %w", err))
 	}
 	return channelID, nil
 }
 
+// GetAllGuildChannels maps every guild with a configured meow channel to
+// that channel's ID.
+func GetAllGuildChannels(ctx context.Context, db *sql.DB) (map[string]string, error) {
+	defer trace(ctx, "GetAllGuildChannels")()
+
+	rows, err := db.QueryContext(ctx, `SELECT guild_id, channel_id FROM guild_channels;`)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to get guild channels: %w", err))
+	}
+	defer rows.Close()
+
+	channels := make(map[string]string)
+	for rows.Next() {
+		var guildID, channelID string
+		if err := rows.Scan(&guildID, &channelID); err != nil {
+			return nil, classify(err)
+		}
+		channels[guildID] = channelID
+	}
+	return channels, classify(rows.Err())
+}
+
+// GetGuildSettings returns the guild's settings. Guilds without a settings row
+// get zero-valued settings.
+func GetGuildSettings(ctx context.Context, db *sql.DB, guildID string) (*GuildSettings, error) {
+	defer trace(ctx, "GetGuildSettings")()
+
+	query := `SELECT guild_id, COALESCE(locale, ''), COALESCE(timezone, '') FROM guild_settings WHERE guild_id = $1;`
+
+	var settings GuildSettings
+	err := db.QueryRowContext(ctx, query, guildID).Scan(&settings.GuildID, &settings.Locale, &settings.Timezone)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &GuildSettings{GuildID: guildID}, nil
+	}
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to get guild settings: %w", err))
+	}
+	return &settings, nil
+}
+
+func UpsertGuildLocale(ctx context.Context, db *sql.DB, guildID, locale string) error {
+	defer trace(ctx, "UpsertGuildLocale")()
+
+	query := `
+		INSERT INTO guild_settings (guild_id, locale)
+		VALUES ($1, $2)
+		ON CONFLICT (guild_id) DO UPDATE SET
+			locale = EXCLUDED.locale;
+	`
+
+	_, err := db.ExecContext(ctx, query, guildID, locale)
+	if err != nil {
+		return classify(fmt.Errorf("failed to upsert guild locale: %w", err))
+	}
+	return nil
+}
+
+func UpsertGuildTimezone(ctx context.Context, db *sql.DB, guildID, timezone string) error {
+	defer trace(ctx, "UpsertGuildTimezone")()
+
+	query := `
+		INSERT INTO guild_settings (guild_id, timezone)
+		VALUES ($1, $2)
+		ON CONFLICT (guild_id) DO UPDATE SET
+			timezone = EXCLUDED.timezone;
+	`
+
+	_, err := db.ExecContext(ctx, query, guildID, timezone)
+	if err != nil {
+		return classify(fmt.Errorf("failed to upsert guild timezone: %w", err))
+	}
+	return nil
+}
+
 func IncrementMeow(ctx context.Context, db *sql.DB, guildID, userID string, success bool, now time.Time) error {
-	successQuery := `
+	defer trace(ctx, "IncrementMeow")()
+
+	// SQLite has no GREATEST; its two-argument MAX is the same thing.
+	successQuery := dialectOf(db).pick(`
 			INSERT INTO user_guild_stats (guild_id, user_id, successful_meows, total_meows, current_streak, highest_streak, last_meow_at)
 			VALUES ($1, $2, 1, 1, 1, 1, $3)
 			ON CONFLICT (guild_id, user_id)
@@ -71,7 +246,17 @@ func IncrementMeow(ctx context.Context, db *sql.DB, guildID, userID string, succ
 				current_streak = user_guild_stats.current_streak + 1,
 				highest_streak = GREATEST(user_guild_stats.highest_streak, user_guild_stats.current_streak + 1),
 				last_meow_at = $3;
-	`
+	`, `
+			INSERT INTO user_guild_stats (guild_id, user_id, successful_meows, total_meows, current_streak, highest_streak, last_meow_at)
+			VALUES ($1, $2, 1, 1, 1, 1, $3)
+			ON CONFLICT (guild_id, user_id)
+			DO UPDATE SET
+				successful_meows = user_guild_stats.successful_meows + 1,
+				total_meows = user_guild_stats.total_meows + 1,
+				current_streak = user_guild_stats.current_streak + 1,
+				highest_streak = MAX(user_guild_stats.highest_streak, user_guild_stats.current_streak + 1),
+				last_meow_at = $3;
+	`)
 	failureQuery := `
 		INSERT INTO user_guild_stats (guild_id, user_id, failed_meows, total_meows, current_streak, last_failed_meow_at)
 		VALUES ($1, $2, 1, 1, 0, $3)
@@ -85,14 +270,16 @@ func IncrementMeow(ctx context.Context, db *sql.DB, guildID, userID string, succ
 
 	if success {
 		_, err := db.ExecContext(ctx, successQuery, guildID, userID, now)
-		return err
+		return classify(err)
 	}
 
 	_, err := db.ExecContext(ctx, failureQuery, guildID, userID, now)
-	return err
+	return classify(err)
 }
 
 func GetGuildStreak(ctx context.Context, db *sql.DB, guildID string) (*GuildStreak, error) {
+	defer trace(ctx, "GetGuildStreak")()
+
 	query := `
 		SELECT guild_id, meow_count, last_user_id, high_score, high_score_user_id
 		FROM guild_streaks
@@ -104,12 +291,14 @@ func GetGuildStreak(ctx context.Context, db *sql.DB, guildID string) (*GuildStre
 	var gs GuildStreak
 	err := row.Scan(&gs.GuildID, &gs.MeowCount, &gs.LastUserID, &gs.HighScore, &gs.HighScoreUserID)
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
 	}
 	return &gs, nil
 }
 
 func UpsertGuildStreak(ctx context.Context, db *sql.DB, streak GuildStreak) error {
+	defer trace(ctx, "UpsertGuildStreak")()
+
 	query := `
 		INSERT INTO guild_streaks (guild_id, meow_count, last_user_id, high_score, high_score_user_id)
 		VALUES ($1, $2, $3, $4, $5)
@@ -128,7 +317,7 @@ func UpsertGuildStreak(ctx context.Context, db *sql.DB, streak GuildStreak) erro
 		streak.HighScore,
 		streak.HighScoreUserID,
 	)
-	return err
+	return classify(err)
 }
 
 func GetUserStats(
@@ -137,6 +326,8 @@ func GetUserStats(
 	guildID *string, // nil means global
 	userID string,
 ) (UserGuildStats, error) {
+	defer trace(ctx, "GetUserStats")()
+
 	var (
 		query string
 		args  []any
@@ -189,8 +380,8 @@ func GetUserStats(
 		&stats.TotalMeows,
 		&stats.CurrentStreak,
 		&stats.HighestStreak,
-		&stats.LastMeowAt,
-		&stats.LastFailedMeowAt,
+		nullTime{&stats.LastMeowAt},
+		nullTime{&stats.LastFailedMeowAt},
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) && guildID != nil {
@@ -207,12 +398,14 @@ func GetUserStats(
 				LastFailedMeowAt: nil,
 			}, nil
 		}
-		return UserGuildStats{}, fmt.Errorf("GetUserStats: %w", err)
+		return UserGuildStats{}, classify(fmt.Errorf("GetUserStats: %w", err))
 	}
 	return stats, nil
 }
 
 func GetGlobalStats(ctx context.Context, db *sql.DB) (*GlobalStats, error) {
+	defer trace(ctx, "GetGlobalStats")()
+
 	guildsQuery := `SELECT COUNT(*) FROM guilds`
 	usersQuery := `SELECT COUNT(*) FROM users`
 	usersGuildStatsQuery := `SELECT COALESCE(SUM(total_meows), 0) FROM user_guild_stats`
@@ -220,23 +413,25 @@ func GetGlobalStats(ctx context.Context, db *sql.DB) (*GlobalStats, error) {
 
 	err := db.QueryRowContext(ctx, guildsQuery).Scan(&stats.TotalGuilds)
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
 	}
 
 	err = db.QueryRowContext(ctx, usersQuery).Scan(&stats.TotalUsers)
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
 	}
 
 	err = db.QueryRowContext(ctx, usersGuildStatsQuery).Scan(&stats.TotalMeows)
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
 	}
 
 	return &stats, nil
 }
 
 func GetUserGlobalStats(ctx context.Context, db *sql.DB, userID string) (UserGlobalStats, error) {
+	defer trace(ctx, "GetUserGlobalStats")()
+
 	query := `
 		SELECT 
 			u.id,
@@ -263,25 +458,27 @@ func GetUserGlobalStats(ctx context.Context, db *sql.DB, userID string) (UserGlo
 		&res.HighestStreak,
 	)
 	if err != nil {
-		return UserGlobalStats{}, err
+		return UserGlobalStats{}, classify(err)
 	}
 
 	return res, nil
 }
 
 func GetLeaderboard3(ctx context.Context, db *sql.DB, limit int) (entries []LeaderboardEntry, err error) {
+	defer trace(ctx, "GetLeaderboard3")()
+
 	query := `
-		SELECT u.id, u.username, u.created_at, SUM(ugs.total_meows) as total
+		SELECT u.id, u.username, COALESCE(u.display_name, ''), COALESCE(u.avatar, ''), u.created_at, SUM(ugs.total_meows) as total
 		FROM user_guild_stats ugs
 		JOIN users u ON ugs.user_id = u.id
-		GROUP BY u.id, u.username, u.created_at
+		GROUP BY u.id, u.username, u.display_name, u.avatar, u.created_at
 		ORDER BY total DESC
 		LIMIT $1;
 	`
 
 	rows, err := db.QueryContext(ctx, query, limit)
 	if err != nil {
-		return nil, fmt.Errorf("query leaderboard: %w", err)
+		return nil, classify(fmt.Errorf("query leaderboard: %w", err))
 	}
 	defer func() {
 		if closeErr := rows.Close(); closeErr != nil && err == nil {
@@ -293,9 +490,9 @@ func GetLeaderboard3(ctx context.Context, db *sql.DB, limit int) (entries []Lead
 		var user User
 		var entry LeaderboardEntry
 
-		err := rows.Scan(&user.ID, &user.Username, &user.CreatedAt, &entry.TotalMeows)
+		err := rows.Scan(&user.ID, &user.Username, &user.DisplayName, &user.Avatar, &user.CreatedAt, &entry.TotalMeows)
 		if err != nil {
-			return nil, fmt.Errorf("scan leaderboard row: %w", err)
+			return nil, classify(fmt.Errorf("scan leaderboard row: %w", err))
 		}
 
 		entry.User = &user
@@ -303,13 +500,15 @@ func GetLeaderboard3(ctx context.Context, db *sql.DB, limit int) (entries []Lead
 	}
 
 	if rowsErr := rows.Err(); rowsErr != nil {
-		return nil, fmt.Errorf("iterate leaderboard rows: %w", rowsErr)
+		return nil, classify(fmt.Errorf("iterate leaderboard rows: %w", rowsErr))
 	}
 
 	return entries, nil
 }
 
 func GetGuildStats(ctx context.Context, db *sql.DB, guildID string) (*GuildStats, error) {
+	defer trace(ctx, "GetGuildStats")()
+
 	query := `
 	SELECT
 		g.id, g.created_at,
@@ -319,7 +518,8 @@ func GetGuildStats(ctx context.Context, db *sql.DB, guildID string) (*GuildStats
 		hu.id, hu.username, hu.created_at,
 		COALESCE(SUM(ugs.total_meows), 0) as total_meows,
 		COALESCE(SUM(ugs.successful_meows), 0) as successful_meows,
-		COALESCE(SUM(ugs.failed_meows), 0) as failed_meows
+		COALESCE(SUM(ugs.failed_meows), 0) as failed_meows,
+		COUNT(ugs.user_id) as participants
 	FROM guild_streaks gs
 	JOIN guilds g ON g.id = gs.guild_id
 	LEFT JOIN users lu ON lu.id = gs.last_user_id
@@ -350,10 +550,11 @@ func GetGuildStats(ctx context.Context, db *sql.DB, guildID string) (*GuildStats
 		&stats.TotalMeows,
 		&stats.SuccessfulMeows,
 		&stats.FailedMeows,
+		&stats.Participants,
 	)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to scan guild stats: %w", err)
+		return nil, classify(fmt.Errorf("failed to scan guild stats: %w", err))
 	}
 
 	stats.Guild = &guild
@@ -379,12 +580,61 @@ func GetGuildStats(ctx context.Context, db *sql.DB, guildID string) (*GuildStats
 	return &stats, nil
 }
 
+func GetGuildTopMeower(ctx context.Context, db *sql.DB, guildID string) (*GuildTopMeower, error) {
+	defer trace(ctx, "GetGuildTopMeower")()
+
+	query := `
+		SELECT u.id, u.username, COALESCE(u.display_name, ''), COALESCE(u.avatar, ''), u.created_at, ugs.total_meows
+		FROM user_guild_stats ugs
+		JOIN users u ON u.id = ugs.user_id
+		WHERE ugs.guild_id = $1
+		ORDER BY ugs.total_meows DESC, ugs.user_id ASC
+		LIMIT 1;
+	`
+
+	var user User
+	var top GuildTopMeower
+	err := db.QueryRowContext(ctx, query, guildID).Scan(&user.ID, &user.Username, &user.DisplayName, &user.Avatar, &user.CreatedAt, &top.TotalMeows)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to get guild top meower: %w", err))
+	}
+
+	top.User = &user
+	return &top, nil
+}
+
+// GetGuildRank returns the guild's position among all guilds ordered by high score,
+// along with the number of ranked guilds.
+func GetGuildRank(ctx context.Context, db *sql.DB, guildID string) (rank int, total int, err error) {
+	defer trace(ctx, "GetGuildRank")()
+
+	query := `
+		SELECT rank, total FROM (
+			SELECT guild_id,
+			       RANK() OVER (ORDER BY high_score DESC) AS rank,
+			       COUNT(*) OVER () AS total
+			FROM guild_streaks
+		) ranked WHERE guild_id = $1;
+	`
+
+	err = db.QueryRowContext(ctx, query, guildID).Scan(&rank, &total)
+	if err != nil {
+		return 0, 0, classify(fmt.Errorf("failed to get guild rank: %w", err))
+	}
+	return rank, total, nil
+}
+
 func GetAllUsers(ctx context.Context, db *sql.DB) ([]*User, error) {
-	query := `SELECT id, username, created_at FROM users`
+	defer trace(ctx, "GetAllUsers")()
+
+	query := `SELECT id, username, COALESCE(display_name, ''), COALESCE(avatar, ''), created_at FROM users`
 
 	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("query users: %w", err)
+		return nil, classify(fmt.Errorf("query users: %w", err))
 	}
 	defer func() {
 		if closeErr := rows.Close(); closeErr != nil && err == nil {
@@ -395,20 +645,22 @@ func GetAllUsers(ctx context.Context, db *sql.DB) ([]*User, error) {
 	var users []*User
 	for rows.Next() {
 		var u User
-		if err := rows.Scan(&u.ID, &u.Username, &u.CreatedAt); err != nil {
-			return nil, fmt.Errorf("scan user: %w", err)
+		if err := rows.Scan(&u.ID, &u.Username, &u.DisplayName, &u.Avatar, &u.CreatedAt); err != nil {
+			return nil, classify(fmt.Errorf("scan user: %w", err))
 		}
 		users = append(users, &u)
 	}
-	return users, err
+	return users, classify(err)
 }
 
 func GetAllGuilds(ctx context.Context, db *sql.DB) ([]*Guild, error) {
-	query := `SELECT id, created_at FROM guilds`
+	defer trace(ctx, "GetAllGuilds")()
+
+	query := `SELECT ` + guildColumns + ` FROM guilds ORDER BY id`
 
 	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("query guilds: %w", err)
+		return nil, classify(fmt.Errorf("query guilds: %w", err))
 	}
 	defer func() {
 		if closeErr := rows.Close(); closeErr != nil && err == nil {
@@ -418,16 +670,18 @@ func GetAllGuilds(ctx context.Context, db *sql.DB) ([]*Guild, error) {
 
 	var guilds []*Guild
 	for rows.Next() {
-		var g Guild
-		if err := rows.Scan(&g.ID, &g.CreatedAt); err != nil {
-			return nil, fmt.Errorf("scan guild: %w", err)
+		g, err := scanGuild(rows)
+		if err != nil {
+			return nil, classify(fmt.Errorf("scan guild: %w", err))
 		}
-		guilds = append(guilds, &g)
+		guilds = append(guilds, g)
 	}
-	return guilds, rows.Err()
+	return guilds, classify(rows.Err())
 }
 
 func GetUserPerGuildStats(ctx context.Context, db *sql.DB, userID string) ([]UserGuildStats, error) {
+	defer trace(ctx, "GetUserPerGuildStats")()
+
 	query := `
 		SELECT 
 			guild_id,
@@ -444,7 +698,7 @@ func GetUserPerGuildStats(ctx context.Context, db *sql.DB, userID string) ([]Use
 
 	rows, err := db.QueryContext(ctx, query, userID)
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
 	}
 	defer func() {
 		if closeErr := rows.Close(); closeErr != nil && err == nil {
@@ -466,7 +720,7 @@ func GetUserPerGuildStats(ctx context.Context, db *sql.DB, userID string) ([]Use
 			&s.LastFailedMeowAt,
 		)
 		if err != nil {
-			return nil, err
+			return nil, classify(err)
 		}
 		s.UserID = userID
 		stats = append(stats, s)
@@ -475,133 +729,47 @@ func GetUserPerGuildStats(ctx context.Context, db *sql.DB, userID string) ([]Use
 	return stats, nil
 }
 
-func GetLeaderboard(
-	ctx context.Context,
-	db *sql.DB,
-	guildID *string, // nil means global
-	metric string, // "total_meows", "successful_meows", or "failed_meows"
-	limit, offset int,
-) ([]LeaderboardEntry, int, error) {
-	var (
-		query      string
-		countQuery string
-		queryArgs  []any
-		countArgs  []any
-	)
-
-	// Use aggregation
-	baseSelect := fmt.Sprintf(`
-		SELECT u.id, u.username, u.created_at, SUM(ugs.%s) AS value
-		FROM user_guild_stats ugs
-		JOIN users u ON u.id = ugs.user_id
-	`, metric)
-
-	baseGroupOrder := ` GROUP BY u.id ORDER BY value DESC LIMIT %d OFFSET %d`
-	baseCount := `SELECT COUNT(*) FROM (
-		SELECT ugs.user_id
-		FROM user_guild_stats ugs
-		%s
-		GROUP BY ugs.user_id
-	) AS subquery`
+// GetLeaderboard returns the page of the guild's leaderboard, or the global
+// one if guildID is nil, that q selects.
+func GetLeaderboard(ctx context.Context, db *sql.DB, guildID *string, q LeaderboardQuery) (*LeaderboardPage, error) {
+	defer trace(ctx, "GetLeaderboard")()
 
-	if guildID != nil {
-		whereClause := `WHERE ugs.guild_id = $1`
-		query = baseSelect + " " + whereClause + fmt.Sprintf(baseGroupOrder, limit, offset)
-		countQuery = fmt.Sprintf(baseCount, whereClause)
-		queryArgs = []any{*guildID}
-		countArgs = []any{*guildID}
-	} else {
-		query = baseSelect + fmt.Sprintf(baseGroupOrder, limit, offset)
-		countQuery = fmt.Sprintf(baseCount, "")
-		queryArgs = []any{}
-		countArgs = []any{}
-	}
-
-	rows, err := db.QueryContext(ctx, query, queryArgs...)
+	column, err := q.Metric.column()
 	if err != nil {
-		return nil, 0, fmt.Errorf("query leaderboard: %w", err)
+		return nil, err
 	}
-	defer func() {
-		if closeErr := rows.Close(); closeErr != nil && err == nil {
-			err = fmt.Errorf("failed to close rows: %w", closeErr)
-		}
-	}()
-
-	var entries []LeaderboardEntry
-	for rows.Next() {
-		var user User
-		var entry LeaderboardEntry
-		var value int
-
-		if err := rows.Scan(&user.ID, &user.Username, &user.CreatedAt, &value); err != nil {
-			return nil, 0, fmt.Errorf("scan leaderboard row: %w", err)
-		}
-
-		entry.User = &user
-		switch metric {
-		case "total_meows":
-			entry.TotalMeows = value
-		case "successful_meows":
-			entry.SuccessfulMeows = value
-		case "failed_meows":
-			entry.FailedMeows = value
-		}
-
-		entries = append(entries, entry)
+	if err := q.validate(); err != nil {
+		return nil, err
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("rows error: %w", err)
-	}
+	var rq rankQuery
+	query := rq.page(rq.ranked(column, "user_guild_stats", guildFilter(&rq, guildID)), q)
+	return queryLeaderboardPage(ctx, db, query, rq.args, q)
+}
+
+// GetUserRank returns the user's rank by metric in the guild, or globally if
+// guildID is nil. Players with the same value share a rank.
+func GetUserRank(ctx context.Context, db *sql.DB, userID string, guildID *string, metric Metric) (int, error) {
+	defer trace(ctx, "GetUserRank")()
 
-	var total int
-	err = db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total)
+	column, err := metric.column()
 	if err != nil {
-		return nil, 0, fmt.Errorf("count leaderboard: %w", err)
+		return 0, err
 	}
 
-	return entries, total, nil
-}
+	var rq rankQuery
+	query := rq.rank(rq.ranked(column, "user_guild_stats", guildFilter(&rq, guildID)), userID)
 
-func GetUserRank(ctx context.Context, db *sql.DB, userID string, guildID *string, column string) (int, error) {
-	// Validate column
-	validColumns := map[string]bool{
-		"total_meows":      true,
-		"successful_meows": true,
-		"failed_meows":     true,
-		"highest_streak":   true,
-		"current_streak":   true,
-	}
-	if !validColumns[column] {
-		return 0, fmt.Errorf("invalid column name: %s", column)
-	}
-
-	var (
-		query string
-		args  []any
-	)
+	var rank int
+	err = db.QueryRowContext(ctx, query, rq.args...).Scan(&rank)
+	return rank, classify(err)
+}
 
-	if guildID != nil {
-		query = fmt.Sprintf(`
-			SELECT rank FROM (
-				SELECT user_id, RANK() OVER (ORDER BY %s DESC) AS rank
-				FROM user_guild_stats
-				WHERE guild_id = $1
-			) ranked WHERE user_id = $2
-		`, column)
-		args = []any{*guildID, userID}
-	} else {
-		query = fmt.Sprintf(`
-			SELECT rank FROM (
-				SELECT user_id, RANK() OVER (ORDER BY SUM(%s) DESC) AS rank
-				FROM user_guild_stats
-				GROUP BY user_id
-			) ranked WHERE user_id = $1
-		`, column)
-		args = []any{userID}
+// guildFilter is the WHERE clause limiting user_guild_stats to the guild,
+// if any.
+func guildFilter(rq *rankQuery, guildID *string) string {
+	if guildID == nil {
+		return ""
 	}
-
-	var rank int
-	err := db.QueryRowContext(ctx, query, args...).Scan(&rank)
-	return rank, err
+	return "WHERE guild_id = " + rq.arg(*guildID)
 }
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/require"
@@ -74,3 +75,67 @@ func TestGetChannelForGuild_Found(t *testing.T) {
 	require.Equal(t, "chan-789", cid)
 	require.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestGetGuildTopMeower_NoRow(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM user_guild_stats ugs`)).
+		WithArgs("guild-foo").
+		WillReturnError(sql.ErrNoRows)
+
+	top, err := GetGuildTopMeower(context.Background(), mockDB, "guild-foo")
+	require.NoError(t, err)
+	require.Nil(t, top)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetGuildTopMeower_Found(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	rows := sqlmock.NewRows([]string{"id", "username", "display_name", "avatar", "created_at", "total_meows"}).
+		AddRow("user-1", "kitty", "Kitty", "", time.Now(), 42)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM user_guild_stats ugs`)).
+		WithArgs("guild-foo").
+		WillReturnRows(rows)
+
+	top, err := GetGuildTopMeower(context.Background(), mockDB, "guild-foo")
+	require.NoError(t, err)
+	require.NotNil(t, top)
+	require.Equal(t, "user-1", top.User.ID)
+	require.Equal(t, "Kitty", top.User.DisplayName)
+	require.Equal(t, 42, top.TotalMeows)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetGuildRank(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	rows := sqlmock.NewRows([]string{"rank", "total"}).AddRow(2, 7)
+	mock.ExpectQuery(regexp.QuoteMeta(`RANK() OVER (ORDER BY high_score DESC)`)).
+		WithArgs("guild-foo").
+		WillReturnRows(rows)
+
+	rank, total, err := GetGuildRank(context.Background(), mockDB, "guild-foo")
+	require.NoError(t, err)
+	require.Equal(t, 2, rank)
+	require.Equal(t, 7, total)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
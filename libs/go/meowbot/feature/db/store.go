@@ -0,0 +1,430 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Store is all the storage the bot plays the meow game on: guilds, users and
+// their stats, and every feature built on top of them. SQLStore implements it
+// on Postgres or SQLite, MemoryStore in memory for tests and local runs.
+type Store interface {
+	// Ping reports whether the storage is reachable.
+	Ping(ctx context.Context) error
+
+	UpsertUser(ctx context.Context, user User) error
+	UpsertGuild(ctx context.Context, guild Guild) error
+	SyncGuild(ctx context.Context, guild Guild) error
+	LeaveGuild(ctx context.Context, guildID string, at time.Time) error
+	GetGuild(ctx context.Context, guildID string) (*Guild, error)
+	GetAllUsers(ctx context.Context) ([]*User, error)
+	DeleteUserData(ctx context.Context, userID string) (string, error)
+	GetAllGuilds(ctx context.Context) ([]*Guild, error)
+
+	UpsertGuildChannel(ctx context.Context, guildID, channelID string) error
+	GetChannelForGuild(ctx context.Context, guildID string) (string, error)
+	GetAllGuildChannels(ctx context.Context) (map[string]string, error)
+	GetGuildSettings(ctx context.Context, guildID string) (*GuildSettings, error)
+	UpsertGuildLocale(ctx context.Context, guildID, locale string) error
+	UpsertGuildTimezone(ctx context.Context, guildID, timezone string) error
+
+	IncrementMeow(ctx context.Context, guildID, userID string, success bool, now time.Time) error
+	GetGuildStreak(ctx context.Context, guildID string) (*GuildStreak, error)
+	UpsertGuildStreak(ctx context.Context, streak GuildStreak) error
+
+	GetUserStats(ctx context.Context, guildID *string, userID string) (UserGuildStats, error)
+	GetUserGlobalStats(ctx context.Context, userID string) (UserGlobalStats, error)
+	GetUserPerGuildStats(ctx context.Context, userID string) ([]UserGuildStats, error)
+	GetGlobalStats(ctx context.Context) (*GlobalStats, error)
+	GetGuildStats(ctx context.Context, guildID string) (*GuildStats, error)
+	GetGuildTopMeower(ctx context.Context, guildID string) (*GuildTopMeower, error)
+	GetGuildRank(ctx context.Context, guildID string) (rank int, total int, err error)
+
+	GetLeaderboard3(ctx context.Context, limit int) ([]LeaderboardEntry, error)
+	GetLeaderboard(ctx context.Context, guildID *string, q LeaderboardQuery) (*LeaderboardPage, error)
+	GetUserRank(ctx context.Context, userID string, guildID *string, metric Metric) (int, error)
+
+	ExportUserData(ctx context.Context, userID string) (*UserData, error)
+	ExportGuild(ctx context.Context, guildID string) (*GuildExport, error)
+	ImportGuild(ctx context.Context, export *GuildExport, dryRun bool) (*ImportReport, error)
+
+	MemberStore
+	AchievementStore
+	SeasonStore
+	TreatStore
+	RecapStore
+	NotificationStore
+	BanStore
+	AuditStore
+}
+
+// MemberStore keeps guild memberships and username history.
+type MemberStore interface {
+	UpsertGuildMember(ctx context.Context, member GuildMember) error
+	GetGuildMember(ctx context.Context, guildID, userID string) (*GuildMember, error)
+	GetUsernameHistory(ctx context.Context, userID string) ([]UsernameChange, error)
+}
+
+// AchievementStore keeps the achievements players unlock.
+type AchievementStore interface {
+	UnlockAchievement(ctx context.Context, guildID, userID, achievementID string, at time.Time) (bool, error)
+	GetUserAchievements(ctx context.Context, userID string, guildID *string) ([]UserAchievement, error)
+}
+
+// SeasonStore keeps seasons and their leaderboards.
+type SeasonStore interface {
+	CreateSeason(ctx context.Context, season Season) (*Season, error)
+	GetSeason(ctx context.Context, id int64) (*Season, error)
+	GetActiveSeason(ctx context.Context, guildID *string, at time.Time) (*Season, error)
+	ListSeasons(ctx context.Context, guildID *string) ([]Season, error)
+	GetDueSeasons(ctx context.Context, now time.Time) ([]Season, error)
+	IncrementSeasonMeow(ctx context.Context, guildID, userID string, success bool, now time.Time) error
+	CloseSeason(ctx context.Context, seasonID int64, now time.Time) (bool, error)
+	GetSeasonLeaderboard(ctx context.Context, season *Season, q LeaderboardQuery) (*LeaderboardPage, error)
+	GetSeasonUserRank(ctx context.Context, season *Season, userID string, metric Metric) (int, error)
+}
+
+// TreatStore keeps treat balances, their ledger, the items bought with them
+// and the roles the bot created for role items.
+type TreatStore interface {
+	AddTreats(ctx context.Context, guildID, userID string, amount int, reason string, at time.Time) (int, error)
+	PurchaseItem(ctx context.Context, guildID, userID, itemID string, price int, at time.Time) (int, error)
+	RefundItem(ctx context.Context, guildID, userID, itemID string, price int, at time.Time) (int, error)
+	GetTreatBalance(ctx context.Context, guildID, userID string) (int, error)
+	GetTreatLedger(ctx context.Context, guildID, userID string, limit int) ([]TreatLedgerEntry, error)
+	GetUserItems(ctx context.Context, guildID, userID string) ([]UserItem, error)
+	GetItemRole(ctx context.Context, guildID, itemID string) (string, error)
+	SetItemRole(ctx context.Context, guildID, itemID, roleID string) error
+}
+
+// RecapStore keeps the meow events recaps summarize and the guilds' recap
+// settings.
+type RecapStore interface {
+	RecordMeowEvent(ctx context.Context, event MeowEvent) error
+	UpsertRecapSettings(ctx context.Context, settings RecapSettings) error
+	DeleteRecapSettings(ctx context.Context, guildID string) (bool, error)
+	GetGuildRecapSettings(ctx context.Context, guildID string) (*RecapSettings, error)
+	GetRecapSettings(ctx context.Context) ([]RecapSettings, error)
+	ClaimRecapPeriod(ctx context.Context, guildID string, periodEnd time.Time) (bool, error)
+	GetGuildRecap(ctx context.Context, guildID string, start, end time.Time, topN int) (*GuildRecap, error)
+}
+
+// NotificationStore keeps notification opt-ins and what users were last told.
+type NotificationStore interface {
+	GetNotificationPrefs(ctx context.Context, userID string) (*NotificationPrefs, error)
+	UpsertNotificationPrefs(ctx context.Context, prefs NotificationPrefs) error
+	GetRankWatchers(ctx context.Context, guildID string) ([]RankWatcher, error)
+	SetWatchedRank(ctx context.Context, guildID, userID string, rank int) error
+	ClaimNotification(ctx context.Context, userID string, now time.Time, minInterval time.Duration) (bool, error)
+}
+
+// BanStore keeps meow bans.
+type BanStore interface {
+	BanUser(ctx context.Context, ban MeowBan) error
+	UnbanUser(ctx context.Context, guildID, userID string) (bool, error)
+	GetActiveBan(ctx context.Context, guildID, userID string, at time.Time) (*MeowBan, error)
+	ListBans(ctx context.Context, guildID *string, at time.Time) ([]MeowBan, error)
+}
+
+// AuditStore keeps the audit log of administrative actions.
+type AuditStore interface {
+	RecordAudit(ctx context.Context, entry AuditEntry) error
+	GetAuditLog(ctx context.Context, filter AuditFilter) ([]AuditEntry, error)
+}
+
+// SQLStore is the Store backed by the queries of this package, on whichever
+// database Connect opened.
+type SQLStore struct {
+	db *sql.DB
+}
+
+var _ Store = (*SQLStore)(nil)
+
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db}
+}
+
+func (s *SQLStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+func (s *SQLStore) UpsertUser(ctx context.Context, user User) error {
+	return UpsertUser(ctx, s.db, user)
+}
+
+func (s *SQLStore) UpsertGuild(ctx context.Context, guild Guild) error {
+	return UpsertGuild(ctx, s.db, guild)
+}
+
+func (s *SQLStore) SyncGuild(ctx context.Context, guild Guild) error {
+	return SyncGuild(ctx, s.db, guild)
+}
+
+func (s *SQLStore) LeaveGuild(ctx context.Context, guildID string, at time.Time) error {
+	return LeaveGuild(ctx, s.db, guildID, at)
+}
+
+func (s *SQLStore) GetGuild(ctx context.Context, guildID string) (*Guild, error) {
+	return GetGuild(ctx, s.db, guildID)
+}
+
+func (s *SQLStore) DeleteUserData(ctx context.Context, userID string) (string, error) {
+	return DeleteUserData(ctx, s.db, userID)
+}
+
+func (s *SQLStore) GetAllUsers(ctx context.Context) ([]*User, error) {
+	return GetAllUsers(ctx, s.db)
+}
+
+func (s *SQLStore) GetAllGuilds(ctx context.Context) ([]*Guild, error) {
+	return GetAllGuilds(ctx, s.db)
+}
+
+func (s *SQLStore) UpsertGuildChannel(ctx context.Context, guildID, channelID string) error {
+	return UpsertGuildChannel(ctx, s.db, guildID, channelID)
+}
+
+func (s *SQLStore) GetChannelForGuild(ctx context.Context, guildID string) (string, error) {
+	return GetChannelForGuild(ctx, s.db, guildID)
+}
+
+func (s *SQLStore) GetAllGuildChannels(ctx context.Context) (map[string]string, error) {
+	return GetAllGuildChannels(ctx, s.db)
+}
+
+func (s *SQLStore) GetGuildSettings(ctx context.Context, guildID string) (*GuildSettings, error) {
+	return GetGuildSettings(ctx, s.db, guildID)
+}
+
+func (s *SQLStore) UpsertGuildLocale(ctx context.Context, guildID, locale string) error {
+	return UpsertGuildLocale(ctx, s.db, guildID, locale)
+}
+
+func (s *SQLStore) UpsertGuildTimezone(ctx context.Context, guildID, timezone string) error {
+	return UpsertGuildTimezone(ctx, s.db, guildID, timezone)
+}
+
+func (s *SQLStore) IncrementMeow(ctx context.Context, guildID, userID string, success bool, now time.Time) error {
+	return IncrementMeow(ctx, s.db, guildID, userID, success, now)
+}
+
+func (s *SQLStore) GetGuildStreak(ctx context.Context, guildID string) (*GuildStreak, error) {
+	return GetGuildStreak(ctx, s.db, guildID)
+}
+
+func (s *SQLStore) UpsertGuildStreak(ctx context.Context, streak GuildStreak) error {
+	return UpsertGuildStreak(ctx, s.db, streak)
+}
+
+func (s *SQLStore) GetUserStats(ctx context.Context, guildID *string, userID string) (UserGuildStats, error) {
+	return GetUserStats(ctx, s.db, guildID, userID)
+}
+
+func (s *SQLStore) GetUserGlobalStats(ctx context.Context, userID string) (UserGlobalStats, error) {
+	return GetUserGlobalStats(ctx, s.db, userID)
+}
+
+func (s *SQLStore) GetUserPerGuildStats(ctx context.Context, userID string) ([]UserGuildStats, error) {
+	return GetUserPerGuildStats(ctx, s.db, userID)
+}
+
+func (s *SQLStore) GetGlobalStats(ctx context.Context) (*GlobalStats, error) {
+	return GetGlobalStats(ctx, s.db)
+}
+
+func (s *SQLStore) GetGuildStats(ctx context.Context, guildID string) (*GuildStats, error) {
+	return GetGuildStats(ctx, s.db, guildID)
+}
+
+func (s *SQLStore) GetGuildTopMeower(ctx context.Context, guildID string) (*GuildTopMeower, error) {
+	return GetGuildTopMeower(ctx, s.db, guildID)
+}
+
+func (s *SQLStore) GetGuildRank(ctx context.Context, guildID string) (int, int, error) {
+	return GetGuildRank(ctx, s.db, guildID)
+}
+
+func (s *SQLStore) GetLeaderboard3(ctx context.Context, limit int) ([]LeaderboardEntry, error) {
+	return GetLeaderboard3(ctx, s.db, limit)
+}
+
+func (s *SQLStore) GetLeaderboard(ctx context.Context, guildID *string, q LeaderboardQuery) (*LeaderboardPage, error) {
+	return GetLeaderboard(ctx, s.db, guildID, q)
+}
+
+func (s *SQLStore) GetUserRank(ctx context.Context, userID string, guildID *string, metric Metric) (int, error) {
+	return GetUserRank(ctx, s.db, userID, guildID, metric)
+}
+
+func (s *SQLStore) ExportUserData(ctx context.Context, userID string) (*UserData, error) {
+	return ExportUserData(ctx, s.db, userID)
+}
+
+func (s *SQLStore) ExportGuild(ctx context.Context, guildID string) (*GuildExport, error) {
+	return ExportGuild(ctx, s.db, guildID)
+}
+
+func (s *SQLStore) ImportGuild(ctx context.Context, export *GuildExport, dryRun bool) (*ImportReport, error) {
+	return ImportGuild(ctx, s.db, export, dryRun)
+}
+
+func (s *SQLStore) UpsertGuildMember(ctx context.Context, member GuildMember) error {
+	return UpsertGuildMember(ctx, s.db, member)
+}
+
+func (s *SQLStore) GetGuildMember(ctx context.Context, guildID, userID string) (*GuildMember, error) {
+	return GetGuildMember(ctx, s.db, guildID, userID)
+}
+
+func (s *SQLStore) GetUsernameHistory(ctx context.Context, userID string) ([]UsernameChange, error) {
+	return GetUsernameHistory(ctx, s.db, userID)
+}
+
+func (s *SQLStore) UnlockAchievement(ctx context.Context, guildID, userID, achievementID string, at time.Time) (bool, error) {
+	return UnlockAchievement(ctx, s.db, guildID, userID, achievementID, at)
+}
+
+func (s *SQLStore) GetUserAchievements(ctx context.Context, userID string, guildID *string) ([]UserAchievement, error) {
+	return GetUserAchievements(ctx, s.db, userID, guildID)
+}
+
+func (s *SQLStore) CreateSeason(ctx context.Context, season Season) (*Season, error) {
+	return CreateSeason(ctx, s.db, season)
+}
+
+func (s *SQLStore) GetSeason(ctx context.Context, id int64) (*Season, error) {
+	return GetSeason(ctx, s.db, id)
+}
+
+func (s *SQLStore) GetActiveSeason(ctx context.Context, guildID *string, at time.Time) (*Season, error) {
+	return GetActiveSeason(ctx, s.db, guildID, at)
+}
+
+func (s *SQLStore) ListSeasons(ctx context.Context, guildID *string) ([]Season, error) {
+	return ListSeasons(ctx, s.db, guildID)
+}
+
+func (s *SQLStore) GetDueSeasons(ctx context.Context, now time.Time) ([]Season, error) {
+	return GetDueSeasons(ctx, s.db, now)
+}
+
+func (s *SQLStore) IncrementSeasonMeow(ctx context.Context, guildID, userID string, success bool, now time.Time) error {
+	return IncrementSeasonMeow(ctx, s.db, guildID, userID, success, now)
+}
+
+func (s *SQLStore) CloseSeason(ctx context.Context, seasonID int64, now time.Time) (bool, error) {
+	return CloseSeason(ctx, s.db, seasonID, now)
+}
+
+func (s *SQLStore) GetSeasonLeaderboard(ctx context.Context, season *Season, q LeaderboardQuery) (*LeaderboardPage, error) {
+	return GetSeasonLeaderboard(ctx, s.db, season, q)
+}
+
+func (s *SQLStore) GetSeasonUserRank(ctx context.Context, season *Season, userID string, metric Metric) (int, error) {
+	return GetSeasonUserRank(ctx, s.db, season, userID, metric)
+}
+
+func (s *SQLStore) AddTreats(ctx context.Context, guildID, userID string, amount int, reason string, at time.Time) (int, error) {
+	return AddTreats(ctx, s.db, guildID, userID, amount, reason, at)
+}
+
+func (s *SQLStore) PurchaseItem(ctx context.Context, guildID, userID, itemID string, price int, at time.Time) (int, error) {
+	return PurchaseItem(ctx, s.db, guildID, userID, itemID, price, at)
+}
+
+func (s *SQLStore) RefundItem(ctx context.Context, guildID, userID, itemID string, price int, at time.Time) (int, error) {
+	return RefundItem(ctx, s.db, guildID, userID, itemID, price, at)
+}
+
+func (s *SQLStore) GetTreatBalance(ctx context.Context, guildID, userID string) (int, error) {
+	return GetTreatBalance(ctx, s.db, guildID, userID)
+}
+
+func (s *SQLStore) GetTreatLedger(ctx context.Context, guildID, userID string, limit int) ([]TreatLedgerEntry, error) {
+	return GetTreatLedger(ctx, s.db, guildID, userID, limit)
+}
+
+func (s *SQLStore) GetUserItems(ctx context.Context, guildID, userID string) ([]UserItem, error) {
+	return GetUserItems(ctx, s.db, guildID, userID)
+}
+
+func (s *SQLStore) GetItemRole(ctx context.Context, guildID, itemID string) (string, error) {
+	return GetItemRole(ctx, s.db, guildID, itemID)
+}
+
+func (s *SQLStore) SetItemRole(ctx context.Context, guildID, itemID, roleID string) error {
+	return SetItemRole(ctx, s.db, guildID, itemID, roleID)
+}
+
+func (s *SQLStore) RecordMeowEvent(ctx context.Context, event MeowEvent) error {
+	return RecordMeowEvent(ctx, s.db, event)
+}
+
+func (s *SQLStore) UpsertRecapSettings(ctx context.Context, settings RecapSettings) error {
+	return UpsertRecapSettings(ctx, s.db, settings)
+}
+
+func (s *SQLStore) DeleteRecapSettings(ctx context.Context, guildID string) (bool, error) {
+	return DeleteRecapSettings(ctx, s.db, guildID)
+}
+
+func (s *SQLStore) GetGuildRecapSettings(ctx context.Context, guildID string) (*RecapSettings, error) {
+	return GetGuildRecapSettings(ctx, s.db, guildID)
+}
+
+func (s *SQLStore) GetRecapSettings(ctx context.Context) ([]RecapSettings, error) {
+	return GetRecapSettings(ctx, s.db)
+}
+
+func (s *SQLStore) ClaimRecapPeriod(ctx context.Context, guildID string, periodEnd time.Time) (bool, error) {
+	return ClaimRecapPeriod(ctx, s.db, guildID, periodEnd)
+}
+
+func (s *SQLStore) GetGuildRecap(ctx context.Context, guildID string, start, end time.Time, topN int) (*GuildRecap, error) {
+	return GetGuildRecap(ctx, s.db, guildID, start, end, topN)
+}
+
+func (s *SQLStore) GetNotificationPrefs(ctx context.Context, userID string) (*NotificationPrefs, error) {
+	return GetNotificationPrefs(ctx, s.db, userID)
+}
+
+func (s *SQLStore) UpsertNotificationPrefs(ctx context.Context, prefs NotificationPrefs) error {
+	return UpsertNotificationPrefs(ctx, s.db, prefs)
+}
+
+func (s *SQLStore) GetRankWatchers(ctx context.Context, guildID string) ([]RankWatcher, error) {
+	return GetRankWatchers(ctx, s.db, guildID)
+}
+
+func (s *SQLStore) SetWatchedRank(ctx context.Context, guildID, userID string, rank int) error {
+	return SetWatchedRank(ctx, s.db, guildID, userID, rank)
+}
+
+func (s *SQLStore) ClaimNotification(ctx context.Context, userID string, now time.Time, minInterval time.Duration) (bool, error) {
+	return ClaimNotification(ctx, s.db, userID, now, minInterval)
+}
+
+func (s *SQLStore) BanUser(ctx context.Context, ban MeowBan) error {
+	return BanUser(ctx, s.db, ban)
+}
+
+func (s *SQLStore) UnbanUser(ctx context.Context, guildID, userID string) (bool, error) {
+	return UnbanUser(ctx, s.db, guildID, userID)
+}
+
+func (s *SQLStore) GetActiveBan(ctx context.Context, guildID, userID string, at time.Time) (*MeowBan, error) {
+	return GetActiveBan(ctx, s.db, guildID, userID, at)
+}
+
+func (s *SQLStore) ListBans(ctx context.Context, guildID *string, at time.Time) ([]MeowBan, error) {
+	return ListBans(ctx, s.db, guildID, at)
+}
+
+func (s *SQLStore) RecordAudit(ctx context.Context, entry AuditEntry) error {
+	return RecordAudit(ctx, s.db, entry)
+}
+
+func (s *SQLStore) GetAuditLog(ctx context.Context, filter AuditFilter) ([]AuditEntry, error) {
+	return GetAuditLog(ctx, s.db, filter)
+}
@@ -0,0 +1,971 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// forEachStore runs fn on an empty MemoryStore, on a RankCache over one and
+// on a SQLStore over each database backend, so they are held to the same
+// behaviour.
+func forEachStore(t *testing.T, fn func(t *testing.T, s Store)) {
+	t.Run("memory", func(t *testing.T) { fn(t, NewMemoryStore()) })
+	t.Run("cached", func(t *testing.T) { fn(t, NewRankCache(NewMemoryStore(), time.Minute)) })
+	forEachDatabase(t, func(t *testing.T, db *sql.DB) { fn(t, NewSQLStore(db)) })
+}
+
+// seedStore creates guilds g1 and g2 and users alice, bob and carol.
+func seedStore(t *testing.T, s Store) {
+	t.Helper()
+	ctx := context.Background()
+	for _, id := range []string{"g1", "g2"} {
+		require.NoError(t, s.UpsertGuild(ctx, Guild{ID: id}))
+	}
+	for _, name := range []string{"alice", "bob", "carol"} {
+		require.NoError(t, s.UpsertUser(ctx, User{ID: name, Username: name}))
+	}
+}
+
+func meow(t *testing.T, s Store, guildID, userID string, success bool, times int) {
+	t.Helper()
+	for range times {
+		require.NoError(t, s.IncrementMeow(context.Background(), guildID, userID, success, time.Now()))
+	}
+}
+
+func TestStore_IncrementMeow(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+		meow(t, s, "g1", "alice", true, 3)
+		meow(t, s, "g1", "alice", false, 1)
+		meow(t, s, "g1", "alice", true, 1)
+		meow(t, s, "g2", "alice", true, 2)
+
+		g1 := "g1"
+		stats, err := s.GetUserStats(ctx, &g1, "alice")
+		require.NoError(t, err)
+		require.Equal(t, 4, stats.SuccessfulMeows)
+		require.Equal(t, 1, stats.FailedMeows)
+		require.Equal(t, 5, stats.TotalMeows)
+		require.Equal(t, 1, stats.CurrentStreak)
+		require.Equal(t, 3, stats.HighestStreak)
+		require.NotNil(t, stats.LastMeowAt)
+		require.NotNil(t, stats.LastFailedMeowAt)
+
+		global, err := s.GetUserStats(ctx, nil, "alice")
+		require.NoError(t, err)
+		require.Equal(t, "", global.GuildID)
+		require.Equal(t, 7, global.TotalMeows)
+		require.Equal(t, 2, global.CurrentStreak)
+
+		// A user who never meowed has zero stats in a guild, but no global row.
+		stats, err = s.GetUserStats(ctx, &g1, "bob")
+		require.NoError(t, err)
+		require.Equal(t, UserGuildStats{GuildID: "g1", UserID: "bob"}, stats)
+		_, err = s.GetUserStats(ctx, nil, "bob")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestStore_ForeignKeys(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+
+		err := s.IncrementMeow(ctx, "nope", "alice", true, time.Now())
+		require.ErrorIs(t, err, ErrInvalidInput)
+		err = s.IncrementMeow(ctx, "g1", "nobody", true, time.Now())
+		require.ErrorIs(t, err, ErrInvalidInput)
+		err = s.UpsertGuildStreak(ctx, GuildStreak{GuildID: "nope"})
+		require.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+func TestStore_GuildMetadata(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+		joined := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+		// Guilds first seen through a meow have no details yet.
+		g, err := s.GetGuild(ctx, "g1")
+		require.NoError(t, err)
+		require.True(t, g.Present)
+		require.Empty(t, g.Name)
+		_, err = s.GetGuild(ctx, "nope")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		require.NoError(t, s.SyncGuild(ctx, Guild{ID: "g1", Name: "Cats", Icon: "i1", MemberCount: 42, OwnerID: "alice", JoinedAt: &joined}))
+		require.NoError(t, s.LeaveGuild(ctx, "g1", joined.Add(time.Hour)))
+		g, err = s.GetGuild(ctx, "g1")
+		require.NoError(t, err)
+		require.False(t, g.Present)
+		require.True(t, joined.Add(time.Hour).Equal(*g.LeftAt))
+
+		// Updates leave out the member count and join date.
+		require.NoError(t, s.SyncGuild(ctx, Guild{ID: "g1", Name: "More Cats", OwnerID: "bob"}))
+		require.NoError(t, s.SyncGuild(ctx, Guild{ID: "g3", Name: "Dogs"}))
+		guilds, err := s.GetAllGuilds(ctx)
+		require.NoError(t, err)
+		require.Len(t, guilds, 3)
+		g = guilds[0]
+		require.Equal(t, "More Cats", g.Name)
+		require.Equal(t, "bob", g.OwnerID)
+		require.Equal(t, 42, g.MemberCount)
+		require.True(t, joined.Equal(*g.JoinedAt))
+		require.True(t, g.Present)
+		require.Nil(t, g.LeftAt)
+		require.Equal(t, "Dogs", guilds[2].Name)
+
+		require.NoError(t, s.LeaveGuild(ctx, "nope", joined))
+	})
+}
+
+func TestStore_GuildSettings(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+
+		settings, err := s.GetGuildSettings(ctx, "g1")
+		require.NoError(t, err)
+		require.Equal(t, &GuildSettings{GuildID: "g1"}, settings)
+
+		require.NoError(t, s.UpsertGuildLocale(ctx, "g1", "fr"))
+		require.NoError(t, s.UpsertGuildTimezone(ctx, "g1", "Europe/Paris"))
+		settings, err = s.GetGuildSettings(ctx, "g1")
+		require.NoError(t, err)
+		require.Equal(t, &GuildSettings{GuildID: "g1", Locale: "fr", Timezone: "Europe/Paris"}, settings)
+
+		require.NoError(t, s.UpsertGuildChannel(ctx, "g1", "c1"))
+		channel, err := s.GetChannelForGuild(ctx, "g1")
+		require.NoError(t, err)
+		require.Equal(t, "c1", channel)
+		channel, err = s.GetChannelForGuild(ctx, "g2")
+		require.NoError(t, err)
+		require.Equal(t, "", channel)
+	})
+}
+
+func TestStore_GuildStats(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+
+		_, err := s.GetGuildStats(ctx, "g1")
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = s.GetGuildStreak(ctx, "g1")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		alice, bob := "alice", "bob"
+		require.NoError(t, s.UpsertGuildStreak(ctx, GuildStreak{GuildID: "g1", MeowCount: 2, LastUserID: &bob, HighScore: 5, HighScoreUserID: &alice}))
+		require.NoError(t, s.UpsertGuildStreak(ctx, GuildStreak{GuildID: "g2", HighScore: 9}))
+		meow(t, s, "g1", "alice", true, 4)
+		meow(t, s, "g1", "bob", true, 4)
+		meow(t, s, "g1", "bob", false, 1)
+
+		stats, err := s.GetGuildStats(ctx, "g1")
+		require.NoError(t, err)
+		require.Equal(t, 2, stats.CurrentStreak)
+		require.Equal(t, "bob", stats.LastUser.ID)
+		require.Equal(t, "alice", stats.HighScoreUser.ID)
+		require.Equal(t, 9, stats.TotalMeows)
+		require.Equal(t, 1, stats.FailedMeows)
+		require.Equal(t, 2, stats.Participants)
+
+		// Ties go to the lower user ID.
+		meow(t, s, "g1", "alice", false, 1)
+		top, err := s.GetGuildTopMeower(ctx, "g1")
+		require.NoError(t, err)
+		require.Equal(t, "alice", top.User.ID)
+		require.Equal(t, 5, top.TotalMeows)
+
+		top, err = s.GetGuildTopMeower(ctx, "g2")
+		require.NoError(t, err)
+		require.Nil(t, top)
+
+		rank, total, err := s.GetGuildRank(ctx, "g1")
+		require.NoError(t, err)
+		require.Equal(t, 2, rank)
+		require.Equal(t, 2, total)
+	})
+}
+
+func TestStore_DeleteUserData(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+		alice := "alice"
+		require.NoError(t, s.UpsertGuildStreak(ctx, GuildStreak{GuildID: "g1", MeowCount: 3, LastUserID: &alice, HighScore: 3, HighScoreUserID: &alice}))
+		meow(t, s, "g1", "alice", true, 3)
+		meow(t, s, "g1", "bob", true, 1)
+		meow(t, s, "g2", "alice", false, 1)
+		before, err := s.GetLeaderboard(ctx, nil, LeaderboardQuery{Metric: MetricTotal, Limit: 5})
+		require.NoError(t, err)
+
+		anonID, err := s.DeleteUserData(ctx, "alice")
+		require.NoError(t, err)
+		_, err = s.GetUserGlobalStats(ctx, "alice")
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = s.DeleteUserData(ctx, "alice")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		// The stand-in keeps alice's meows, so totals and ranks don't change.
+		after, err := s.GetLeaderboard(ctx, nil, LeaderboardQuery{Metric: MetricTotal, Limit: 5})
+		require.NoError(t, err)
+		require.Equal(t, before.Total, after.Total)
+		require.Equal(t, anonID, after.Entries[0].User.ID)
+		require.Equal(t, before.Entries[0].TotalMeows, after.Entries[0].TotalMeows)
+		require.Equal(t, 1, after.Entries[0].Rank)
+		rank, err := s.GetUserRank(ctx, anonID, nil, MetricTotal)
+		require.NoError(t, err)
+		require.Equal(t, 1, rank)
+
+		stats, err := s.GetGuildStats(ctx, "g1")
+		require.NoError(t, err)
+		require.Equal(t, 4, stats.TotalMeows)
+		require.Equal(t, anonID, stats.HighScoreUser.ID)
+		require.Equal(t, anonID, stats.LastUser.ID)
+
+		// Alice can play again from scratch.
+		require.NoError(t, s.UpsertUser(ctx, User{ID: "alice", Username: "alice"}))
+		meow(t, s, "g1", "alice", true, 1)
+		got, err := s.GetUserStats(ctx, nil, "alice")
+		require.NoError(t, err)
+		require.Equal(t, 1, got.TotalMeows)
+	})
+}
+
+func TestStore_Leaderboard(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+		meow(t, s, "g1", "alice", true, 2)
+		meow(t, s, "g1", "bob", true, 5)
+		meow(t, s, "g2", "alice", true, 4)
+		meow(t, s, "g2", "carol", true, 2)
+		meow(t, s, "g2", "carol", false, 1)
+
+		page, err := s.GetLeaderboard(ctx, nil, LeaderboardQuery{Metric: MetricTotal, Limit: 2})
+		require.NoError(t, err)
+		require.Equal(t, 3, page.Total)
+		require.Equal(t, 1, page.Start)
+		require.Len(t, page.Entries, 2)
+		require.Equal(t, "alice", page.Entries[0].User.ID)
+		require.Equal(t, 6, page.Entries[0].TotalMeows)
+		require.Equal(t, 1, page.Entries[0].Rank)
+		require.Equal(t, "bob", page.Entries[1].User.ID)
+		require.Nil(t, page.Prev)
+
+		page, err = s.GetLeaderboard(ctx, nil, LeaderboardQuery{Metric: MetricTotal, Limit: 2, After: page.Next})
+		require.NoError(t, err)
+		require.Len(t, page.Entries, 1)
+		require.Equal(t, "carol", page.Entries[0].User.ID)
+		require.Equal(t, 3, page.Start)
+		require.Nil(t, page.Next)
+
+		g2 := "g2"
+		page, err = s.GetLeaderboard(ctx, &g2, LeaderboardQuery{Metric: MetricFailed, Limit: 5})
+		require.NoError(t, err)
+		require.Equal(t, 2, page.Total)
+		require.Equal(t, "carol", page.Entries[0].User.ID)
+		require.Equal(t, 1, page.Entries[0].FailedMeows)
+		require.Equal(t, 2, page.Entries[0].SuccessfulMeows)
+
+		top, err := s.GetLeaderboard3(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, top, 3)
+		require.Equal(t, 6, top[0].TotalMeows)
+
+		_, err = s.GetLeaderboard(ctx, nil, LeaderboardQuery{Metric: "1; DROP TABLE users", Limit: 5})
+		require.ErrorIs(t, err, ErrInvalidInput)
+		_, err = s.GetLeaderboard(ctx, nil, LeaderboardQuery{Metric: MetricTotal})
+		require.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+func TestStore_UserProfile(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+		require.NoError(t, s.UpsertUser(ctx, User{ID: "alice", Username: "alice", DisplayName: "Alice", Avatar: "a1"}))
+		meow(t, s, "g1", "alice", true, 1)
+
+		page, err := s.GetLeaderboard(ctx, nil, LeaderboardQuery{Metric: MetricTotal, Limit: 5})
+		require.NoError(t, err)
+		require.Equal(t, "Alice", page.Entries[0].User.Name())
+		require.Equal(t, "a1", page.Entries[0].User.Avatar)
+
+		// A profile without a display name falls back to the username.
+		require.NoError(t, s.UpsertUser(ctx, User{ID: "alice", Username: "alice2", Avatar: "a2"}))
+		page, err = s.GetLeaderboard(ctx, nil, LeaderboardQuery{Metric: MetricTotal, Limit: 5})
+		require.NoError(t, err)
+		require.Equal(t, "alice2", page.Entries[0].User.Name())
+		require.Equal(t, "a2", page.Entries[0].User.Avatar)
+	})
+}
+
+func TestStore_LeaderboardPaging(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+		for _, u := range []string{"dave", "erin"} {
+			require.NoError(t, s.UpsertUser(ctx, User{ID: u, Username: u}))
+		}
+		// alice and bob tie, as do carol, dave and erin.
+		meow(t, s, "g1", "bob", true, 3)
+		meow(t, s, "g1", "alice", true, 3)
+		meow(t, s, "g1", "erin", true, 1)
+		meow(t, s, "g1", "carol", true, 1)
+		meow(t, s, "g1", "dave", true, 1)
+		g1 := "g1"
+
+		var ids []string
+		var ranks []int
+		q := LeaderboardQuery{Metric: MetricTotal, Limit: 2}
+		for {
+			page, err := s.GetLeaderboard(ctx, &g1, q)
+			require.NoError(t, err)
+			require.Equal(t, 5, page.Total)
+			require.Equal(t, len(ids)+1, page.Start)
+			for _, e := range page.Entries {
+				ids = append(ids, e.User.ID)
+				ranks = append(ranks, e.Rank)
+
+				rank, err := s.GetUserRank(ctx, e.User.ID, &g1, MetricTotal)
+				require.NoError(t, err)
+				require.Equal(t, rank, e.Rank, e.User.ID)
+			}
+			if page.Next == nil {
+				break
+			}
+			q.After = page.Next
+		}
+		require.Equal(t, []string{"alice", "bob", "carol", "dave", "erin"}, ids)
+		require.Equal(t, []int{1, 1, 3, 3, 3}, ranks)
+
+		// Paging back from the last page lands on the same pages.
+		last, err := s.GetLeaderboard(ctx, &g1, LeaderboardQuery{Metric: MetricTotal, Limit: 2, Page: 99})
+		require.NoError(t, err)
+		require.Equal(t, 5, last.Start)
+		require.Equal(t, "erin", last.Entries[0].User.ID)
+		page, err := s.GetLeaderboard(ctx, &g1, LeaderboardQuery{Metric: MetricTotal, Limit: 2, Before: last.Prev})
+		require.NoError(t, err)
+		require.Equal(t, 3, page.Start)
+		require.Equal(t, "carol", page.Entries[0].User.ID)
+		require.Equal(t, "dave", page.Entries[1].User.ID)
+
+		page, err = s.GetLeaderboard(ctx, &g1, LeaderboardQuery{Metric: MetricTotal, Limit: 2, Page: 2})
+		require.NoError(t, err)
+		require.Equal(t, 3, page.Start)
+
+		// A cursor keeps its place when players move in front of it.
+		meow(t, s, "g1", "erin", true, 5)
+		page, err = s.GetLeaderboard(ctx, &g1, LeaderboardQuery{Metric: MetricTotal, Limit: 2, After: &LeaderboardCursor{Value: 1, UserID: "carol"}})
+		require.NoError(t, err)
+		require.Len(t, page.Entries, 1)
+		require.Equal(t, "dave", page.Entries[0].User.ID)
+		require.Equal(t, 5, page.Start)
+		require.Equal(t, 4, page.Entries[0].Rank)
+
+		empty, err := s.GetLeaderboard(ctx, &g1, LeaderboardQuery{Metric: MetricTotal, Limit: 2, After: page.Prev})
+		require.NoError(t, err)
+		require.Empty(t, empty.Entries)
+		require.Zero(t, empty.Total)
+	})
+}
+
+func TestStore_UserRank(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+		meow(t, s, "g1", "alice", true, 3)
+		meow(t, s, "g1", "bob", true, 3)
+		meow(t, s, "g1", "carol", true, 1)
+
+		g1 := "g1"
+		for user, want := range map[string]int{"alice": 1, "bob": 1, "carol": 3} {
+			rank, err := s.GetUserRank(ctx, user, &g1, MetricTotal)
+			require.NoError(t, err)
+			require.Equal(t, want, rank, user)
+		}
+		rank, err := s.GetUserRank(ctx, "carol", nil, MetricHighestStreak)
+		require.NoError(t, err)
+		require.Equal(t, 3, rank)
+
+		_, err = s.GetUserRank(ctx, "alice", &g1, "username")
+		require.ErrorIs(t, err, ErrInvalidInput)
+		g2 := "g2"
+		_, err = s.GetUserRank(ctx, "alice", &g2, MetricTotal)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestStore_Concurrent(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+
+		var wg sync.WaitGroup
+		for range 50 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_ = s.IncrementMeow(ctx, "g1", "alice", true, time.Now())
+			}()
+			go func() {
+				defer wg.Done()
+				_, _ = s.GetLeaderboard(ctx, nil, LeaderboardQuery{Metric: MetricTotal, Limit: 10})
+			}()
+		}
+		wg.Wait()
+
+		stats, err := s.GetGlobalStats(ctx)
+		require.NoError(t, err)
+		require.Equal(t, &GlobalStats{TotalGuilds: 2, TotalUsers: 3, TotalMeows: 50}, stats)
+	})
+}
+
+func TestStore_Seasons(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		end := start.AddDate(0, 1, 0)
+		g1 := "g1"
+
+		season, err := s.CreateSeason(ctx, Season{GuildID: &g1, Name: "Winter", StartsAt: start, EndsAt: end})
+		require.NoError(t, err)
+		require.Equal(t, "g1", *season.GuildID)
+		require.True(t, season.StartsAt.Equal(start))
+
+		_, err = s.CreateSeason(ctx, Season{GuildID: &g1, Name: "Overlap", StartsAt: start.AddDate(0, 0, 7), EndsAt: end.AddDate(0, 0, 7)})
+		require.ErrorIs(t, err, ErrInvalidInput)
+		global, err := s.CreateSeason(ctx, Season{Name: "Global", StartsAt: start, EndsAt: end})
+		require.NoError(t, err)
+		require.Nil(t, global.GuildID)
+
+		// The guild's own season wins over the global one.
+		now := start.Add(time.Hour)
+		active, err := s.GetActiveSeason(ctx, &g1, now)
+		require.NoError(t, err)
+		require.Equal(t, season.ID, active.ID)
+
+		for range 3 {
+			require.NoError(t, s.IncrementSeasonMeow(ctx, "g1", "alice", true, now))
+		}
+		require.NoError(t, s.IncrementSeasonMeow(ctx, "g1", "bob", false, now))
+
+		page, err := s.GetSeasonLeaderboard(ctx, season, LeaderboardQuery{Metric: MetricTotal, Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, 2, page.Total)
+		require.Equal(t, "alice", page.Entries[0].User.ID)
+		require.Equal(t, 3, page.Entries[0].SuccessfulMeows)
+		require.Equal(t, 1, page.Entries[1].FailedMeows)
+		require.Equal(t, 2, page.Entries[1].Rank)
+
+		due, err := s.GetDueSeasons(ctx, end)
+		require.NoError(t, err)
+		require.Len(t, due, 2)
+
+		closed, err := s.CloseSeason(ctx, season.ID, end)
+		require.NoError(t, err)
+		require.True(t, closed)
+		closed, err = s.CloseSeason(ctx, season.ID, end)
+		require.NoError(t, err)
+		require.False(t, closed)
+
+		season, err = s.GetSeason(ctx, season.ID)
+		require.NoError(t, err)
+		require.NotNil(t, season.ClosedAt)
+		rank, err := s.GetSeasonUserRank(ctx, season, "bob", MetricTotal)
+		require.NoError(t, err)
+		require.Equal(t, 2, rank)
+
+		seasons, err := s.ListSeasons(ctx, &g1)
+		require.NoError(t, err)
+		require.Len(t, seasons, 2)
+	})
+}
+
+func TestStore_Treats(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+		now := time.Now()
+
+		balance, err := s.AddTreats(ctx, "g1", "alice", 10, "meow", now)
+		require.NoError(t, err)
+		require.Equal(t, 10, balance)
+		balance, err = s.AddTreats(ctx, "g1", "alice", -25, "penalty", now)
+		require.NoError(t, err)
+		require.Equal(t, 0, balance)
+
+		_, err = s.PurchaseItem(ctx, "g1", "alice", "hat", 5, now)
+		require.ErrorIs(t, err, ErrInsufficientTreats)
+
+		_, err = s.AddTreats(ctx, "g1", "alice", 8, "meow", now)
+		require.NoError(t, err)
+		balance, err = s.PurchaseItem(ctx, "g1", "alice", "hat", 5, now)
+		require.NoError(t, err)
+		require.Equal(t, 3, balance)
+		_, err = s.PurchaseItem(ctx, "g1", "alice", "hat", 1, now)
+		require.ErrorIs(t, err, ErrAlreadyOwned)
+
+		items, err := s.GetUserItems(ctx, "g1", "alice")
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+
+		balance, err = s.RefundItem(ctx, "g1", "alice", "hat", 5, now)
+		require.NoError(t, err)
+		require.Equal(t, 8, balance)
+		_, err = s.RefundItem(ctx, "g1", "alice", "hat", 5, now)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		ledger, err := s.GetTreatLedger(ctx, "g1", "alice", 10)
+		require.NoError(t, err)
+		require.Len(t, ledger, 5)
+		require.Equal(t, "refund:hat", ledger[0].Reason)
+		require.Equal(t, -10, ledger[3].Amount, "the penalty only takes what there is")
+	})
+}
+
+func TestStore_ItemRoles(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+
+		roleID, err := s.GetItemRole(ctx, "g1", "role_cat_herder")
+		require.NoError(t, err)
+		require.Empty(t, roleID)
+
+		require.NoError(t, s.SetItemRole(ctx, "g1", "role_cat_herder", "r1"))
+		require.NoError(t, s.SetItemRole(ctx, "g1", "role_cat_herder", "r2"))
+		roleID, err = s.GetItemRole(ctx, "g1", "role_cat_herder")
+		require.NoError(t, err)
+		require.Equal(t, "r2", roleID)
+
+		roleID, err = s.GetItemRole(ctx, "g2", "role_cat_herder")
+		require.NoError(t, err)
+		require.Empty(t, roleID, "roles are per guild")
+
+		require.ErrorIs(t, s.SetItemRole(ctx, "missing", "role_cat_herder", "r3"), ErrInvalidInput)
+	})
+}
+
+func TestStore_Recaps(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+		start := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+		end := start.AddDate(0, 0, 7)
+
+		events := []MeowEvent{
+			{GuildID: "g1", UserID: "alice", Success: true, Chain: 1, CreatedAt: start.Add(time.Minute)},
+			{GuildID: "g1", UserID: "alice", Success: true, Chain: 2, HighScore: true, CreatedAt: start.Add(2 * time.Minute)},
+			{GuildID: "g1", UserID: "bob", Success: false, Broken: 2, CreatedAt: start.Add(3 * time.Minute)},
+			{GuildID: "g1", UserID: "bob", Success: true, Chain: 1, CreatedAt: end},
+		}
+		for _, e := range events {
+			require.NoError(t, s.RecordMeowEvent(ctx, e))
+		}
+
+		recap, err := s.GetGuildRecap(ctx, "g1", start, end, 3)
+		require.NoError(t, err)
+		require.Equal(t, 3, recap.TotalMeows)
+		require.Equal(t, 2, recap.SuccessfulMeows)
+		require.Equal(t, 2, recap.BestStreak)
+		require.Equal(t, 1, recap.NewRecords)
+		require.Len(t, recap.TopContributors, 1)
+		require.Equal(t, "bob", recap.Breaker.ID)
+		require.Equal(t, 2, recap.BrokenStreak)
+
+		// A chain that keeps beating the record is one new record, counted in
+		// the period it first beat it.
+		next := end.AddDate(0, 0, 7)
+		for i, e := range []MeowEvent{
+			{UserID: "alice", Success: true, Chain: 2, HighScore: true},
+			{UserID: "bob", Success: true, Chain: 3, HighScore: true},
+			{UserID: "alice", Success: true, Chain: 4, HighScore: true},
+			{UserID: "alice", Success: false, Broken: 4},
+			{UserID: "bob", Success: true, Chain: 1},
+			{UserID: "alice", Success: true, Chain: 2},
+			{UserID: "bob", Success: true, Chain: 3},
+			{UserID: "alice", Success: true, Chain: 4},
+			{UserID: "bob", Success: true, Chain: 5, HighScore: true},
+			{UserID: "alice", Success: true, Chain: 6, HighScore: true},
+		} {
+			e.GuildID, e.CreatedAt = "g1", end.Add(time.Duration(i+1)*time.Minute)
+			require.NoError(t, s.RecordMeowEvent(ctx, e))
+		}
+		require.NoError(t, s.RecordMeowEvent(ctx, MeowEvent{GuildID: "g1", UserID: "bob", Success: true, Chain: 7, HighScore: true, CreatedAt: next}))
+		recap, err = s.GetGuildRecap(ctx, "g1", end, next, 3)
+		require.NoError(t, err)
+		require.Equal(t, 2, recap.NewRecords)
+		recap, err = s.GetGuildRecap(ctx, "g1", next, next.AddDate(0, 0, 7), 3)
+		require.NoError(t, err)
+		require.Zero(t, recap.NewRecords, "the chain beat the record in the period before")
+
+		// Re-opting in never moves the claimed period back.
+		require.NoError(t, s.UpsertRecapSettings(ctx, RecapSettings{GuildID: "g1", Frequency: "weekly"}))
+		claimed, err := s.ClaimRecapPeriod(ctx, "g1", end)
+		require.NoError(t, err)
+		require.True(t, claimed)
+		claimed, err = s.ClaimRecapPeriod(ctx, "g1", end)
+		require.NoError(t, err)
+		require.False(t, claimed)
+		require.NoError(t, s.UpsertRecapSettings(ctx, RecapSettings{GuildID: "g1", Frequency: "daily", LastPeriodEnd: &start}))
+
+		settings, err := s.GetGuildRecapSettings(ctx, "g1")
+		require.NoError(t, err)
+		require.Equal(t, "daily", settings.Frequency)
+		require.True(t, settings.LastPeriodEnd.Equal(end))
+
+		deleted, err := s.DeleteRecapSettings(ctx, "g1")
+		require.NoError(t, err)
+		require.True(t, deleted)
+	})
+}
+
+func TestStore_Achievements(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+		first := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+		unlocked, err := s.UnlockAchievement(ctx, "g2", "alice", "first_meow", first.Add(time.Hour))
+		require.NoError(t, err)
+		require.True(t, unlocked)
+		_, err = s.UnlockAchievement(ctx, "g1", "alice", "first_meow", first)
+		require.NoError(t, err)
+		unlocked, err = s.UnlockAchievement(ctx, "g1", "alice", "first_meow", first)
+		require.NoError(t, err)
+		require.False(t, unlocked)
+
+		achievements, err := s.GetUserAchievements(ctx, "alice", nil)
+		require.NoError(t, err)
+		require.Len(t, achievements, 1)
+		require.True(t, achievements[0].UnlockedAt.Equal(first))
+	})
+}
+
+func TestStore_BansAndAudit(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+		now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		expires := now.Add(time.Hour)
+
+		require.NoError(t, s.BanUser(ctx, MeowBan{GuildID: "g1", UserID: "bob", Reason: "spam", DeleteMessages: true, CreatedAt: now, ExpiresAt: &expires}))
+		ban, err := s.GetActiveBan(ctx, "g1", "bob", now)
+		require.NoError(t, err)
+		require.Equal(t, "spam", ban.Reason)
+		require.True(t, ban.DeleteMessages)
+		ban, err = s.GetActiveBan(ctx, "g1", "bob", expires)
+		require.NoError(t, err)
+		require.Nil(t, ban)
+
+		bans, err := s.ListBans(ctx, nil, now)
+		require.NoError(t, err)
+		require.Len(t, bans, 1)
+		unbanned, err := s.UnbanUser(ctx, "g1", "bob")
+		require.NoError(t, err)
+		require.True(t, unbanned)
+
+		for i, action := range []string{"ban", "unban", "channel"} {
+			require.NoError(t, s.RecordAudit(ctx, AuditEntry{GuildID: "g1", ActorID: "alice", Action: action, CreatedAt: now.Add(time.Duration(i) * time.Minute)}))
+		}
+		entries, err := s.GetAuditLog(ctx, AuditFilter{GuildID: "g1", Since: now.Add(time.Minute)})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.Equal(t, "channel", entries[0].Action)
+		require.Empty(t, entries[0].Target)
+	})
+}
+
+func TestStore_Notifications(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+		now := time.Now()
+
+		require.NoError(t, s.UpsertNotificationPrefs(ctx, NotificationPrefs{UserID: "alice", RankChanges: true}))
+		require.NoError(t, s.UpsertNotificationPrefs(ctx, NotificationPrefs{UserID: "bob", StreakMilestones: true}))
+		require.NoError(t, s.IncrementMeow(ctx, "g1", "alice", true, now))
+		require.NoError(t, s.IncrementMeow(ctx, "g1", "bob", true, now))
+		require.NoError(t, s.IncrementMeow(ctx, "g1", "bob", true, now))
+		require.NoError(t, s.IncrementMeow(ctx, "g2", "alice", true, now))
+		require.NoError(t, s.IncrementMeow(ctx, "g2", "alice", true, now))
+		require.NoError(t, s.IncrementMeow(ctx, "g2", "alice", true, now))
+		require.NoError(t, s.UpsertNotificationPrefs(ctx, NotificationPrefs{UserID: "carol", RankChanges: true}))
+		require.NoError(t, s.SetWatchedRank(ctx, "g1", "alice", 1))
+
+		// Ranks are the guild's alone; carol has no stats in g1.
+		watchers, err := s.GetRankWatchers(ctx, "g1")
+		require.NoError(t, err)
+		require.Equal(t, []RankWatcher{{UserID: "alice", LastRank: 1, Rank: 2}}, watchers)
+
+		claimed, err := s.ClaimNotification(ctx, "alice", now, time.Hour)
+		require.NoError(t, err)
+		require.True(t, claimed)
+		claimed, err = s.ClaimNotification(ctx, "alice", now.Add(time.Minute), time.Hour)
+		require.NoError(t, err)
+		require.False(t, claimed)
+	})
+}
+
+func TestStore_Members(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+		joined := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+		// Renames are recorded; profile-only updates aren't.
+		require.NoError(t, s.UpsertUser(ctx, User{ID: "alice", Username: "alice", DisplayName: "Alice", Avatar: "a1"}))
+		require.NoError(t, s.UpsertUser(ctx, User{ID: "alice", Username: "alice2", DisplayName: "Alice", Avatar: "a2"}))
+		history, err := s.GetUsernameHistory(ctx, "alice")
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		require.Equal(t, "alice", history[0].Username)
+		require.Equal(t, "alice2", history[1].Username)
+
+		users, err := s.GetAllUsers(ctx)
+		require.NoError(t, err)
+		for _, u := range users {
+			if u.ID == "alice" {
+				require.Equal(t, "Alice", u.Name())
+				require.Equal(t, "a2", u.Avatar)
+			}
+		}
+
+		require.NoError(t, s.UpsertGuildMember(ctx, GuildMember{GuildID: "g1", UserID: "alice", Nickname: "Al", JoinedAt: &joined, UpdatedAt: joined}))
+		require.NoError(t, s.UpsertGuildMember(ctx, GuildMember{GuildID: "g1", UserID: "alice", UpdatedAt: joined.Add(time.Hour)}))
+		member, err := s.GetGuildMember(ctx, "g1", "alice")
+		require.NoError(t, err)
+		require.Empty(t, member.Nickname)
+		require.True(t, joined.Equal(*member.JoinedAt))
+		member, err = s.GetGuildMember(ctx, "g2", "alice")
+		require.NoError(t, err)
+		require.Nil(t, member)
+	})
+}
+
+func TestStore_Privacy(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+		now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+		require.NoError(t, s.UpsertUser(ctx, User{ID: "alice", Username: "alice2"}))
+		require.NoError(t, s.IncrementMeow(ctx, "g1", "alice", true, now))
+		require.NoError(t, s.RecordMeowEvent(ctx, MeowEvent{GuildID: "g1", UserID: "alice", Success: true, Chain: 1, CreatedAt: now}))
+		_, err := s.AddTreats(ctx, "g1", "alice", 5, "meow", now)
+		require.NoError(t, err)
+		require.NoError(t, s.UpsertNotificationPrefs(ctx, NotificationPrefs{UserID: "alice", RankChanges: true}))
+		require.NoError(t, s.BanUser(ctx, MeowBan{GuildID: "g1", UserID: "bob", BannedBy: "alice", CreatedAt: now}))
+		require.NoError(t, s.RecordAudit(ctx, AuditEntry{GuildID: "g1", ActorID: "bob", Action: "ban", Target: "<@alice>",
+			After: mustJSON(t, MeowBan{GuildID: "g1", UserID: "alice", BannedBy: "bob", Reason: "spam", CreatedAt: now}), CreatedAt: now}))
+		require.NoError(t, s.RecordAudit(ctx, AuditEntry{GuildID: "g1", ActorID: "alice", Action: "ban", Target: "<@bob>",
+			After: mustJSON(t, MeowBan{GuildID: "g1", UserID: "bob", BannedBy: "alice", Reason: "flood", CreatedAt: now}), CreatedAt: now}))
+
+		data, err := s.ExportUserData(ctx, "alice")
+		require.NoError(t, err)
+		require.Len(t, data.Tables["users"], 1)
+		require.Len(t, data.Tables["username_history"], 2)
+		require.Len(t, data.Tables["user_guild_stats"], 1)
+		require.Len(t, data.Tables["meow_events"], 1)
+		require.Len(t, data.Tables["treat_ledger"], 1)
+		require.Len(t, data.Tables["notification_prefs"], 1)
+		require.Len(t, data.Tables["meow_bans.banned_by"], 1)
+		require.Len(t, data.Tables["audit_log.target"], 1)
+		require.NotContains(t, data.Tables, "meow_bans")
+		_, err = s.ExportUserData(ctx, "nobody")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		anonID, err := s.DeleteUserData(ctx, "alice")
+		require.NoError(t, err)
+		_, err = s.ExportUserData(ctx, "alice")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		// What counts toward totals or records an action stays, under the stand-in.
+		data, err = s.ExportUserData(ctx, anonID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"audit_log.actor_id", "audit_log.target", "meow_bans.banned_by", "meow_events", "user_guild_stats", "users"}, slices.Sorted(maps.Keys(data.Tables)))
+		require.Equal(t, deletedUsername, data.Tables["users"][0]["username"])
+
+		// Neither the user's ID nor the reason for banning them is left in the audit log.
+		entries, err := s.GetAuditLog(ctx, AuditFilter{})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		for _, e := range entries {
+			row := mustJSON(t, e)
+			require.NotContains(t, row, "alice")
+			require.NotContains(t, row, "spam")
+		}
+		require.Contains(t, mustJSON(t, entries), "flood", "other users' bans keep their details")
+	})
+}
+
+func TestStore_GuildExport(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		ctx := context.Background()
+		seedStore(t, s)
+		now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+		require.NoError(t, s.UpsertGuildChannel(ctx, "g1", "c1"))
+		require.NoError(t, s.UpsertGuildLocale(ctx, "g1", "fr"))
+		require.NoError(t, s.UpsertGuildTimezone(ctx, "g1", "Europe/Paris"))
+		require.NoError(t, s.UpsertRecapSettings(ctx, RecapSettings{GuildID: "g1", Frequency: "weekly"}))
+		alice := "alice"
+		require.NoError(t, s.UpsertGuildStreak(ctx, GuildStreak{GuildID: "g1", MeowCount: 2, LastUserID: &alice, HighScore: 5, HighScoreUserID: &alice}))
+		require.NoError(t, s.IncrementMeow(ctx, "g1", "alice", true, now))
+		require.NoError(t, s.IncrementMeow(ctx, "g1", "bob", false, now))
+		require.NoError(t, s.IncrementMeow(ctx, "g2", "carol", true, now))
+		require.NoError(t, s.UpsertGuildMember(ctx, GuildMember{GuildID: "g1", UserID: "alice", Nickname: "Al", UpdatedAt: now}))
+		_, err := s.UnlockAchievement(ctx, "g1", "alice", "first_meow", now)
+		require.NoError(t, err)
+		require.NoError(t, s.BanUser(ctx, MeowBan{GuildID: "g1", UserID: "bob", BannedBy: "alice", CreatedAt: now}))
+		_, err = s.AddTreats(ctx, "g1", "alice", 10, "meow", now)
+		require.NoError(t, err)
+		_, err = s.PurchaseItem(ctx, "g1", "alice", "hat", 3, now)
+		require.NoError(t, err)
+		require.NoError(t, s.SetItemRole(ctx, "g1", "hat", "role1"))
+
+		g1 := "g1"
+		winter, err := s.CreateSeason(ctx, Season{GuildID: &g1, Name: "Winter",
+			StartsAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), EndsAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)})
+		require.NoError(t, err)
+		require.NoError(t, s.IncrementSeasonMeow(ctx, "g1", "alice", true, winter.StartsAt))
+		require.NoError(t, s.IncrementSeasonMeow(ctx, "g1", "bob", true, winter.StartsAt))
+		require.NoError(t, s.IncrementSeasonMeow(ctx, "g1", "bob", false, winter.StartsAt))
+		_, err = s.CloseSeason(ctx, winter.ID, winter.EndsAt)
+		require.NoError(t, err)
+		summer := Season{Name: "Summer", StartsAt: now.AddDate(0, -1, 0), EndsAt: now.AddDate(0, 1, 0)}
+		global, err := s.CreateSeason(ctx, summer)
+		require.NoError(t, err)
+		summer.GuildID = &g1
+		_, err = s.CreateSeason(ctx, summer)
+		require.NoError(t, err)
+		require.NoError(t, s.IncrementSeasonMeow(ctx, "g1", "alice", true, now))
+		require.NoError(t, s.IncrementSeasonMeow(ctx, "g2", "carol", true, now))
+
+		export, err := s.ExportGuild(ctx, "g1")
+		require.NoError(t, err)
+		require.Equal(t, GuildExportVersion, export.Version)
+		require.Equal(t, "c1", export.ChannelID)
+		require.Equal(t, "Europe/Paris", export.Settings.Timezone)
+		require.Equal(t, 5, export.Streak.HighScore)
+		require.Len(t, export.Users, 2)
+		require.Len(t, export.Stats, 2)
+		require.Len(t, export.Members, 1)
+		require.Len(t, export.Achievements, 1)
+		require.Len(t, export.Bans, 1)
+		require.Equal(t, []TreatBalance{{GuildID: "g1", UserID: "alice", Balance: 7}}, export.Balances)
+		require.Len(t, export.Ledger, 2)
+		require.Len(t, export.Items, 1)
+		require.Equal(t, []ItemRole{{GuildID: "g1", ItemID: "hat", RoleID: "role1"}}, export.ItemRoles)
+		require.Len(t, export.Seasons, 2, "global seasons are left out")
+		require.Len(t, export.SeasonStats, 2, "counters in the guild's and the global season")
+		require.Equal(t, []SeasonStanding{
+			{SeasonID: winter.ID, UserID: "bob", Rank: 1, SuccessfulMeows: 1, FailedMeows: 1, TotalMeows: 2},
+			{SeasonID: winter.ID, UserID: "alice", Rank: 2, SuccessfulMeows: 1, TotalMeows: 1},
+		}, export.Standings)
+		_, err = s.ExportGuild(ctx, "nope")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		// The export survives a trip through JSON.
+		body, err := json.Marshal(export)
+		require.NoError(t, err)
+		var restored GuildExport
+		require.NoError(t, json.Unmarshal(body, &restored))
+
+		// Changes made after the export...
+		require.NoError(t, s.UpsertGuildChannel(ctx, "g1", "c2"))
+		require.NoError(t, s.IncrementMeow(ctx, "g1", "carol", true, now))
+
+		// ...are reported but kept by a dry run...
+		report, err := s.ImportGuild(ctx, &restored, true)
+		require.NoError(t, err)
+		require.True(t, report.DryRun)
+		require.Contains(t, report.Conflicts, ImportConflict{Table: "user_guild_stats", Rows: 3})
+		channel, err := s.GetChannelForGuild(ctx, "g1")
+		require.NoError(t, err)
+		require.Equal(t, "c2", channel)
+
+		// ...and undone by the import.
+		report, err = s.ImportGuild(ctx, &restored, false)
+		require.NoError(t, err)
+		require.Contains(t, report.Conflicts, ImportConflict{Table: "seasons", Rows: 2})
+		require.Zero(t, report.SkippedSeasonStats)
+		again, err := s.ExportGuild(ctx, "g1")
+		require.NoError(t, err)
+		again.ExportedAt = export.ExportedAt
+		require.Equal(t, exportWithoutIDs(t, export), exportWithoutIDs(t, again))
+		g2 := "g2"
+		_, err = s.GetUserStats(ctx, &g2, "carol")
+		require.NoError(t, err, "other guilds are left alone")
+		rank, err := s.GetSeasonUserRank(ctx, global, "carol", MetricTotal)
+		require.NoError(t, err)
+		require.Equal(t, 1, rank, "other guilds keep their global season counters")
+
+		// Counters of a global season the database doesn't have open are
+		// skipped.
+		_, err = s.CloseSeason(ctx, global.ID, now)
+		require.NoError(t, err)
+		report, err = s.ImportGuild(ctx, &restored, true)
+		require.NoError(t, err)
+		require.Equal(t, 1, report.SkippedSeasonStats)
+
+		restored.Version = GuildExportVersion + 1
+		report, err = s.ImportGuild(ctx, &restored, true)
+		require.ErrorIs(t, err, ErrInvalidInput)
+		require.Len(t, report.Problems, 1)
+
+		restored.Version = GuildExportVersion
+		restored.Users = restored.Users[:1]
+		restored.Settings.Timezone = "Mars/Olympus"
+		report, err = s.ImportGuild(ctx, &restored, true)
+		require.ErrorIs(t, err, ErrInvalidInput)
+		require.GreaterOrEqual(t, len(report.Problems), 2)
+	})
+}
+
+// exportWithoutIDs renders e as JSON with the IDs an import assigns anew
+// replaced: ledger entries lose theirs and the guild's seasons are numbered
+// by position.
+func exportWithoutIDs(t *testing.T, e *GuildExport) string {
+	t.Helper()
+	var c GuildExport
+	require.NoError(t, json.Unmarshal([]byte(mustJSON(t, e)), &c))
+	seasonIDs := make(map[int64]int64)
+	for i := range c.Seasons {
+		seasonIDs[c.Seasons[i].ID] = int64(i + 1)
+		c.Seasons[i].ID = int64(i + 1)
+	}
+	for i := range c.Ledger {
+		c.Ledger[i].ID = 0
+	}
+	for i, s := range c.SeasonStats {
+		if id, ok := seasonIDs[s.SeasonID]; ok {
+			c.SeasonStats[i].SeasonID = id
+		}
+	}
+	for i, s := range c.Standings {
+		c.Standings[i].SeasonID = seasonIDs[s.SeasonID]
+	}
+	return mustJSON(t, c)
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	body, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(body)
+}
@@ -0,0 +1,17 @@
+package db
+
+import (
+	"context"
+	"libs/go/meowbot/util"
+	"time"
+)
+
+// trace logs the duration of a db operation at debug level, tagged with the
+// correlation ID of the Discord event or API request that triggered it.
+// Use as: defer trace(ctx, "GetUserStats")()
+func trace(ctx context.Context, op string) func() {
+	start := time.Now()
+	return func() {
+		util.LoggerFrom(ctx).Debug("🗄️ DB call", "op", op, "duration", time.Since(start))
+	}
+}
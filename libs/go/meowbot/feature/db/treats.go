@@ -0,0 +1,258 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrInsufficientTreats means a purchase costs more than the buyer's balance.
+	ErrInsufficientTreats = fmt.Errorf("%w: insufficient treats", ErrInvalidInput)
+	// ErrAlreadyOwned means the buyer already owns the item.
+	ErrAlreadyOwned = fmt.Errorf("%w: item already owned", ErrInvalidInput)
+)
+
+// lockBalance returns the user's balance, creating the row if needed, and
+// locks it until the transaction ends so concurrent updates serialize. SQLite
+// has no row locks, but its transactions already hold the database's write
+// lock from the start.
+func lockBalance(ctx context.Context, tx *sql.Tx, d dialect, guildID, userID string) (int, error) {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO treat_balances (guild_id, user_id, balance)
+		VALUES ($1, $2, 0)
+		ON CONFLICT (guild_id, user_id) DO NOTHING;
+	`, guildID, userID)
+	if err != nil {
+		return 0, classify(fmt.Errorf("failed to create treat balance: %w", err))
+	}
+
+	var balance int
+	err = tx.QueryRowContext(ctx, d.pick(`
+		SELECT balance FROM treat_balances
+		WHERE guild_id = $1 AND user_id = $2
+		FOR UPDATE;
+	`, `
+		SELECT balance FROM treat_balances
+		WHERE guild_id = $1 AND user_id = $2;
+	`), guildID, userID).Scan(&balance)
+	if err != nil {
+		return 0, classify(fmt.Errorf("failed to lock treat balance: %w", err))
+	}
+	return balance, nil
+}
+
+// applyTreats changes a locked balance by amount and records it in the ledger.
+func applyTreats(ctx context.Context, tx *sql.Tx, guildID, userID string, balance, amount int, reason string, at time.Time) (int, error) {
+	newBalance := balance + amount
+	_, err := tx.ExecContext(ctx, `
+		UPDATE treat_balances SET balance = $3
+		WHERE guild_id = $1 AND user_id = $2;
+	`, guildID, userID, newBalance)
+	if err != nil {
+		return 0, classify(fmt.Errorf("failed to update treat balance: %w", err))
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO treat_ledger (guild_id, user_id, amount, reason, balance_after, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6);
+	`, guildID, userID, amount, reason, newBalance, at)
+	if err != nil {
+		return 0, classify(fmt.Errorf("failed to record treat transaction: %w", err))
+	}
+	return newBalance, nil
+}
+
+// AddTreats credits (or, with a negative amount, debits) the user's treats in
+// a guild and records the change in the ledger. Debits never take the
+// balance below zero; only what could be taken is recorded.
+func AddTreats(ctx context.Context, db *sql.DB, guildID, userID string, amount int, reason string, at time.Time) (balance int, err error) {
+	defer trace(ctx, "AddTreats")()
+
+	err = withTx(ctx, db, func(tx *sql.Tx) error {
+		current, err := lockBalance(ctx, tx, dialectOf(db), guildID, userID)
+		if err != nil {
+			return err
+		}
+
+		applied := max(amount, -current)
+		if applied == 0 {
+			balance = current
+			return nil
+		}
+
+		balance, err = applyTreats(ctx, tx, guildID, userID, current, applied, reason, at)
+		return err
+	})
+	return balance, err
+}
+
+// PurchaseItem buys an item for price treats. It fails with
+// ErrInsufficientTreats or ErrAlreadyOwned without changing anything.
+func PurchaseItem(ctx context.Context, db *sql.DB, guildID, userID, itemID string, price int, at time.Time) (balance int, err error) {
+	defer trace(ctx, "PurchaseItem")()
+
+	err = withTx(ctx, db, func(tx *sql.Tx) error {
+		current, err := lockBalance(ctx, tx, dialectOf(db), guildID, userID)
+		if err != nil {
+			return err
+		}
+		if current < price {
+			return ErrInsufficientTreats
+		}
+
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO user_items (guild_id, user_id, item_id, purchased_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (guild_id, user_id, item_id) DO NOTHING;
+		`, guildID, userID, itemID, at)
+		if err != nil {
+			return classify(fmt.Errorf("failed to add item: %w", err))
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return classify(err)
+		} else if n == 0 {
+			return ErrAlreadyOwned
+		}
+
+		balance, err = applyTreats(ctx, tx, guildID, userID, current, -price, "purchase:"+itemID, at)
+		return err
+	})
+	return balance, err
+}
+
+// RefundItem undoes a purchase: the item is removed and price is credited back.
+func RefundItem(ctx context.Context, db *sql.DB, guildID, userID, itemID string, price int, at time.Time) (balance int, err error) {
+	defer trace(ctx, "RefundItem")()
+
+	err = withTx(ctx, db, func(tx *sql.Tx) error {
+		current, err := lockBalance(ctx, tx, dialectOf(db), guildID, userID)
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, `
+			DELETE FROM user_items
+			WHERE guild_id = $1 AND user_id = $2 AND item_id = $3;
+		`, guildID, userID, itemID)
+		if err != nil {
+			return classify(fmt.Errorf("failed to remove item: %w", err))
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return classify(err)
+		} else if n == 0 {
+			return fmt.Errorf("%w: item not owned", ErrNotFound)
+		}
+
+		balance, err = applyTreats(ctx, tx, guildID, userID, current, price, "refund:"+itemID, at)
+		return err
+	})
+	return balance, err
+}
+
+// GetTreatBalance returns the user's treats in a guild, 0 if they have none.
+func GetTreatBalance(ctx context.Context, db *sql.DB, guildID, userID string) (int, error) {
+	defer trace(ctx, "GetTreatBalance")()
+
+	var balance int
+	err := db.QueryRowContext(ctx, `
+		SELECT balance FROM treat_balances WHERE guild_id = $1 AND user_id = $2;
+	`, guildID, userID).Scan(&balance)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, classify(fmt.Errorf("failed to get treat balance: %w", err))
+	}
+	return balance, nil
+}
+
+// GetTreatLedger returns the user's most recent treat transactions in a guild, newest first.
+func GetTreatLedger(ctx context.Context, db *sql.DB, guildID, userID string, limit int) ([]TreatLedgerEntry, error) {
+	defer trace(ctx, "GetTreatLedger")()
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, guild_id, user_id, amount, reason, balance_after, created_at
+		FROM treat_ledger
+		WHERE guild_id = $1 AND user_id = $2
+		ORDER BY id DESC
+		LIMIT $3;
+	`, guildID, userID, limit)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to get treat ledger: %w", err))
+	}
+	defer rows.Close()
+
+	var entries []TreatLedgerEntry
+	for rows.Next() {
+		var e TreatLedgerEntry
+		if err := rows.Scan(&e.ID, &e.GuildID, &e.UserID, &e.Amount, &e.Reason, &e.BalanceAfter, &e.CreatedAt); err != nil {
+			return nil, classify(err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, classify(rows.Err())
+}
+
+// GetUserItems lists the items the user owns in a guild, oldest first.
+func GetUserItems(ctx context.Context, db *sql.DB, guildID, userID string) ([]UserItem, error) {
+	defer trace(ctx, "GetUserItems")()
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT guild_id, user_id, item_id, purchased_at
+		FROM user_items
+		WHERE guild_id = $1 AND user_id = $2
+		ORDER BY purchased_at, item_id;
+	`, guildID, userID)
+	if err != nil {
+		return nil, classify(fmt.Errorf("failed to get user items: %w", err))
+	}
+	defer rows.Close()
+
+	var items []UserItem
+	for rows.Next() {
+		var item UserItem
+		if err := rows.Scan(&item.GuildID, &item.UserID, &item.ItemID, &item.PurchasedAt); err != nil {
+			return nil, classify(err)
+		}
+		items = append(items, item)
+	}
+	return items, classify(rows.Err())
+}
+
+// GetItemRole returns the ID of the role the bot created for an item in a
+// guild, or "" if it hasn't created one.
+func GetItemRole(ctx context.Context, db *sql.DB, guildID, itemID string) (string, error) {
+	defer trace(ctx, "GetItemRole")()
+
+	var roleID string
+	err := db.QueryRowContext(ctx, `
+		SELECT role_id FROM item_roles WHERE guild_id = $1 AND item_id = $2;
+	`, guildID, itemID).Scan(&roleID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", classify(fmt.Errorf("failed to get item role: %w", err))
+	}
+	return roleID, nil
+}
+
+// SetItemRole records the role the bot created for an item in a guild,
+// replacing the one recorded before.
+func SetItemRole(ctx context.Context, db *sql.DB, guildID, itemID, roleID string) error {
+	defer trace(ctx, "SetItemRole")()
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO item_roles (guild_id, item_id, role_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (guild_id, item_id) DO UPDATE SET
+			role_id = EXCLUDED.role_id;
+	`, guildID, itemID, roleID)
+	if err != nil {
+		return classify(fmt.Errorf("failed to set item role: %w", err))
+	}
+	return nil
+}
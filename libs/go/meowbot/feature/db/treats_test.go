@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func expectLockBalance(mock sqlmock.Sqlmock, balance int) {
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO treat_balances (guild_id, user_id, balance)`)).
+		WithArgs("guild-foo", "user-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+		WithArgs("guild-foo", "user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(balance))
+}
+
+func TestAddTreats_PenaltyStopsAtZero(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	now := time.Now()
+	mock.ExpectBegin()
+	expectLockBalance(mock, 2)
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE treat_balances SET balance = $3`)).
+		WithArgs("guild-foo", "user-1", 0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO treat_ledger`)).
+		WithArgs("guild-foo", "user-1", -2, "chain_break", 0, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	balance, err := AddTreats(context.Background(), mockDB, "guild-foo", "user-1", -5, "chain_break", now)
+	require.NoError(t, err)
+	require.Equal(t, 0, balance)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurchaseItem(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	now := time.Now()
+	mock.ExpectBegin()
+	expectLockBalance(mock, 120)
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO user_items`)).
+		WithArgs("guild-foo", "user-1", "title_whisker_lord", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE treat_balances SET balance = $3`)).
+		WithArgs("guild-foo", "user-1", 20).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO treat_ledger`)).
+		WithArgs("guild-foo", "user-1", -100, "purchase:title_whisker_lord", 20, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	balance, err := PurchaseItem(context.Background(), mockDB, "guild-foo", "user-1", "title_whisker_lord", 100, now)
+	require.NoError(t, err)
+	require.Equal(t, 20, balance)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurchaseItem_InsufficientTreats(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	mock.ExpectBegin()
+	expectLockBalance(mock, 50)
+	mock.ExpectRollback()
+
+	_, err := PurchaseItem(context.Background(), mockDB, "guild-foo", "user-1", "title_whisker_lord", 100, time.Now())
+	require.ErrorIs(t, err, ErrInsufficientTreats)
+	require.ErrorIs(t, err, ErrInvalidInput)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurchaseItem_AlreadyOwned(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		err := mockDB.Close()
+		if err != nil {
+
+		}
+	}(mockDB)
+
+	now := time.Now()
+	mock.ExpectBegin()
+	expectLockBalance(mock, 500)
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO user_items`)).
+		WithArgs("guild-foo", "user-1", "title_whisker_lord", now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := PurchaseItem(context.Background(), mockDB, "guild-foo", "user-1", "title_whisker_lord", 100, now)
+	require.ErrorIs(t, err, ErrAlreadyOwned)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
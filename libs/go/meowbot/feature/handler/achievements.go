@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"libs/go/meowbot/util"
+	"strings"
+	"time"
+)
+
+// meowOutcome describes what a processed message did to the guild's chain.
+type meowOutcome struct {
+	// Success is true if the message continued the chain.
+	Success bool
+	// Chain is the chain length after a successful meow.
+	Chain int
+	// Broken is the length of the chain a failed message broke.
+	Broken int
+	// HighScore is true if the meow set a new guild high score.
+	HighScore bool
+}
+
+// AchievementEvent is what achievement rules are evaluated against after each
+// processed meow.
+type AchievementEvent struct {
+	GuildID string
+	UserID  string
+	Outcome meowOutcome
+
+	// Timestamp is when the meow was sent, in the guild's timezone.
+	Timestamp time.Time
+
+	// Stats are the user's stats in the guild, including this meow.
+	Stats db.UserGuildStats
+}
+
+// Achievement is a badge a user unlocks once per guild when Rule first
+// matches. Its name and description come from the message catalog under
+// achievement.<ID>.name and achievement.<ID>.desc.
+type Achievement struct {
+	ID    string
+	Emoji string
+	Rule  func(e AchievementEvent) bool
+}
+
+// achievements lists every achievement the bot awards. Add new ones here,
+// together with their catalog entries.
+var achievements = []Achievement{
+	{
+		ID:    "first_meow",
+		Emoji: "🐣",
+		Rule:  func(e AchievementEvent) bool { return e.Stats.SuccessfulMeows >= 1 },
+	},
+	{
+		ID:    "century",
+		Emoji: "💯",
+		Rule:  func(e AchievementEvent) bool { return e.Stats.SuccessfulMeows >= 100 },
+	},
+	{
+		ID:    "chain_50",
+		Emoji: "⛓️",
+		Rule:  func(e AchievementEvent) bool { return e.Outcome.Success && e.Outcome.Chain >= 50 },
+	},
+	{
+		ID:    "chain_breaker",
+		Emoji: "💔",
+		Rule:  func(e AchievementEvent) bool { return !e.Outcome.Success && e.Outcome.Broken >= 100 },
+	},
+	{
+		ID:    "night_owl",
+		Emoji: "🦉",
+		Rule:  func(e AchievementEvent) bool { return e.Outcome.Success && e.Timestamp.Hour() == 3 },
+	},
+}
+
+func lookupAchievement(id string) (Achievement, bool) {
+	for _, a := range achievements {
+		if a.ID == id {
+			return a, true
+		}
+	}
+	return Achievement{}, false
+}
+
+// matchAchievements returns the achievements whose rule matches e and which
+// aren't in unlocked yet.
+func matchAchievements(e AchievementEvent, unlocked map[string]bool) []Achievement {
+	var matched []Achievement
+	for _, a := range achievements {
+		if !unlocked[a.ID] && a.Rule(e) {
+			matched = append(matched, a)
+		}
+	}
+	return matched
+}
+
+// checkAchievements evaluates every achievement rule for the message's author,
+// stores new unlocks and announces them in the channel.
+func (b *Bot) checkAchievements(ctx context.Context, s Discord, m *discordgo.MessageCreate, outcome meowOutcome) {
+	guildID := m.GuildID
+	user := m.Author
+
+	stats, err := b.store.GetUserStats(ctx, &guildID, user.ID)
+	if err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to fetch stats for achievements", "guildID", guildID, "userID", user.ID, "error", err)
+		return
+	}
+	existing, err := b.store.GetUserAchievements(ctx, user.ID, &guildID)
+	if err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to fetch achievements", "guildID", guildID, "userID", user.ID, "error", err)
+		return
+	}
+	unlocked := make(map[string]bool, len(existing))
+	for _, a := range existing {
+		unlocked[a.AchievementID] = true
+	}
+
+	event := AchievementEvent{
+		GuildID:   guildID,
+		UserID:    user.ID,
+		Outcome:   outcome,
+		Timestamp: m.Timestamp.In(b.guildLocation(ctx, guildID)),
+		Stats:     stats,
+	}
+
+	matched := matchAchievements(event, unlocked)
+	if len(matched) == 0 {
+		return
+	}
+
+	tr := b.localizerForGuild(ctx, guildID)
+	for _, a := range matched {
+		isNew, err := b.store.UnlockAchievement(ctx, guildID, user.ID, a.ID, m.Timestamp)
+		if err != nil {
+			util.LoggerFrom(ctx).Error("❌ Failed to unlock achievement", "guildID", guildID, "userID", user.ID, "achievement", a.ID, "error", err)
+			continue
+		}
+		if !isNew {
+			continue
+		}
+
+		util.LoggerFrom(ctx).Info("🏅 Achievement unlocked", "guildID", guildID, "userID", user.ID, "achievement", a.ID)
+		_ = sendMessage(s, m.ChannelID, tr.T("achievement.unlocked", a.Emoji, user.Username, tr.T("achievement."+a.ID+".name"), tr.T("achievement."+a.ID+".desc")), guildID)
+	}
+}
+
+// formatAchievements renders a user's badges with their unlock dates, one per line.
+func formatAchievements(tr Localizer, unlocked []db.UserAchievement) string {
+	if len(unlocked) == 0 {
+		return tr.T("achievement.none")
+	}
+
+	var b strings.Builder
+	for _, u := range unlocked {
+		a, ok := lookupAchievement(u.AchievementID)
+		if !ok {
+			continue
+		}
+		b.WriteString(tr.T("achievement.line", a.Emoji, tr.T("achievement."+a.ID+".name"), u.UnlockedAt.Unix()))
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
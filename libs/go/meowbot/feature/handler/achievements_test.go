@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"libs/go/meowbot/feature/db"
+	"strings"
+	"testing"
+	"time"
+)
+
+func achievementIDs(as []Achievement) []string {
+	ids := make([]string, 0, len(as))
+	for _, a := range as {
+		ids = append(ids, a.ID)
+	}
+	return ids
+}
+
+func TestMatchAchievements(t *testing.T) {
+	afternoon := time.Date(2025, 6, 11, 15, 0, 0, 0, time.UTC)
+	night := time.Date(2025, 6, 11, 3, 12, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		event    AchievementEvent
+		unlocked map[string]bool
+		want     []string
+	}{
+		{
+			name: "first meow",
+			event: AchievementEvent{
+				Outcome:   meowOutcome{Success: true, Chain: 1},
+				Timestamp: afternoon,
+				Stats:     db.UserGuildStats{SuccessfulMeows: 1},
+			},
+			want: []string{"first_meow"},
+		},
+		{
+			name: "already unlocked",
+			event: AchievementEvent{
+				Outcome:   meowOutcome{Success: true, Chain: 2},
+				Timestamp: afternoon,
+				Stats:     db.UserGuildStats{SuccessfulMeows: 2},
+			},
+			unlocked: map[string]bool{"first_meow": true},
+		},
+		{
+			name: "hundredth meow in a long chain at night",
+			event: AchievementEvent{
+				Outcome:   meowOutcome{Success: true, Chain: 50},
+				Timestamp: night,
+				Stats:     db.UserGuildStats{SuccessfulMeows: 100},
+			},
+			unlocked: map[string]bool{"first_meow": true},
+			want:     []string{"century", "chain_50", "night_owl"},
+		},
+		{
+			name: "broke a long chain",
+			event: AchievementEvent{
+				Outcome:   meowOutcome{Broken: 120},
+				Timestamp: night,
+				Stats:     db.UserGuildStats{SuccessfulMeows: 5},
+			},
+			unlocked: map[string]bool{"first_meow": true},
+			want:     []string{"chain_breaker"},
+		},
+		{
+			name: "broke a short chain",
+			event: AchievementEvent{
+				Outcome:   meowOutcome{Broken: 99},
+				Timestamp: afternoon,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := achievementIDs(matchAchievements(tt.event, tt.unlocked))
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("matchAchievements() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAchievementsHaveCatalogEntries(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, a := range achievements {
+		if seen[a.ID] {
+			t.Errorf("duplicate achievement ID %q", a.ID)
+		}
+		seen[a.ID] = true
+
+		for _, key := range []string{"achievement." + a.ID + ".name", "achievement." + a.ID + ".desc"} {
+			if _, ok := catalog[defaultLocale][key]; !ok {
+				t.Errorf("missing catalog entry %q", key)
+			}
+		}
+	}
+}
+
+func TestFormatAchievements(t *testing.T) {
+	tr := Localizer{Locale: defaultLocale}
+	unlockedAt := time.Date(2025, 6, 11, 3, 0, 0, 0, time.UTC)
+
+	got := formatAchievements(tr, []db.UserAchievement{
+		{AchievementID: "night_owl", UnlockedAt: unlockedAt},
+		{AchievementID: "retired_badge", UnlockedAt: unlockedAt},
+	})
+	if !strings.Contains(got, "Night Owl") || !strings.Contains(got, "<t:1749610800:D>") {
+		t.Errorf("formatAchievements() = %q, want Night Owl with its unlock date", got)
+	}
+	if strings.Contains(got, "retired_badge") {
+		t.Errorf("formatAchievements() should skip unknown achievements, got %q", got)
+	}
+
+	if got := formatAchievements(tr, nil); got != tr.T("achievement.none") {
+		t.Errorf("formatAchievements(nil) = %q", got)
+	}
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"libs/go/meowbot/util"
+	"strings"
+	"time"
+)
+
+// Audited actions.
+const (
+	auditChannelSet   = "channel.set"
+	auditLanguageSet  = "language.set"
+	auditTimezoneSet  = "timezone.set"
+	auditRecapEnable  = "recap.enable"
+	auditRecapDisable = "recap.disable"
+	auditSeasonCreate = "season.create"
+	auditBan          = "ban"
+	auditUnban        = "unban"
+)
+
+const (
+	auditPageSize  = 10
+	auditValueSize = 80
+)
+
+// recordAudit stores an administrative action taken through the interaction.
+// before and after are the changed value; strings are stored as is, nil as
+// nothing and anything else as JSON.
+func (b *Bot) recordAudit(ctx context.Context, i *discordgo.InteractionCreate, action, target string, before, after any) {
+	entry := db.AuditEntry{
+		GuildID:   i.GuildID,
+		ActorID:   interactionUserID(i),
+		Action:    action,
+		Target:    target,
+		Before:    auditValue(before),
+		After:     auditValue(after),
+		CreatedAt: time.Now(),
+	}
+	if err := b.store.RecordAudit(ctx, entry); err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to record audit entry", "guildID", entry.GuildID, "actorID", entry.ActorID, "action", action, "error", err)
+	}
+}
+
+func auditValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	if string(b) == "null" {
+		return ""
+	}
+	return string(b)
+}
+
+func (b *Bot) handleAudit(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	tr := localizerFor(ctx, i)
+	filter := db.AuditFilter{GuildID: i.GuildID, Limit: auditPageSize}
+
+	var since, until string
+	for _, opt := range i.ApplicationCommandData().Options {
+		switch opt.Name {
+		case "actor":
+			filter.ActorID = opt.UserValue(nil).ID
+		case "since":
+			since = opt.StringValue()
+		case "until":
+			until = opt.StringValue()
+		}
+	}
+
+	var err error
+	if filter.Since, filter.Until, err = parseAuditDates(since, until, b.guildLocation(ctx, i.GuildID)); err != nil {
+		embed := formatSimpleEmbed(tr.T("audit.error.title"), tr.T("audit.invalid.desc"), 0xED4245)
+		sendResponseEmbed(ctx, s, i, embed, i.GuildID, "audit")
+		return
+	}
+
+	entries, err := b.store.GetAuditLog(ctx, filter)
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("audit.error.title"), tr.T("audit.error.desc"), i.GuildID, "audit", err)
+		return
+	}
+	if len(entries) == 0 {
+		embed := formatSimpleEmbed(tr.T("audit.title"), tr.T("audit.empty"), 0xFEE75C)
+		sendResponseEmbed(ctx, s, i, embed, i.GuildID, "audit")
+		return
+	}
+
+	embed := formatSimpleEmbed(tr.T("audit.title"), formatAuditLog(tr, entries))
+	sendResponseEmbed(ctx, s, i, embed, i.GuildID, "audit")
+}
+
+// parseAuditDates parses the optional first and last day, in loc, of an audit
+// query into an inclusive start and an exclusive end.
+func parseAuditDates(since, until string, loc *time.Location) (time.Time, time.Time, error) {
+	var start, end time.Time
+	if since != "" {
+		t, err := time.ParseInLocation(seasonDateLayout, since, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		start = t
+	}
+	if until != "" {
+		t, err := time.ParseInLocation(seasonDateLayout, until, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		end = t.AddDate(0, 0, 1)
+	}
+	if !start.IsZero() && !end.IsZero() && !end.After(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("audit range ends before it starts")
+	}
+	return start, end, nil
+}
+
+func formatAuditLog(tr Localizer, entries []db.AuditEntry) string {
+	none := tr.T("audit.value.none")
+	value := func(v string) string {
+		if v == "" {
+			return none
+		}
+		if r := []rune(v); len(r) > auditValueSize {
+			v = string(r[:auditValueSize]) + "…"
+		}
+		return "`" + strings.ReplaceAll(v, "`", "'") + "`"
+	}
+
+	var b strings.Builder
+	for _, e := range entries {
+		target := ""
+		if e.Target != "" {
+			target = " " + e.Target
+		}
+		b.WriteString(tr.T("audit.line", e.CreatedAt.Unix(), e.ActorID, e.Action, target, value(e.Before), value(e.After)))
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"libs/go/meowbot/feature/db"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuditValue(t *testing.T) {
+	var noBan *db.MeowBan
+	tests := []struct {
+		in   any
+		want string
+	}{
+		{nil, ""},
+		{"c1", "c1"},
+		{noBan, ""},
+		{map[string]int{"a": 1}, `{"a":1}`},
+	}
+	for _, tc := range tests {
+		if got := auditValue(tc.in); got != tc.want {
+			t.Errorf("auditValue(%#v) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParseAuditDates(t *testing.T) {
+	start, end, err := parseAuditDates("2025-06-01", "2025-06-01", time.UTC)
+	if err != nil {
+		t.Fatalf("parseAuditDates() error = %v", err)
+	}
+	if want := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("start = %v, want %v", start, want)
+	}
+	if want := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC); !end.Equal(want) {
+		t.Errorf("end = %v, want the end of the day (%v)", end, want)
+	}
+
+	if start, end, err := parseAuditDates("", "", time.UTC); err != nil || !start.IsZero() || !end.IsZero() {
+		t.Errorf("parseAuditDates(\"\", \"\") = %v, %v, %v; want no bounds", start, end, err)
+	}
+	for _, tc := range [][2]string{{"2025-06-02", "2025-06-01"}, {"yesterday", ""}} {
+		if _, _, err := parseAuditDates(tc[0], tc[1], time.UTC); err == nil {
+			t.Errorf("parseAuditDates(%q, %q) should fail", tc[0], tc[1])
+		}
+	}
+}
+
+func TestFormatAuditLog(t *testing.T) {
+	tr := Localizer{Locale: defaultLocale}
+	at := time.Unix(1750000000, 0)
+
+	got := formatAuditLog(tr, []db.AuditEntry{
+		{ActorID: "a1", Action: auditChannelSet, After: "c2", CreatedAt: at},
+		{ActorID: "a1", Action: auditBan, Target: "<@u1>", Before: strings.Repeat("x", 100), CreatedAt: at},
+	})
+	lines := strings.Split(got, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), got)
+	}
+	if want := "<t:1750000000:f> <@a1> **channel.set**: none → `c2`"; lines[0] != want {
+		t.Errorf("line 0 = %q, want %q", lines[0], want)
+	}
+	if !strings.Contains(lines[1], "**ban** <@u1>: `"+strings.Repeat("x", auditValueSize)+"…` → none") {
+		t.Errorf("line 1 = %q", lines[1])
+	}
+}
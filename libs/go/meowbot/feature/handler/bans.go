@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"libs/go/meowbot/util"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (b *Bot) handleMeowBan(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	tr := localizerFor(ctx, i)
+	guildID := i.GuildID
+
+	var (
+		user           *discordgo.User
+		duration       string
+		reason         string
+		deleteMessages bool
+	)
+	for _, opt := range i.ApplicationCommandData().Options {
+		switch opt.Name {
+		case "user":
+			user = opt.UserValue(nil)
+		case "duration":
+			duration = opt.StringValue()
+		case "reason":
+			reason = strings.TrimSpace(opt.StringValue())
+		case "delete":
+			deleteMessages = opt.BoolValue()
+		}
+	}
+	if user == nil {
+		return
+	}
+
+	length, err := parseBanDuration(duration)
+	if err != nil {
+		embed := formatSimpleEmbed(tr.T("meowban.error.title"), tr.T("meowban.duration.invalid", duration), 0xED4245)
+		sendResponseEmbed(ctx, s, i, embed, guildID, "meowban")
+		return
+	}
+
+	now := time.Now()
+	ban := db.MeowBan{
+		GuildID:        guildID,
+		UserID:         user.ID,
+		Reason:         reason,
+		BannedBy:       interactionUserID(i),
+		DeleteMessages: deleteMessages,
+		CreatedAt:      now,
+	}
+	if length > 0 {
+		expiresAt := now.Add(length)
+		ban.ExpiresAt = &expiresAt
+	}
+
+	previous, err := b.store.GetActiveBan(ctx, guildID, user.ID, now)
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("meowban.error.title"), tr.T("meowban.error.desc"), guildID, "meowban", err)
+		return
+	}
+
+	// The ban references the user, who may never have meowed.
+	b.upsertEntities(ctx, user, guildID)
+	if err := b.store.BanUser(ctx, ban); err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("meowban.error.title"), tr.T("meowban.error.desc"), guildID, "meowban", err)
+		return
+	}
+	b.recordAudit(ctx, i, auditBan, "<@"+user.ID+">", previous, ban)
+
+	util.LoggerFrom(ctx).Info("🔨 User banned from the meow game", "guildID", guildID, "userID", user.ID, "bannedBy", ban.BannedBy, "expiresAt", ban.ExpiresAt, "reason", reason)
+	sendSuccessEmbed(ctx, s, i, tr.T("meowban.title"), formatBan(tr, ban), guildID, "meowban")
+}
+
+func (b *Bot) handleMeowUnban(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	tr := localizerFor(ctx, i)
+	guildID := i.GuildID
+
+	var user *discordgo.User
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "user" {
+			user = opt.UserValue(nil)
+		}
+	}
+	if user == nil {
+		return
+	}
+
+	previous, err := b.store.GetActiveBan(ctx, guildID, user.ID, time.Now())
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("meowunban.error.title"), tr.T("meowunban.error.desc"), guildID, "meowunban", err)
+		return
+	}
+	unbanned, err := b.store.UnbanUser(ctx, guildID, user.ID)
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("meowunban.error.title"), tr.T("meowunban.error.desc"), guildID, "meowunban", err)
+		return
+	}
+	if !unbanned {
+		embed := formatSimpleEmbed(tr.T("meowunban.title"), tr.T("meowunban.none", user.ID), 0xFEE75C)
+		sendResponseEmbed(ctx, s, i, embed, guildID, "meowunban")
+		return
+	}
+
+	b.recordAudit(ctx, i, auditUnban, "<@"+user.ID+">", previous, nil)
+	util.LoggerFrom(ctx).Info("🕊️ User unbanned from the meow game", "guildID", guildID, "userID", user.ID, "unbannedBy", interactionUserID(i))
+	sendSuccessEmbed(ctx, s, i, tr.T("meowunban.title"), tr.T("meowunban.desc", user.ID), guildID, "meowunban")
+}
+
+// parseBanDuration parses a ban length such as "30m", "12h", "7d" or "2w".
+// An empty duration means a permanent ban and parses to 0.
+func parseBanDuration(value string) (time.Duration, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return 0, nil
+	}
+
+	var d time.Duration
+	var err error
+	switch unit := value[len(value)-1]; unit {
+	case 'd', 'w':
+		var n int
+		n, err = strconv.Atoi(value[:len(value)-1])
+		d = time.Duration(n) * 24 * time.Hour
+		if unit == 'w' {
+			d *= 7
+		}
+	default:
+		d, err = time.ParseDuration(value)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("ban duration must be positive: %q", value)
+	}
+	return d, nil
+}
+
+func formatBan(tr Localizer, ban db.MeowBan) string {
+	reason := ban.Reason
+	if reason == "" {
+		reason = tr.T("meowban.reason.none")
+	}
+	if ban.ExpiresAt == nil {
+		return tr.T("meowban.desc.permanent", ban.UserID, reason)
+	}
+	return tr.T("meowban.desc", ban.UserID, ban.ExpiresAt.Unix(), reason)
+}
+
+// rejectBanned reports whether the author of m is banned from the guild's
+// meow game, deleting the message if the ban asks for it. Lookup failures
+// let the message through.
+func (b *Bot) rejectBanned(ctx context.Context, s Discord, m *discordgo.MessageCreate) bool {
+	ban, err := b.store.GetActiveBan(ctx, m.GuildID, m.Author.ID, time.Now())
+	if err != nil {
+		util.LoggerFrom(ctx).Error("❌ Could not check meow ban", "guildID", m.GuildID, "userID", m.Author.ID, "error", err)
+		return false
+	}
+	if ban == nil {
+		return false
+	}
+
+	util.LoggerFrom(ctx).Info("🔨 Ignored message from banned user", "guildID", m.GuildID, "userID", m.Author.ID, "delete", ban.DeleteMessages)
+	if ban.DeleteMessages {
+		safeDelete(s, m.ChannelID, m.ID, m.GuildID)
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"libs/go/meowbot/feature/db"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseBanDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"", 0},
+		{"30m", 30 * time.Minute},
+		{"12h", 12 * time.Hour},
+		{"7d", 7 * 24 * time.Hour},
+		{"2W", 14 * 24 * time.Hour},
+	}
+	for _, tc := range tests {
+		got, err := parseBanDuration(tc.in)
+		if err != nil || got != tc.want {
+			t.Errorf("parseBanDuration(%q) = %v, %v; want %v", tc.in, got, err, tc.want)
+		}
+	}
+
+	for _, in := range []string{"soon", "d", "-1h", "0d", "3x"} {
+		if _, err := parseBanDuration(in); err == nil {
+			t.Errorf("parseBanDuration(%q) should fail", in)
+		}
+	}
+}
+
+func TestFormatBan(t *testing.T) {
+	tr := Localizer{Locale: defaultLocale}
+	expiresAt := time.Unix(1750000000, 0)
+
+	got := formatBan(tr, db.MeowBan{UserID: "u1", Reason: "chain troll", ExpiresAt: &expiresAt})
+	if !strings.Contains(got, "<@u1> can't play the meow game until <t:1750000000:f>") || !strings.Contains(got, "Reason: chain troll") {
+		t.Errorf("timed ban = %q", got)
+	}
+
+	got = formatBan(tr, db.MeowBan{UserID: "u1"})
+	if !strings.Contains(got, "anymore") || !strings.Contains(got, "No reason given") {
+		t.Errorf("permanent ban = %q", got)
+	}
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"libs/go/meowbot/feature/db"
+	"libs/go/meowbot/feature/state"
+)
+
+// Bot holds what the event handlers need: the store and the in-memory guild
+// state built on it.
+type Bot struct {
+	store  db.Store
+	guilds *state.Tracker
+}
+
+// NewBot builds the handlers on top of store.
+func NewBot(store db.Store) *Bot {
+	return &Bot{
+		store:  store,
+		guilds: state.NewTracker(store),
+	}
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"testing"
+)
+
+// newTestBot returns a Bot on an empty in-memory store.
+func newTestBot() *Bot {
+	return NewBot(db.NewMemoryStore())
+}
+
+func TestWithLocalizer(t *testing.T) {
+	ctx := context.Background()
+	b := newTestBot()
+	if err := b.store.UpsertGuildLocale(ctx, "g1", string(discordgo.French)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		guildID string
+		locale  discordgo.Locale
+		want    discordgo.Locale
+	}{
+		{"guild locale wins", "g1", discordgo.German, discordgo.French},
+		{"user locale without guild locale", "g2", discordgo.German, discordgo.German},
+		{"default", "g2", discordgo.Japanese, defaultLocale},
+		{"dm", "", discordgo.SpanishLATAM, discordgo.SpanishES},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: tt.guildID, Locale: tt.locale}}
+			var got Localizer
+			Chain(func(ctx context.Context, _ Discord, i *discordgo.InteractionCreate) {
+				got = localizerFor(ctx, i)
+			}, b.withLocalizer())(ctx, nil, i)
+
+			if got.Locale != tt.want {
+				t.Errorf("got locale %q, want %q", got.Locale, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsInAllowedChannel(t *testing.T) {
+	ctx := context.Background()
+	b := newTestBot()
+	if err := b.store.UpsertGuildChannel(ctx, "g1", "c1"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		guildID, channelID string
+		want               bool
+	}{
+		{"g1", "c1", true},
+		{"g1", "c2", false},
+		{"g2", "c1", false},
+	}
+	for _, tt := range tests {
+		m := &discordgo.MessageCreate{Message: &discordgo.Message{GuildID: tt.guildID, ChannelID: tt.channelID}}
+		if got := b.isInAllowedChannel(ctx, m); got != tt.want {
+			t.Errorf("isInAllowedChannel(%s, %s) = %v, want %v", tt.guildID, tt.channelID, got, tt.want)
+		}
+	}
+}
+
+func TestGuildStateLoadsFromStore(t *testing.T) {
+	ctx := context.Background()
+	b := newTestBot()
+	user := "u1"
+	if err := b.store.UpsertGuild(ctx, db.Guild{ID: "g1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.store.UpsertGuildStreak(ctx, db.GuildStreak{GuildID: "g1", MeowCount: 4, LastUserID: &user, HighScore: 9, HighScoreUserID: &user}); err != nil {
+		t.Fatal(err)
+	}
+
+	gs := b.guilds.GetOrCreate(ctx, "g1")
+	if gs.MeowCount != 4 || gs.LastUserID != "u1" || gs.HighScore != 9 {
+		t.Errorf("unexpected guild state %+v", gs)
+	}
+}
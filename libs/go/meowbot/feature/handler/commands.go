@@ -2,10 +2,10 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/bwmarrin/discordgo"
 	"libs/go/meowbot/feature/db"
-	"libs/go/meowbot/feature/state"
 	"libs/go/meowbot/util"
 	"strconv"
 	"strings"
@@ -15,74 +15,48 @@ import (
 const leaderboardPageSize = 5
 
 func sendResponseEmbed(
-	s *discordgo.Session,
+	ctx context.Context,
+	s Discord,
 	i *discordgo.InteractionCreate,
 	embed *discordgo.MessageEmbed,
 	guildID string,
 	commandName string,
 ) {
-	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds: []*discordgo.MessageEmbed{embed},
-			Flags:  discordgo.MessageFlagsEphemeral,
-		},
+	err := respond(ctx, s, i, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{embed},
+		Flags:  discordgo.MessageFlagsEphemeral,
 	})
 	if err != nil {
-		util.Cfg.Logger.Error(fmt.Sprintf("❌ Failed to respond to /%s", commandName), "error", err, "guildID", guildID)
+		util.LoggerFrom(ctx).Error(fmt.Sprintf("❌ Failed to respond to /%s", commandName), "error", err, "guildID", guildID)
 	} else {
-		util.Cfg.Logger.Info(fmt.Sprintf("💬 Responded to /%s", commandName), "guildID", guildID, "response", embed.Description)
-	}
-}
-
-// CommandHandler manages slash commands
-func CommandHandler(ctx context.Context) func(*discordgo.Session, *discordgo.InteractionCreate) {
-	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-		if i.Type != discordgo.InteractionApplicationCommand {
-			return
-		}
-
-		guildID := i.GuildID
-		gs := state.GetOrCreate(ctx, guildID)
-
-		switch i.ApplicationCommandData().Name {
-		case "count":
-			handleCount(s, i, gs)
-		case "highscore":
-			handleHighscore(s, i, gs)
-		case "stats":
-			handleStats(ctx, s, i)
-		case "setup":
-			handleSetup(ctx, s, i)
-		case "leaderboard":
-			handleLeaderboard(ctx, s, i)
-
-		default:
-			util.Cfg.Logger.Warn("⚠️ Unknown command", "guildID", guildID, "command", i.ApplicationCommandData().Name)
-		}
+		util.LoggerFrom(ctx).Info(fmt.Sprintf("💬 Responded to /%s", commandName), "guildID", guildID, "response", embed.Description)
 	}
 }
 
-func handleCount(s *discordgo.Session, i *discordgo.InteractionCreate, gs *state.GuildState) {
-	title := "📈 Meow Count"
-	desc := fmt.Sprintf("Current meow count: **%d**", gs.MeowCount)
+func (b *Bot) handleCount(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	gs := b.guilds.GetOrCreate(ctx, i.GuildID)
+	tr := localizerFor(ctx, i)
+	title := tr.T("count.title")
+	desc := tr.T("count.desc", gs.MeowCount)
 
 	embed := formatSimpleEmbed(title, desc)
-	sendResponseEmbed(s, i, embed, i.GuildID, "count")
+	sendResponseEmbed(ctx, s, i, embed, i.GuildID, "count")
 }
 
-func handleHighscore(s *discordgo.Session, i *discordgo.InteractionCreate, gs *state.GuildState) {
-	title := "🏆 High Score"
-	desc := "😿 No high score yet!"
+func (b *Bot) handleHighscore(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	gs := b.guilds.GetOrCreate(ctx, i.GuildID)
+	tr := localizerFor(ctx, i)
+	title := tr.T("highscore.title")
+	desc := tr.T("highscore.none")
 	if gs.HighScore > 0 {
-		desc = fmt.Sprintf("High score: **%d** by <@%s>", gs.HighScore, gs.HighScoreUserID)
+		desc = tr.T("highscore.desc", gs.HighScore, gs.HighScoreUserID)
 	}
 
 	embed := formatSimpleEmbed(title, desc)
-	sendResponseEmbed(s, i, embed, i.GuildID, "highscore")
+	sendResponseEmbed(ctx, s, i, embed, i.GuildID, "highscore")
 }
 
-func handleStats(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (b *Bot) handleStats(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
 	scope := "guild"
 	for _, opt := range i.ApplicationCommandData().Options {
 		switch opt.Name {
@@ -91,32 +65,33 @@ func handleStats(ctx context.Context, s *discordgo.Session, i *discordgo.Interac
 		}
 	}
 
+	tr := localizerFor(ctx, i)
 	guildID := &i.GuildID
-	scopeTitle := "Guild Stats"
+	scopeTitle := tr.T("stats.scope.guild")
 	if scope == "global" {
 		guildID = nil
-		scopeTitle = "Global Stats"
+		scopeTitle = tr.T("stats.scope.global")
 	}
 
-	stats, err := db.GetUserStats(ctx, db.DB, guildID, i.Member.User.ID)
+	stats, err := b.store.GetUserStats(ctx, guildID, interactionUserID(i))
 	if err != nil {
-		sendErrorEmbed(s, i, "❌ Failed to Fetch Stats", "Couldn't fetch your stats. You might not have any meows yet!", i.GuildID, "stats", err)
+		sendErrorEmbed(ctx, s, i, tr.T("stats.error.title"), tr.T("stats.error.desc"), i.GuildID, "stats", err)
 		return
 	}
 
-	lastMeow := "N/A"
+	lastMeow := tr.T("common.na")
 	if stats.LastMeowAt != nil {
-		lastMeow = time.Since(*stats.LastMeowAt).Round(time.Second).String() + " ago"
+		lastMeow = tr.T("time.at", formatLocalTime(*stats.LastMeowAt, b.guildLocation(ctx, i.GuildID)), tr.Ago(time.Since(*stats.LastMeowAt)))
 	}
 
-	title := fmt.Sprintf("📊 **Your Meows — %s**", scopeTitle)
-	resp := fmt.Sprintf(
-		"📈 Total Meows: %d\n"+
-			"✅ Successful Meows: %d\n"+
-			"❌ Failed Meows: %d\n"+
-			"🔁 Highest Streak: %d\n"+
-			"🔥 Current Streak: %d\n"+
-			"⏱️ Last Meow: %s",
+	title := tr.T("stats.title", scopeTitle)
+	if guildID != nil {
+		if profile := b.profileTitle(ctx, tr, i.GuildID, interactionUserID(i)); profile != "" {
+			title += " · " + profile
+		}
+	}
+	resp := tr.T(
+		"stats.body",
 		stats.TotalMeows,
 		stats.SuccessfulMeows,
 		stats.FailedMeows,
@@ -125,45 +100,182 @@ func handleStats(ctx context.Context, s *discordgo.Session, i *discordgo.Interac
 		lastMeow,
 	)
 
+	badges, err := b.store.GetUserAchievements(ctx, interactionUserID(i), guildID)
+	if err != nil {
+		util.LoggerFrom(ctx).Warn("⚠️ Failed to fetch achievements", "guildID", i.GuildID, "error", err)
+	} else {
+		resp += tr.T("stats.badges", formatAchievements(tr, badges))
+	}
+
 	embed := formatSimpleEmbed(title, resp)
-	sendResponseEmbed(s, i, embed, i.GuildID, "stats")
+	sendResponseEmbed(ctx, s, i, embed, i.GuildID, "stats")
 }
 
-func handleSetup(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (b *Bot) handleGuildStats(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
 	guildID := i.GuildID
+	tr := localizerFor(ctx, i)
 
-	if i.Member.Permissions&discordgo.PermissionAdministrator == 0 {
-		embed := formatSimpleEmbed("🚫 Permission Denied", "You need to be a server admin to use this command.")
-		sendResponseEmbed(s, i, embed, guildID, "setup")
+	stats, err := b.store.GetGuildStats(ctx, guildID)
+	if errors.Is(err, db.ErrNotFound) {
+		embed := formatSimpleEmbed(tr.T("guildstats.empty.title"), tr.T("guildstats.empty.desc"), 0xFEE75C)
+		sendResponseEmbed(ctx, s, i, embed, guildID, "guildstats")
+		return
+	}
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("guildstats.error.title"), tr.T("guildstats.error.desc"), guildID, "guildstats", err)
 		return
 	}
 
+	top, err := b.store.GetGuildTopMeower(ctx, guildID)
+	if err != nil {
+		util.LoggerFrom(ctx).Warn("⚠️ Failed to fetch top meower", "guildID", guildID, "error", err)
+	}
+
+	rank, totalGuilds, rankErr := b.store.GetGuildRank(ctx, guildID)
+	if rankErr != nil {
+		util.LoggerFrom(ctx).Warn("⚠️ Failed to fetch guild rank", "guildID", guildID, "error", rankErr)
+	}
+
+	embed := formatGuildStatsEmbed(tr, stats, top, rank, totalGuilds, rankErr, time.Now())
+	sendResponseEmbed(ctx, s, i, embed, guildID, "guildstats")
+}
+
+func formatGuildStatsEmbed(tr Localizer, stats *db.GuildStats, top *db.GuildTopMeower, rank, totalGuilds int, rankErr error, now time.Time) *discordgo.MessageEmbed {
+	na := tr.T("common.na")
+
+	lastUser := na
+	if stats.LastUser != nil {
+		lastUser = fmt.Sprintf("<@%s>", stats.LastUser.ID)
+	}
+
+	highScore := tr.T("highscore.none")
+	if stats.HighScore > 0 {
+		highScore = fmt.Sprintf("%d", stats.HighScore)
+		if stats.HighScoreUser != nil {
+			highScore = tr.T("guildstats.highscore.by", stats.HighScore, stats.HighScoreUser.ID)
+		}
+	}
+
+	mostActive := na
+	if top != nil && top.User != nil {
+		mostActive = tr.T("guildstats.top", top.User.ID, top.TotalMeows)
+	}
+
+	guildRank := na
+	if rankErr == nil && rank > 0 {
+		guildRank = tr.T("guildstats.rank", rank, totalGuilds)
+	}
+
+	guildAge := na
+	if stats.Guild != nil && !stats.Guild.CreatedAt.IsZero() {
+		days := int(now.Sub(stats.Guild.CreatedAt).Hours() / 24)
+		guildAge = tr.T("guildstats.age", days)
+	}
+
+	resp := tr.T(
+		"guildstats.body",
+		stats.CurrentStreak,
+		lastUser,
+		highScore,
+		guildRank,
+		stats.TotalMeows,
+		stats.SuccessfulMeows,
+		stats.FailedMeows,
+		stats.Participants,
+		mostActive,
+		guildAge,
+	)
+
+	return formatSimpleEmbed(tr.T("guildstats.title"), resp)
+}
+
+func (b *Bot) handleSetup(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	guildID := i.GuildID
+	tr := localizerFor(ctx, i)
+
 	options := i.ApplicationCommandData().Options
 	if len(options) != 1 || options[0].Name != "channel" {
-		embed := formatSimpleEmbed("⚠️ Invalid Usage", "You must provide a channel using `/setup channel:#channel-name`.", 0xffff00)
-		sendResponseEmbed(s, i, embed, guildID, "setup")
+		embed := formatSimpleEmbed(tr.T("setup.invalid.title"), tr.T("setup.invalid.desc"), 0xffff00)
+		sendResponseEmbed(ctx, s, i, embed, guildID, "setup")
 		return
 	}
 
 	channelOpt := options[0]
-	channelID := channelOpt.ChannelValue(s).ID
+	channelID := channelOpt.ChannelValue(nil).ID
 
-	err := db.UpsertGuildChannel(ctx, db.DB, guildID, channelID)
+	// Meows are only read in the guild's text and announcement channels.
+	channel, err := s.Channel(channelID)
 	if err != nil {
-		sendErrorEmbed(s, i, "❌ Failed to Set Channel", "Failed to set meow channel. Try again later.", guildID, "setup", err)
+		sendErrorEmbed(ctx, s, i, tr.T("setup.error.title"), tr.T("setup.error.desc"), guildID, "setup", err)
 		return
 	}
+	if channel.GuildID != guildID || (channel.Type != discordgo.ChannelTypeGuildText && channel.Type != discordgo.ChannelTypeGuildNews) {
+		embed := formatSimpleEmbed(tr.T("setup.invalid.title"), tr.T("setup.channel.desc", channelID), 0xffff00)
+		sendResponseEmbed(ctx, s, i, embed, guildID, "setup")
+		return
+	}
+
+	// The audit entry needs the channel being replaced, so don't change it
+	// without knowing which one that is.
+	previous, err := b.store.GetChannelForGuild(ctx, guildID)
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("setup.error.title"), tr.T("setup.error.desc"), guildID, "setup", err)
+		return
+	}
+	if err := b.store.UpsertGuildChannel(ctx, guildID, channelID); err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("setup.error.title"), tr.T("setup.error.desc"), guildID, "setup", err)
+		return
+	}
+	b.recordAudit(ctx, i, auditChannelSet, "", previous, channelID)
+
+	title := tr.T("setup.title")
+	resp := tr.T("setup.desc", channelID)
+	sendSuccessEmbed(ctx, s, i, title, resp, guildID, "setup")
+}
+
+func (b *Bot) handleLanguage(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	guildID := i.GuildID
+
+	var locale discordgo.Locale
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "locale" {
+			locale = discordgo.Locale(opt.StringValue())
+		}
+	}
+
+	supported, ok := supportedLocale(locale)
+	if !ok {
+		tr := localizerFor(ctx, i)
+		embed := formatSimpleEmbed(tr.T("setup.invalid.title"), tr.T("language.invalid.desc"), 0xffff00)
+		sendResponseEmbed(ctx, s, i, embed, guildID, "language")
+		return
+	}
+
+	// As in /setup, the audit entry needs the locale being replaced.
+	settings, err := b.store.GetGuildSettings(ctx, guildID)
+	if err == nil {
+		err = b.store.UpsertGuildLocale(ctx, guildID, string(supported))
+	}
+	if err != nil {
+		tr := localizerFor(ctx, i)
+		sendErrorEmbed(ctx, s, i, tr.T("language.error.title"), tr.T("language.error.desc"), guildID, "language", err)
+		return
+	}
+	b.recordAudit(ctx, i, auditLanguageSet, "", settings.Locale, string(supported))
 
-	title := "⚙ Setup Complete"
-	resp := fmt.Sprintf("✅ Meow channel has been set to <#%s>", channelID)
-	sendSuccessEmbed(s, i, title, resp, guildID, "setup")
+	// Confirm in the newly selected language.
+	tr := Localizer{Locale: supported}
+	sendSuccessEmbed(ctx, s, i, tr.T("language.title"), tr.T("language.desc", tr.T("language.name")), guildID, "language")
 }
 
-func handleLeaderboard(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (b *Bot) handleLeaderboard(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	tr := localizerFor(ctx, i)
+
 	// Default options
 	scope := "guild"
 	metric := "total"
 	page := 1
+	seasonParam := ""
 
 	// Parse options
 	for _, opt := range i.ApplicationCommandData().Options {
@@ -174,6 +286,8 @@ func handleLeaderboard(ctx context.Context, s *discordgo.Session, i *discordgo.I
 			metric = opt.StringValue()
 		case "page":
 			page = int(opt.IntValue())
+		case "season":
+			seasonParam = strings.ToLower(strings.TrimSpace(opt.StringValue()))
 		}
 	}
 
@@ -181,17 +295,6 @@ func handleLeaderboard(ctx context.Context, s *discordgo.Session, i *discordgo.I
 		page = 1
 	}
 
-	// Determine metric column
-	var column string
-	switch metric {
-	case "success":
-		column = "successful_meows"
-	case "fail":
-		column = "failed_meows"
-	default:
-		column = "total_meows"
-	}
-
 	// Set scope
 	var guildID *string
 	if scope == "guild" {
@@ -199,177 +302,458 @@ func handleLeaderboard(ctx context.Context, s *discordgo.Session, i *discordgo.I
 		guildID = &id
 	}
 
-	// Fetch leaderboard data from DB
-	_, totalCount, err := db.GetLeaderboard(ctx, db.DB, guildID, column, 0, 0)
+	// A season has its own scope
+	season, err := b.resolveSeason(ctx, i.GuildID, seasonParam)
 	if err != nil {
-		sendErrorEmbed(s, i, "❌ Failed to Fetch Leaderboard", "Something went wrong while retrieving leaderboard data.", i.GuildID, "leaderboard", err)
+		sendErrorEmbed(ctx, s, i, tr.T("leaderboard.season.error.title"), tr.T("leaderboard.season.error.desc"), i.GuildID, "leaderboard", err)
 		return
 	}
-
-	maxPages := (totalCount + leaderboardPageSize - 1) / leaderboardPageSize
-	if maxPages < 1 {
-		maxPages = 1
+	if season != nil {
+		scope = seasonScope(season)
+		guildID = season.GuildID
 	}
 
-	if page > maxPages {
-		page = maxPages
-	}
-
-	offset := (page - 1) * leaderboardPageSize
-	entries, _, err := db.GetLeaderboard(ctx, db.DB, guildID, column, leaderboardPageSize, offset)
+	// Fetch leaderboard data from DB; a page past the end shows the last one
+	lb, err := b.fetchLeaderboard(ctx, guildID, season, db.LeaderboardQuery{
+		Metric: leaderboardMetric(metric),
+		Limit:  leaderboardPageSize,
+		Page:   page,
+	})
 	if err != nil {
-		sendErrorEmbed(s, i, "❌ Failed to Fetch Leaderboard", "Something went wrong while retrieving leaderboard data.", i.GuildID, "leaderboard", err)
+		sendErrorEmbed(ctx, s, i, tr.T("leaderboard.error.title"), tr.T("leaderboard.error.desc"), i.GuildID, "leaderboard", err)
 		return
 	}
 
-	if len(entries) == 0 {
-		embed := formatSimpleEmbed("📉 Empty Leaderboard", "No one has meowed yet! Be the first.", 0xFEE75C)
-		sendResponseEmbed(s, i, embed, i.GuildID, "leaderboard")
+	if len(lb.Entries) == 0 {
+		embed := formatSimpleEmbed(tr.T("leaderboard.empty.title"), tr.T("leaderboard.empty.desc"), 0xFEE75C)
+		sendResponseEmbed(ctx, s, i, embed, i.GuildID, "leaderboard")
 		return
 	}
 
 	// Fetch user's rank if interaction is from a user
-	userRank, rankErr := db.GetUserRank(ctx, db.DB, i.Member.User.ID, guildID, column)
+	userRank, rankErr := b.fetchUserRank(ctx, interactionUserID(i), guildID, season, leaderboardMetric(metric))
 
 	// Format embed and buttons
-	embed := formatLeaderboardEmbed(entries, scope, metric, page, totalCount, userRank, rankErr, i.Member.User.ID)
-	components := renderLeaderboardButtons(scope, metric, page, totalCount)
+	embed := formatLeaderboardEmbed(tr, lb, scope, metric, userRank, rankErr, interactionUserID(i))
+	setSeasonTitle(tr, embed, season)
+	components := renderLeaderboardButtons(tr, scope, metric, seasonParamFor(season), lb)
 
 	// Respond with leaderboard
-	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds:     []*discordgo.MessageEmbed{embed},
-			Components: components,
-			Flags:      discordgo.MessageFlagsEphemeral,
-		},
+	err = respond(ctx, s, i, &discordgo.InteractionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: components,
+		Flags:      discordgo.MessageFlagsEphemeral,
 	})
 	if err != nil {
-		util.Cfg.Logger.Error("❌ Failed to send leaderboard response:", "error", err)
-	}
-}
-
-// UnregisterCommands unregisters slash commands with Discord
-func UnregisterCommands(sess *discordgo.Session) error {
-	cmds, _ := sess.ApplicationCommands(sess.State.User.ID, "")
-	for _, cmd := range cmds {
-		err := sess.ApplicationCommandDelete(sess.State.User.ID, "", cmd.ID)
-		if err != nil {
-			return err
-		}
-		util.Cfg.Logger.Info("✅ Unregistered command", "command", cmd.Name)
+		util.LoggerFrom(ctx).Error("❌ Failed to send leaderboard response:", "error", err)
 	}
-	return nil
 }
 
-// RegisterCommands registers slash commands with Discord
-func RegisterCommands(sess *discordgo.Session) error {
-	commands := []discordgo.ApplicationCommand{
-		{
-			Name:        "count",
-			Description: "Check the current meow count for this server",
+// NewDefaultRegistry returns the registry of every slash command Meow Bot exposes
+func (b *Bot) NewDefaultRegistry() *Registry {
+	return NewRegistry(
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "count",
+				Description: "Check the current meow count for this server",
+			},
+			Handler: b.handleCount,
+		},
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "highscore",
+				Description: "Check the highest meow streak for this server",
+			},
+			Handler: b.handleHighscore,
+		},
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "stats",
+				Description: "Check your personal meow stats",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "scope",
+						Description: "Whether to show the guild or global stats",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Guild", Value: "guild"},
+							{Name: "Global", Value: "global"},
+						},
+					},
+				},
+			},
+			Handler:  b.handleStats,
+			Cooldown: 3 * time.Second,
 		},
-		{
-			Name:        "highscore",
-			Description: "Check the highest meow streak for this server",
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "guildstats",
+				Description: "Check the meow stats for this server",
+			},
+			Handler:  b.handleGuildStats,
+			Cooldown: 3 * time.Second,
 		},
-		{
-			Name:        "stats",
-			Description: "Check your personal meow stats",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "scope",
-					Description: "Whether to show the guild or global stats",
-					Required:    false,
-					Choices: []*discordgo.ApplicationCommandOptionChoice{
-						{Name: "Guild", Value: "guild"},
-						{Name: "Global", Value: "global"},
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "setup",
+				Description: "Configure Meow Bot for this server",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionChannel,
+						Name:        "channel",
+						Description: "Channel where Meow Bot should listen for meows",
+						Required:    true,
 					},
 				},
 			},
+			Handler:    b.handleSetup,
+			Permission: discordgo.PermissionAdministrator,
 		},
-		{
-			Name:        "setup",
-			Description: "Configure Meow Bot for this server",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:        discordgo.ApplicationCommandOptionChannel,
-					Name:        "channel",
-					Description: "Channel where Meow Bot should listen for meows",
-					Required:    true,
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "language",
+				Description: "Change the language Meow Bot speaks in this server",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "locale",
+						Description: "Language Meow Bot should use",
+						Required:    true,
+						Choices:     languageChoices(),
+					},
 				},
 			},
+			Handler:    b.handleLanguage,
+			Permission: discordgo.PermissionAdministrator,
 		},
-		{
-			Name:        "leaderboard",
-			Description: "Show the top meowers",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "scope",
-					Description: "Whether to show the guild or global leaderboard",
-					Required:    false,
-					Choices: []*discordgo.ApplicationCommandOptionChoice{
-						{Name: "Guild", Value: "guild"},
-						{Name: "Global", Value: "global"},
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "timezone",
+				Description: "Show or change the timezone dates are shown in on this server",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "zone",
+						Description: "IANA timezone, e.g. Europe/Berlin or America/New_York",
+						Required:    false,
 					},
 				},
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "metric",
-					Description: "Leaderboard metric",
-					Required:    false,
-					Choices: []*discordgo.ApplicationCommandOptionChoice{
-						{Name: "Total Meows", Value: "total"},
-						{Name: "Successful Meows", Value: "success"},
-						{Name: "Failed Meows", Value: "fail"},
+			},
+			Handler:    b.handleTimezone,
+			Permission: discordgo.PermissionAdministrator,
+		},
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "leaderboard",
+				Description: "Show the top meowers",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "scope",
+						Description: "Whether to show the guild or global leaderboard",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Guild", Value: "guild"},
+							{Name: "Global", Value: "global"},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "metric",
+						Description: "Leaderboard metric",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Total Meows", Value: "total"},
+							{Name: "Successful Meows", Value: "success"},
+							{Name: "Failed Meows", Value: "fail"},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "page",
+						Description: "Page number of the leaderboard",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "season",
+						Description: "Season ID from /season list, or \"current\". Defaults to all-time",
+						Required:    false,
 					},
 				},
-				{
-					Type:        discordgo.ApplicationCommandOptionInteger,
-					Name:        "page",
-					Description: "Page number of the leaderboard",
-					Required:    false,
+			},
+			Handler:           b.handleLeaderboard,
+			ComponentPrefixes: []string{"lb_"},
+			ComponentHandler:  b.handleLeaderboardPagination,
+		},
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "season",
+				Description: "List or create leaderboard seasons",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "list",
+						Description: "List this server's seasons and the global ones",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "create",
+						Description: "Create a season for this server (requires Manage Server)",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:        discordgo.ApplicationCommandOptionString,
+								Name:        "name",
+								Description: "Name of the season",
+								Required:    true,
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionString,
+								Name:        "start",
+								Description: "First day of the season (YYYY-MM-DD, server timezone)",
+								Required:    true,
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionString,
+								Name:        "end",
+								Description: "Last day of the season (YYYY-MM-DD, server timezone)",
+								Required:    true,
+							},
+						},
+					},
 				},
 			},
+			Handler: b.handleSeason,
 		},
-	}
-
-	for _, cmd := range commands {
-		if util.Cfg.IsProd {
-			_, err := sess.ApplicationCommandCreate(sess.State.User.ID, "", &cmd)
-			if err != nil {
-				util.Cfg.Logger.Error("❌ Failed to register command", "command", cmd.Name, "error", err)
-				return err
-			}
-			util.Cfg.Logger.Info("✅ Registered command", "command", cmd.Name)
-		} else {
-			for _, guildId := range util.Cfg.Whitelist.Guilds {
-				_, err := sess.ApplicationCommandCreate(sess.State.User.ID, guildId, &cmd)
-				if err != nil {
-					util.Cfg.Logger.Error("❌ Failed to register command", "command", cmd.Name, "guildID", guildId, "error", err)
-					return err
-				}
-				util.Cfg.Logger.Info("✅ Registered command", "command", cmd.Name, "guildID", guildId)
-			}
-		}
-	}
-
-	return nil
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "balance",
+				Description: "Check your cat treats and recent transactions",
+			},
+			Handler:  b.handleBalance,
+			Cooldown: 3 * time.Second,
+		},
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "shop",
+				Description: "Browse items you can buy with cat treats",
+			},
+			Handler:  b.handleShop,
+			Cooldown: 3 * time.Second,
+		},
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "buy",
+				Description: "Buy an item from the shop",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "item",
+						Description: "Item to buy",
+						Required:    true,
+						Choices:     shopItemChoices(),
+					},
+				},
+			},
+			Handler:  b.handleBuy,
+			Cooldown: 3 * time.Second,
+		},
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "recap",
+				Description: "Configure daily or weekly recap posts",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "enable",
+						Description: "Post a recap of this server's meows every day or week",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:        discordgo.ApplicationCommandOptionString,
+								Name:        "frequency",
+								Description: "How often to post a recap",
+								Required:    true,
+								Choices: []*discordgo.ApplicationCommandOptionChoice{
+									{Name: "Daily", Value: recapDaily},
+									{Name: "Weekly", Value: recapWeekly},
+								},
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionChannel,
+								Name:        "channel",
+								Description: "Channel to post recaps in. Defaults to the meow channel",
+								Required:    false,
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionString,
+								Name:        "timezone",
+								Description: "IANA timezone periods end in, e.g. Europe/Berlin. Defaults to UTC",
+								Required:    false,
+							},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "disable",
+						Description: "Stop posting recaps",
+					},
+				},
+			},
+			Handler:    b.handleRecap,
+			Permission: discordgo.PermissionManageServer,
+		},
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "notify",
+				Description: "Manage your DM notifications",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "on",
+						Description: "Get a DM when someone passes you or a chain you built nears the record",
+						Options:     []*discordgo.ApplicationCommandOption{notifyTypeOption()},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "off",
+						Description: "Stop DM notifications",
+						Options:     []*discordgo.ApplicationCommandOption{notifyTypeOption()},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "status",
+						Description: "Show which DM notifications you get",
+					},
+				},
+			},
+			Handler:  b.handleNotify,
+			Cooldown: 3 * time.Second,
+		},
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "privacy",
+				Description: "See or delete what Meow Bot stores about you",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "export",
+						Description: "Get a DM with everything Meow Bot stores about you",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Name:        "delete",
+						Description: "Delete everything Meow Bot stores about you",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:        discordgo.ApplicationCommandOptionBoolean,
+								Name:        "confirm",
+								Description: "Set to true to delete your data. This can't be undone",
+								Required:    true,
+							},
+						},
+					},
+				},
+			},
+			Handler:  b.handlePrivacy,
+			Cooldown: time.Minute,
+		},
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "meowban",
+				Description: "Ban a user from the meow game",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "user",
+						Description: "User to ban",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "duration",
+						Description: "How long, e.g. 30m, 12h, 7d or 2w. Defaults to permanent",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "reason",
+						Description: "Why the user is banned",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "delete",
+						Description: "Delete the user's messages in the meow channel instead of ignoring them",
+						Required:    false,
+					},
+				},
+			},
+			Handler:    b.handleMeowBan,
+			Permission: discordgo.PermissionModerateMembers,
+		},
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "meowunban",
+				Description: "Let a banned user play the meow game again",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "user",
+						Description: "User to unban",
+						Required:    true,
+					},
+				},
+			},
+			Handler:    b.handleMeowUnban,
+			Permission: discordgo.PermissionModerateMembers,
+		},
+		&Command{
+			Definition: &discordgo.ApplicationCommand{
+				Name:        "audit",
+				Description: "Show recent admin actions in this server",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "actor",
+						Description: "Only show actions by this user",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "since",
+						Description: "First day to show (YYYY-MM-DD, server timezone)",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "until",
+						Description: "Last day to show (YYYY-MM-DD, server timezone)",
+						Required:    false,
+					},
+				},
+			},
+			Handler:    b.handleAudit,
+			Permission: discordgo.PermissionAdministrator,
+		},
+	)
 }
 
-func renderLeaderboardButtons(scope string, metric string, page, total int) []discordgo.MessageComponent {
-	totalPages := (total + leaderboardPageSize - 1) / leaderboardPageSize
+// renderLeaderboardButtons renders the pagination buttons. Their CustomIDs
+// have the form "<action>:<page>:<scope>:<metric>:<season>[:<cursor>]"; the
+// previous and next buttons carry a cursor so that paging keeps its place
+// while players move up or down.
+func renderLeaderboardButtons(tr Localizer, scope string, metric string, season string, lb *db.LeaderboardPage) []discordgo.MessageComponent {
+	page, totalPages := leaderboardPages(lb)
 
 	if totalPages <= 1 {
 		return nil
 	}
 
-	firstDisabled := page == 1
-	prevDisabled := page <= 1
-	nextDisabled := page >= totalPages
-	lastDisabled := page == totalPages
+	firstDisabled := lb.Prev == nil
+	prevDisabled := lb.Prev == nil
+	nextDisabled := lb.Next == nil
+	lastDisabled := lb.Next == nil
 
 	firstStyle := discordgo.PrimaryButton
 	prevStyle := discordgo.PrimaryButton
@@ -393,27 +777,27 @@ func renderLeaderboardButtons(scope string, metric string, page, total int) []di
 		discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
 				discordgo.Button{
-					Label:    "⏮️ First",
+					Label:    tr.T("leaderboard.button.first"),
 					Style:    firstStyle,
-					CustomID: fmt.Sprintf("lb_goto:1:%s:%s", scope, metric),
+					CustomID: fmt.Sprintf("lb_goto:1:%s:%s:%s", scope, metric, season),
 					Disabled: firstDisabled,
 				},
 				discordgo.Button{
-					Label:    "◀️ Prev",
+					Label:    tr.T("leaderboard.button.prev"),
 					Style:    prevStyle,
-					CustomID: fmt.Sprintf("lb_prev:%d:%s:%s", page, scope, metric),
+					CustomID: fmt.Sprintf("lb_prev:%d:%s:%s:%s:%s", page, scope, metric, season, formatLeaderboardCursor(lb.Prev)),
 					Disabled: prevDisabled,
 				},
 				discordgo.Button{
-					Label:    "Next ▶️",
+					Label:    tr.T("leaderboard.button.next"),
 					Style:    nextStyle,
-					CustomID: fmt.Sprintf("lb_next:%d:%s:%s", page, scope, metric),
+					CustomID: fmt.Sprintf("lb_next:%d:%s:%s:%s:%s", page, scope, metric, season, formatLeaderboardCursor(lb.Next)),
 					Disabled: nextDisabled,
 				},
 				discordgo.Button{
-					Label:    "Last ⏭️",
+					Label:    tr.T("leaderboard.button.last"),
 					Style:    lastStyle,
-					CustomID: fmt.Sprintf("lb_goto:%d:%s:%s", totalPages, scope, metric),
+					CustomID: fmt.Sprintf("lb_goto:%d:%s:%s:%s", totalPages, scope, metric, season),
 					Disabled: lastDisabled,
 				},
 			},
@@ -421,30 +805,58 @@ func renderLeaderboardButtons(scope string, metric string, page, total int) []di
 	}
 }
 
-func formatLeaderboardEmbed(entries []db.LeaderboardEntry, scope, metric string, page, total int, userRank int, rankErr error, currentUserID string) *discordgo.MessageEmbed {
+// leaderboardPages returns the number of lb's page and how many pages the
+// leaderboard has.
+func leaderboardPages(lb *db.LeaderboardPage) (page, totalPages int) {
+	page = (lb.Start-1)/leaderboardPageSize + 1
+	totalPages = (lb.Total + leaderboardPageSize - 1) / leaderboardPageSize
+	return page, totalPages
+}
+
+// formatLeaderboardCursor encodes a cursor for a button's CustomID as
+// "<value>.<userID>", or "" for none.
+func formatLeaderboardCursor(c *db.LeaderboardCursor) string {
+	if c == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d.%s", c.Value, c.UserID)
+}
+
+// parseLeaderboardCursor decodes a cursor encoded by formatLeaderboardCursor.
+func parseLeaderboardCursor(s string) (*db.LeaderboardCursor, bool) {
+	value, userID, ok := strings.Cut(s, ".")
+	if !ok || userID == "" {
+		return nil, false
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, false
+	}
+	return &db.LeaderboardCursor{Value: v, UserID: userID}, true
+}
+
+func formatLeaderboardEmbed(tr Localizer, lb *db.LeaderboardPage, scope, metric string, userRank int, rankErr error, currentUserID string) *discordgo.MessageEmbed {
 	var sb strings.Builder
-	startRank := (page-1)*leaderboardPageSize + 1
 
-	for i, entry := range entries {
-		rank := startRank + i
+	for _, entry := range lb.Entries {
 		count := getCountByMetric(entry, metric)
 
 		// Highlight current user
-		line := fmt.Sprintf("**%2d.** <@%s> — %d\n", rank, entry.User.ID, count)
+		line := fmt.Sprintf("**%2d.** <@%s> — %d\n", entry.Rank, entry.User.ID, count)
 		if entry.User.ID == currentUserID {
-			line = fmt.Sprintf("**%2d.** 👑 <@%s> — %d\n", rank, entry.User.ID, count)
+			line = fmt.Sprintf("**%2d.** 👑 <@%s> — %d\n", entry.Rank, entry.User.ID, count)
 		}
 
 		sb.WriteString(line)
 	}
 
-	title := buildTitle(scope, metric)
-	start := (page-1)*leaderboardPageSize + 1
-	end := start + len(entries) - 1
+	title := buildTitle(tr, scope, metric)
+	page, _ := leaderboardPages(lb)
+	end := lb.Start + len(lb.Entries) - 1
 
-	footerText := fmt.Sprintf("📄 Page %d — Showing ranks %d–%d of %d", page, start, end, total)
+	footerText := tr.T("leaderboard.footer", page, lb.Start, end, lb.Total)
 	if rankErr == nil {
-		footerText += fmt.Sprintf(" | Your Rank: #%d", userRank)
+		footerText += tr.T("leaderboard.footer.rank", userRank)
 	}
 
 	// Set color based on metric
@@ -483,35 +895,44 @@ func formatSimpleEmbed(title, description string, color ...int) *discordgo.Messa
 }
 
 func sendErrorEmbed(
-	s *discordgo.Session,
+	ctx context.Context,
+	s Discord,
 	i *discordgo.InteractionCreate,
 	title, message string,
 	guildID string,
 	context string,
 	err error,
 ) {
-	util.Cfg.Logger.Error(title, "guildID", guildID, "error", err)
-	embed := formatSimpleEmbed(title, fmt.Sprintf("%s\n\n```%s```", message, err.Error()), 0xED4245) // red
-	sendResponseEmbed(s, i, embed, guildID, context)
+	kind := classifyError(err)
+	incident := incidentID(ctx)
+	util.LoggerFrom(ctx).Error(title, "guildID", guildID, "incidentID", incident, "kind", kind, "error", err)
+
+	tr := localizerFor(ctx, i)
+	embed := formatSimpleEmbed(title, userErrorMessage(tr, message, kind, incident), 0xED4245) // red
+	sendResponseEmbed(ctx, s, i, embed, guildID, context)
 }
 
 func sendSuccessEmbed(
-	s *discordgo.Session,
+	ctx context.Context,
+	s Discord,
 	i *discordgo.InteractionCreate,
 	title, message string,
 	guildID string,
 	context string,
 ) {
 	embed := formatSimpleEmbed(title, message, 0x57F287) // green
-	sendResponseEmbed(s, i, embed, guildID, context)
+	sendResponseEmbed(ctx, s, i, embed, guildID, context)
 }
 
-func ComponentHandler(ctx context.Context) func(*discordgo.Session, *discordgo.InteractionCreate) {
-	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-		if i.Type == discordgo.InteractionMessageComponent {
-			handleLeaderboardPagination(ctx, s, i)
-			return
-		}
+// leaderboardMetric is the db metric for a leaderboard's metric option.
+func leaderboardMetric(metric string) db.Metric {
+	switch metric {
+	case "success":
+		return db.MetricSuccessful
+	case "fail":
+		return db.MetricFailed
+	default:
+		return db.MetricTotal
 	}
 }
 
@@ -526,33 +947,33 @@ func getCountByMetric(e db.LeaderboardEntry, metric string) int {
 	}
 }
 
-func buildTitle(scope, metric string) string {
+func buildTitle(tr Localizer, scope, metric string) string {
 	var scopeLabel, metricLabel string
 
 	// Scope context
 	if scope == "global" {
-		scopeLabel = "Global Leaderboard 🌐"
+		scopeLabel = tr.T("leaderboard.scope.global")
 	} else {
-		scopeLabel = "Guild Leaderboard 🏠"
+		scopeLabel = tr.T("leaderboard.scope.guild")
 	}
 
 	// Metric context
 	switch metric {
 	case "success":
-		metricLabel = "Most Successful Meows"
+		metricLabel = tr.T("leaderboard.metric.success")
 	case "fail":
-		metricLabel = "Most Failed Meows"
+		metricLabel = tr.T("leaderboard.metric.fail")
 	default:
-		metricLabel = "Most Total Meows"
+		metricLabel = tr.T("leaderboard.metric.total")
 	}
 
-	return fmt.Sprintf("🏆 %s — %s", metricLabel, scopeLabel)
+	return tr.T("leaderboard.title", metricLabel, scopeLabel)
 
 }
 
-func handleLeaderboardPagination(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (b *Bot) handleLeaderboardPagination(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
 	data := strings.Split(i.MessageComponentData().CustomID, ":")
-	if len(data) != 4 {
+	if len(data) < 4 || len(data) > 6 {
 		// Invalid format
 		return
 	}
@@ -561,36 +982,36 @@ func handleLeaderboardPagination(ctx context.Context, s *discordgo.Session, i *d
 	pageStr := data[1]
 	scope := data[2]
 	metric := data[3]
+	seasonParam := ""
+	if len(data) >= 5 {
+		seasonParam = data[4]
+	}
+	var cursor *db.LeaderboardCursor
+	if len(data) == 6 {
+		cursor, _ = parseLeaderboardCursor(data[5])
+	}
 
 	page, err := strconv.Atoi(pageStr)
 	if err != nil || page < 1 {
 		page = 1
 	}
 
-	// Update page number
+	// Page from the cursor, or by page number for buttons without one
+	q := db.LeaderboardQuery{Metric: leaderboardMetric(metric), Limit: leaderboardPageSize}
 	switch action {
 	case "lb_prev":
 		page--
+		q.Before = cursor
 	case "lb_next":
 		page++
+		q.After = cursor
 	case "lb_goto":
 	default:
 		return
 	}
-	if page < 1 {
-		page = 1
-	}
+	q.Page = page
 
-	// Determine metric column
-	var column string
-	switch metric {
-	case "success":
-		column = "successful_meows"
-	case "fail":
-		column = "failed_meows"
-	default:
-		column = "total_meows"
-	}
+	tr := localizerFor(ctx, i)
 
 	// Set scope
 	var guildID *string
@@ -599,31 +1020,32 @@ func handleLeaderboardPagination(ctx context.Context, s *discordgo.Session, i *d
 		guildID = &id
 	}
 
-	offset := (page - 1) * leaderboardPageSize
-
-	entries, totalCount, err := db.GetLeaderboard(ctx, db.DB, guildID, column, leaderboardPageSize, offset)
+	season, err := b.resolveSeason(ctx, i.GuildID, seasonParam)
+	if err == nil && season != nil {
+		guildID = season.GuildID
+	}
 
-	userRank, rankErr := db.GetUserRank(ctx, db.DB, i.Member.User.ID, guildID, column)
+	var lb *db.LeaderboardPage
+	if err == nil {
+		lb, err = b.fetchLeaderboard(ctx, guildID, season, q)
+	}
 
-	if err != nil || len(entries) == 0 {
-		_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseUpdateMessage,
-			Data: &discordgo.InteractionResponseData{
-				Content: "⚠️ Couldn't load that page of the leaderboard.",
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
+	if err != nil || len(lb.Entries) == 0 {
+		_ = respond(ctx, s, i, &discordgo.InteractionResponseData{
+			Content: tr.T("leaderboard.page_error"),
+			Flags:   discordgo.MessageFlagsEphemeral,
 		})
 		return
 	}
 
-	embed := formatLeaderboardEmbed(entries, scope, metric, page, totalCount, userRank, rankErr, i.Member.User.ID)
-	components := renderLeaderboardButtons(scope, metric, page, totalCount)
+	userRank, rankErr := b.fetchUserRank(ctx, interactionUserID(i), guildID, season, q.Metric)
 
-	_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseUpdateMessage,
-		Data: &discordgo.InteractionResponseData{
-			Embeds:     []*discordgo.MessageEmbed{embed},
-			Components: components,
-		},
+	embed := formatLeaderboardEmbed(tr, lb, scope, metric, userRank, rankErr, interactionUserID(i))
+	setSeasonTitle(tr, embed, season)
+	components := renderLeaderboardButtons(tr, scope, metric, seasonParam, lb)
+
+	_ = respond(ctx, s, i, &discordgo.InteractionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: components,
 	})
 }
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatGuildStatsEmbed(t *testing.T) {
+	now := time.Date(2025, 6, 11, 12, 0, 0, 0, time.UTC)
+	stats := &db.GuildStats{
+		Guild:           &db.Guild{ID: "g1", CreatedAt: now.Add(-10 * 24 * time.Hour)},
+		CurrentStreak:   3,
+		LastUser:        &db.User{ID: "u1"},
+		HighScore:       12,
+		HighScoreUser:   &db.User{ID: "u2"},
+		TotalMeows:      40,
+		SuccessfulMeows: 35,
+		FailedMeows:     5,
+		Participants:    4,
+	}
+	top := &db.GuildTopMeower{User: &db.User{ID: "u3"}, TotalMeows: 20}
+
+	embed := formatGuildStatsEmbed(Localizer{Locale: defaultLocale}, stats, top, 2, 9, nil, now)
+
+	for _, want := range []string{
+		"Current Streak: 3",
+		"Last Meower: <@u1>",
+		"High Score: 12 by <@u2>",
+		"Guild Rank: #2 of 9",
+		"Participants: 4",
+		"Most Active: <@u3> (20 meows)",
+		"Meowing Since: 10 days",
+	} {
+		if !strings.Contains(embed.Description, want) {
+			t.Errorf("embed description missing %q:\n%s", want, embed.Description)
+		}
+	}
+}
+
+func TestFormatGuildStatsEmbed_MissingData(t *testing.T) {
+	stats := &db.GuildStats{Guild: &db.Guild{ID: "g1"}}
+
+	embed := formatGuildStatsEmbed(Localizer{Locale: defaultLocale}, stats, nil, 0, 0, errors.New("boom"), time.Now())
+
+	for _, want := range []string{
+		"Last Meower: N/A",
+		"No high score yet!",
+		"Guild Rank: N/A",
+		"Most Active: N/A",
+		"Meowing Since: N/A",
+	} {
+		if !strings.Contains(embed.Description, want) {
+			t.Errorf("embed description missing %q:\n%s", want, embed.Description)
+		}
+	}
+}
+
+func TestFormatLeaderboardEmbed_SharedRanks(t *testing.T) {
+	lb := &db.LeaderboardPage{
+		Entries: []db.LeaderboardEntry{
+			{User: &db.User{ID: "u1"}, Rank: 5, TotalMeows: 9},
+			{User: &db.User{ID: "u2"}, Rank: 5, TotalMeows: 9},
+		},
+		Start: 6,
+		Total: 12,
+	}
+
+	embed := formatLeaderboardEmbed(Localizer{Locale: defaultLocale}, lb, "guild", "total", 5, nil, "u2")
+
+	for _, want := range []string{"** 5.** <@u1> — 9", "** 5.** 👑 <@u2> — 9"} {
+		if !strings.Contains(embed.Description, want) {
+			t.Errorf("embed description missing %q:\n%s", want, embed.Description)
+		}
+	}
+	if want := "📄 Page 2 — Showing ranks 6–7 of 12 | Your Rank: #5"; embed.Footer.Text != want {
+		t.Errorf("footer = %q, want %q", embed.Footer.Text, want)
+	}
+}
+
+func TestRenderLeaderboardButtons_Cursors(t *testing.T) {
+	lb := &db.LeaderboardPage{
+		Entries: []db.LeaderboardEntry{{User: &db.User{ID: "u1"}}},
+		Start:   6,
+		Total:   12,
+		Prev:    &db.LeaderboardCursor{Value: 9, UserID: "123"},
+		Next:    &db.LeaderboardCursor{Value: 4, UserID: "456"},
+	}
+
+	row := renderLeaderboardButtons(Localizer{Locale: defaultLocale}, "guild", "total", "", lb)[0].(discordgo.ActionsRow)
+	var ids []string
+	for _, c := range row.Components {
+		ids = append(ids, c.(discordgo.Button).CustomID)
+	}
+	want := []string{
+		"lb_goto:1:guild:total:",
+		"lb_prev:2:guild:total::9.123",
+		"lb_next:2:guild:total::4.456",
+		"lb_goto:3:guild:total:",
+	}
+	if strings.Join(ids, " ") != strings.Join(want, " ") {
+		t.Errorf("CustomIDs = %q, want %q", ids, want)
+	}
+
+	cursor, ok := parseLeaderboardCursor(strings.Split(ids[2], ":")[5])
+	if !ok || *cursor != *lb.Next {
+		t.Errorf("parseLeaderboardCursor = %v, %v, want %v", cursor, ok, lb.Next)
+	}
+	if _, ok := parseLeaderboardCursor(""); ok {
+		t.Error("parseLeaderboardCursor accepted an empty cursor")
+	}
+}
+
+func TestHandleSetup(t *testing.T) {
+	ctx := context.Background()
+	b := newTestBot()
+	s := &fakeDiscord{channels: map[string]*discordgo.Channel{
+		"meows": {ID: "meows", GuildID: "g1", Type: discordgo.ChannelTypeGuildText},
+		"voice": {ID: "voice", GuildID: "g1", Type: discordgo.ChannelTypeGuildVoice},
+		"other": {ID: "other", GuildID: "g2", Type: discordgo.ChannelTypeGuildText},
+	}}
+
+	tests := []struct {
+		channelID   string
+		wantDesc    string
+		wantChannel string
+	}{
+		{"meows", "<#meows>", "meows"},
+		{"voice", "isn't a text channel", "meows"},
+		{"other", "isn't a text channel", "meows"},
+		{"gone", "Failed to set meow channel", "meows"},
+	}
+	for _, tt := range tests {
+		before := len(s.Responses())
+		b.handleSetup(ctx, s, &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{Name: "setup", Options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{Name: "channel", Type: discordgo.ApplicationCommandOptionChannel, Value: tt.channelID},
+			}},
+			GuildID: "g1",
+			Member:  &discordgo.Member{User: &discordgo.User{ID: "admin"}},
+		}})
+
+		responses := s.Responses()[before:]
+		if len(responses) != 1 || len(responses[0].Data.Embeds) != 1 {
+			t.Fatalf("channel %q: responses = %+v, want one embed", tt.channelID, responses)
+		}
+		if desc := responses[0].Data.Embeds[0].Description; !strings.Contains(desc, tt.wantDesc) {
+			t.Errorf("channel %q: response %q doesn't mention %q", tt.channelID, desc, tt.wantDesc)
+		}
+		if channelID, err := b.store.GetChannelForGuild(ctx, "g1"); err != nil || channelID != tt.wantChannel {
+			t.Errorf("channel %q: GetChannelForGuild() = %q, %v, want %q", tt.channelID, channelID, err, tt.wantChannel)
+		}
+	}
+}
+
+// unreadableSettingsStore fails to read the guild's channel and settings.
+type unreadableSettingsStore struct {
+	db.Store
+}
+
+func (unreadableSettingsStore) GetChannelForGuild(context.Context, string) (string, error) {
+	return "", errors.New("connection reset")
+}
+
+func (unreadableSettingsStore) GetGuildSettings(context.Context, string) (*db.GuildSettings, error) {
+	return nil, errors.New("connection reset")
+}
+
+func TestSettingsCommands_UnreadableBefore(t *testing.T) {
+	ctx := context.Background()
+	store := db.NewMemoryStore()
+	b := NewBot(unreadableSettingsStore{store})
+	s := &fakeDiscord{channels: map[string]*discordgo.Channel{
+		"meows": {ID: "meows", GuildID: "g1", Type: discordgo.ChannelTypeGuildText},
+	}}
+	interaction := func(name string, opt *discordgo.ApplicationCommandInteractionDataOption) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			Type:    discordgo.InteractionApplicationCommand,
+			Data:    discordgo.ApplicationCommandInteractionData{Name: name, Options: []*discordgo.ApplicationCommandInteractionDataOption{opt}},
+			GuildID: "g1",
+			Member:  &discordgo.Member{User: &discordgo.User{ID: "admin"}},
+		}}
+	}
+
+	b.handleSetup(ctx, s, interaction("setup", &discordgo.ApplicationCommandInteractionDataOption{
+		Name: "channel", Type: discordgo.ApplicationCommandOptionChannel, Value: "meows",
+	}))
+	b.handleLanguage(ctx, s, interaction("language", &discordgo.ApplicationCommandInteractionDataOption{
+		Name: "locale", Type: discordgo.ApplicationCommandOptionString, Value: string(discordgo.French),
+	}))
+
+	if responses := s.Responses(); len(responses) != 2 {
+		t.Fatalf("responses = %+v, want an error for each command", responses)
+	}
+	if channelID, _ := store.GetChannelForGuild(ctx, "g1"); channelID != "" {
+		t.Errorf("channel = %q, want none set without the previous one", channelID)
+	}
+	if settings, _ := store.GetGuildSettings(ctx, "g1"); settings.Locale != "" {
+		t.Errorf("locale = %q, want none set without the previous one", settings.Locale)
+	}
+	if entries, err := store.GetAuditLog(ctx, db.AuditFilter{GuildID: "g1"}); err != nil || len(entries) != 0 {
+		t.Errorf("audit entries = %+v, %v, want none", entries, err)
+	}
+}
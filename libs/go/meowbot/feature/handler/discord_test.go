@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"errors"
+	"github.com/bwmarrin/discordgo"
+	"strconv"
+	"sync"
+)
+
+// fakeDiscord records what the handlers send to Discord instead of sending
+// it. Messages are recorded as "channelID: content", reactions as
+// "messageID emoji" and deletions by message ID. Channel looks channels up in
+// channels.
+type fakeDiscord struct {
+	mu        sync.Mutex
+	nextID    int
+	messages  []string
+	reactions []string
+	deleted   []string
+	responses []*discordgo.InteractionResponse
+	edits     []*discordgo.WebhookEdit
+	followups []*discordgo.WebhookParams
+	roles     map[string][]*discordgo.Role
+	granted   []string
+	channels  map[string]*discordgo.Channel
+}
+
+var _ Discord = (*fakeDiscord)(nil)
+
+func (f *fakeDiscord) message(channelID, content string) *discordgo.Message {
+	f.nextID++
+	f.messages = append(f.messages, channelID+": "+content)
+	return &discordgo.Message{ID: "sent" + strconv.Itoa(f.nextID), ChannelID: channelID, Content: content}
+}
+
+func (f *fakeDiscord) ChannelMessageSend(channelID string, content string, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.message(channelID, content), nil
+}
+
+func (f *fakeDiscord) ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	content := data.Content
+	for _, e := range data.Embeds {
+		content += "[" + e.Title + "]"
+	}
+	for _, file := range data.Files {
+		content += "<" + file.Name + ">"
+	}
+	return f.message(channelID, content), nil
+}
+
+func (f *fakeDiscord) ChannelMessageDelete(_, messageID string, _ ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleted = append(f.deleted, messageID)
+	return nil
+}
+
+func (f *fakeDiscord) MessageReactionAdd(_, messageID, emojiID string, _ ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reactions = append(f.reactions, messageID+" "+emojiID)
+	return nil
+}
+
+func (f *fakeDiscord) Channel(channelID string, _ ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	channel, ok := f.channels[channelID]
+	if !ok {
+		return nil, errors.New("unknown channel " + channelID)
+	}
+	return channel, nil
+}
+
+func (f *fakeDiscord) UserChannelCreate(recipientID string, _ ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	return &discordgo.Channel{ID: "dm-" + recipientID, Type: discordgo.ChannelTypeDM}, nil
+}
+
+func (f *fakeDiscord) InteractionRespond(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses = append(f.responses, resp)
+	return nil
+}
+
+func (f *fakeDiscord) InteractionResponseEdit(_ *discordgo.Interaction, newresp *discordgo.WebhookEdit, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.edits = append(f.edits, newresp)
+	return &discordgo.Message{}, nil
+}
+
+func (f *fakeDiscord) FollowupMessageCreate(_ *discordgo.Interaction, _ bool, data *discordgo.WebhookParams, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.followups = append(f.followups, data)
+	return &discordgo.Message{}, nil
+}
+
+func (f *fakeDiscord) GuildRoles(guildID string, _ ...discordgo.RequestOption) ([]*discordgo.Role, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.roles[guildID], nil
+}
+
+func (f *fakeDiscord) GuildRoleCreate(guildID string, data *discordgo.RoleParams, _ ...discordgo.RequestOption) (*discordgo.Role, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.roles == nil {
+		f.roles = make(map[string][]*discordgo.Role)
+	}
+	role := &discordgo.Role{ID: "role" + strconv.Itoa(len(f.roles[guildID])+1), Name: data.Name}
+	if data.Permissions != nil {
+		role.Permissions = *data.Permissions
+	}
+	f.roles[guildID] = append(f.roles[guildID], role)
+	return role, nil
+}
+
+func (f *fakeDiscord) GuildMemberRoleAdd(guildID, userID, roleID string, _ ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.granted = append(f.granted, guildID+" "+userID+" "+roleID)
+	return nil
+}
+
+// Messages returns the messages sent so far.
+func (f *fakeDiscord) Messages() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.messages...)
+}
+
+// Reactions returns the reactions added so far.
+func (f *fakeDiscord) Reactions() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.reactions...)
+}
+
+// Responses returns the initial interaction responses sent so far.
+func (f *fakeDiscord) Responses() []*discordgo.InteractionResponse {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*discordgo.InteractionResponse(nil), f.responses...)
+}
+
+// Edits returns the edits of the original interaction response made so far.
+func (f *fakeDiscord) Edits() []*discordgo.WebhookEdit {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*discordgo.WebhookEdit(nil), f.edits...)
+}
+
+// Followups returns the follow-up messages sent so far.
+func (f *fakeDiscord) Followups() []*discordgo.WebhookParams {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*discordgo.WebhookParams(nil), f.followups...)
+}
+
+// Deleted returns the IDs of the messages deleted so far.
+func (f *fakeDiscord) Deleted() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.deleted...)
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"libs/go/meowbot/util"
+	"net/http"
+)
+
+// errorKind is the user-facing classification of a failure.
+type errorKind string
+
+const (
+	errorKindInternal     errorKind = "internal"
+	errorKindNotFound     errorKind = "not_found"
+	errorKindUnavailable  errorKind = "unavailable"
+	errorKindInvalidInput errorKind = "invalid_input"
+)
+
+// classifyError decides what kind of failure err is, from the db package's
+// error kinds or the status of a failed Discord REST call.
+func classifyError(err error) errorKind {
+	var restErr *discordgo.RESTError
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		return errorKindNotFound
+	case errors.Is(err, db.ErrUnavailable), errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return errorKindUnavailable
+	case errors.Is(err, db.ErrInvalidInput):
+		return errorKindInvalidInput
+	case errors.As(err, &restErr) && restErr.Response != nil:
+		switch status := restErr.Response.StatusCode; {
+		case status == http.StatusNotFound:
+			return errorKindNotFound
+		case status == http.StatusBadRequest:
+			return errorKindInvalidInput
+		case status >= http.StatusInternalServerError:
+			return errorKindUnavailable
+		}
+	}
+	return errorKindInternal
+}
+
+// incidentID returns the ID users can quote when reporting a failure. It is
+// the event's correlation ID, so every log line for the event shares it.
+func incidentID(ctx context.Context) string {
+	if id := util.CorrelationID(ctx); id != "" {
+		return id
+	}
+	return util.NewCorrelationID()
+}
+
+// userErrorMessage builds the text shown to users for a failure: the
+// command's own message, an explanation of the failure kind, and the
+// incident ID. The raw error is never included.
+func userErrorMessage(tr Localizer, message string, kind errorKind, incident string) string {
+	desc := message
+	if kind != errorKindInternal {
+		desc += "\n\n" + tr.T("error.kind."+string(kind))
+	}
+	return desc + "\n\n" + tr.T("error.incident", incident)
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"libs/go/meowbot/feature/db"
+	"libs/go/meowbot/util"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestClassifyError(t *testing.T) {
+	restErr := func(status int) error {
+		return &discordgo.RESTError{Response: &http.Response{StatusCode: status}}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want errorKind
+	}{
+		{"db not found", fmt.Errorf("%w: no rows", db.ErrNotFound), errorKindNotFound},
+		{"db unavailable", fmt.Errorf("%w: timeout", db.ErrUnavailable), errorKindUnavailable},
+		{"db invalid input", fmt.Errorf("%w: bad column", db.ErrInvalidInput), errorKindInvalidInput},
+		{"context deadline", context.DeadlineExceeded, errorKindUnavailable},
+		{"discord 404", restErr(http.StatusNotFound), errorKindNotFound},
+		{"discord 400", restErr(http.StatusBadRequest), errorKindInvalidInput},
+		{"discord 502", restErr(http.StatusBadGateway), errorKindUnavailable},
+		{"discord 403", restErr(http.StatusForbidden), errorKindInternal},
+		{"unknown", errors.New("boom"), errorKindInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyError(tt.err); got != tt.want {
+				t.Errorf("classifyError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncidentIDUsesCorrelationID(t *testing.T) {
+	ctx := util.WithCorrelationID(context.Background(), "abc123")
+	if got := incidentID(ctx); got != "abc123" {
+		t.Errorf("incidentID() = %q, want correlation ID", got)
+	}
+	if got := incidentID(context.Background()); got == "" {
+		t.Error("incidentID() without a correlation ID should generate one")
+	}
+}
+
+func TestUserErrorMessage(t *testing.T) {
+	tr := Localizer{Locale: defaultLocale}
+
+	msg := userErrorMessage(tr, "Couldn't load stats.", errorKindUnavailable, "abc123")
+	for _, want := range []string{"Couldn't load stats.", tr.T("error.kind.unavailable"), "abc123"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("message %q missing %q", msg, want)
+		}
+	}
+
+	msg = userErrorMessage(tr, "Couldn't load stats.", errorKindInternal, "abc123")
+	if strings.Contains(msg, "error.kind") {
+		t.Errorf("internal errors should not get a kind hint, got %q", msg)
+	}
+}
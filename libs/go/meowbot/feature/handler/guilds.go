@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"libs/go/meowbot/util"
+	"time"
+)
+
+// guildFromDiscord is the guild g as the db stores it. Guild updates leave
+// out the member count and join date, which then stay as stored.
+func guildFromDiscord(g *discordgo.Guild) db.Guild {
+	guild := db.Guild{ID: g.ID, Name: g.Name, Icon: g.Icon, MemberCount: g.MemberCount, OwnerID: g.OwnerID}
+	if !g.JoinedAt.IsZero() {
+		joined := g.JoinedAt
+		guild.JoinedAt = &joined
+	}
+	return guild
+}
+
+// syncGuild stores the guild's details and marks the bot as present in it.
+func (b *Bot) syncGuild(ctx context.Context, g *discordgo.Guild) {
+	if err := b.store.SyncGuild(ctx, guildFromDiscord(g)); err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to sync guild", "guildID", g.ID, "error", err)
+		return
+	}
+	util.LoggerFrom(ctx).Debug("🏠 Guild synced", "guildID", g.ID, "name", g.Name)
+}
+
+// ForgetGuild drops a guild's in-memory state after its data was replaced
+// elsewhere, e.g. by an import, so it is reloaded from the store.
+func (b *Bot) ForgetGuild(guildID string) {
+	b.guilds.Forget(guildID)
+}
+
+// GuildCreateHandler stores the details of each guild the bot is in, sent
+// when it connects and when it joins a guild.
+func (b *Bot) GuildCreateHandler(ctx context.Context) func(*discordgo.Session, *discordgo.GuildCreate) {
+	h := Chain(b.handleGuildCreate,
+		WithCorrelationID[*discordgo.GuildCreate](),
+		WithRecovery(guildCreateName, nil),
+		WithTiming(guildCreateName),
+	)
+	return func(s *discordgo.Session, e *discordgo.GuildCreate) {
+		h(ctx, s, e)
+	}
+}
+
+func (b *Bot) handleGuildCreate(ctx context.Context, _ Discord, e *discordgo.GuildCreate) {
+	// An unavailable guild is in an outage and comes without its details.
+	if e.Guild == nil || e.Unavailable {
+		return
+	}
+	b.syncGuild(ctx, e.Guild)
+}
+
+// GuildUpdateHandler keeps a guild's name, icon and owner current.
+func (b *Bot) GuildUpdateHandler(ctx context.Context) func(*discordgo.Session, *discordgo.GuildUpdate) {
+	h := Chain(b.handleGuildUpdate,
+		WithCorrelationID[*discordgo.GuildUpdate](),
+		WithRecovery(guildUpdateName, nil),
+		WithTiming(guildUpdateName),
+	)
+	return func(s *discordgo.Session, e *discordgo.GuildUpdate) {
+		h(ctx, s, e)
+	}
+}
+
+func (b *Bot) handleGuildUpdate(ctx context.Context, _ Discord, e *discordgo.GuildUpdate) {
+	if e.Guild == nil || e.Unavailable {
+		return
+	}
+	b.syncGuild(ctx, e.Guild)
+}
+
+// GuildDeleteHandler records that the bot left a guild, or was removed from
+// it. The guild's stats are kept.
+func (b *Bot) GuildDeleteHandler(ctx context.Context) func(*discordgo.Session, *discordgo.GuildDelete) {
+	h := Chain(b.handleGuildDelete,
+		WithCorrelationID[*discordgo.GuildDelete](),
+		WithRecovery(guildDeleteName, nil),
+		WithTiming(guildDeleteName),
+	)
+	return func(s *discordgo.Session, e *discordgo.GuildDelete) {
+		h(ctx, s, e)
+	}
+}
+
+func (b *Bot) handleGuildDelete(ctx context.Context, _ Discord, e *discordgo.GuildDelete) {
+	// Unavailable means an outage; the bot is still in the guild.
+	if e.Guild == nil || e.Unavailable {
+		return
+	}
+	if err := b.store.LeaveGuild(ctx, e.ID, time.Now()); err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to record leaving guild", "guildID", e.ID, "error", err)
+		return
+	}
+	util.LoggerFrom(ctx).Info("👋 Left guild", "guildID", e.ID)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"github.com/bwmarrin/discordgo"
+	"testing"
+	"time"
+)
+
+func TestGuildEvents(t *testing.T) {
+	ctx := context.Background()
+	b := newTestBot()
+	joined := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	b.handleGuildCreate(ctx, nil, &discordgo.GuildCreate{Guild: &discordgo.Guild{
+		ID: "g1", Name: "Cats", Icon: "i1", MemberCount: 42, OwnerID: "u1", JoinedAt: joined,
+	}})
+	b.handleGuildCreate(ctx, nil, &discordgo.GuildCreate{Guild: &discordgo.Guild{ID: "g2", Unavailable: true}})
+	b.handleGuildUpdate(ctx, nil, &discordgo.GuildUpdate{Guild: &discordgo.Guild{ID: "g1", Name: "More Cats", Icon: "i2", OwnerID: "u1"}})
+
+	g, err := b.store.GetGuild(ctx, "g1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Name != "More Cats" || g.Icon != "i2" || g.MemberCount != 42 || g.JoinedAt == nil || !g.JoinedAt.Equal(joined) || !g.Present {
+		t.Errorf("guild after update = %+v", g)
+	}
+	if _, err := b.store.GetGuild(ctx, "g2"); err == nil {
+		t.Error("unavailable guild was stored")
+	}
+
+	// An outage doesn't count as leaving.
+	b.handleGuildDelete(ctx, nil, &discordgo.GuildDelete{Guild: &discordgo.Guild{ID: "g1", Unavailable: true}})
+	if g, _ := b.store.GetGuild(ctx, "g1"); !g.Present {
+		t.Error("guild marked as left during an outage")
+	}
+	b.handleGuildDelete(ctx, nil, &discordgo.GuildDelete{Guild: &discordgo.Guild{ID: "g1"}})
+	if g, _ := b.store.GetGuild(ctx, "g1"); g.Present || g.LeftAt == nil {
+		t.Errorf("guild after delete = %+v, want left", g)
+	}
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/util"
+	"strings"
+	"time"
+)
+
+// Localizer renders catalog messages in a single locale.
+type Localizer struct {
+	Locale discordgo.Locale
+}
+
+// T formats the message for key in the localizer's locale, falling back to
+// the default locale and finally to the key itself.
+func (l Localizer) T(key string, args ...any) string {
+	format, ok := catalog[l.Locale][key]
+	if !ok {
+		format, ok = catalog[defaultLocale][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Ago formats how long ago something happened, e.g. "5m ago", using the
+// largest whole unit.
+func (l Localizer) Ago(d time.Duration) string {
+	var amount string
+	switch {
+	case d >= 24*time.Hour:
+		amount = l.T("time.days", int(d/(24*time.Hour)))
+	case d >= time.Hour:
+		amount = l.T("time.hours", int(d/time.Hour))
+	case d >= time.Minute:
+		amount = l.T("time.minutes", int(d/time.Minute))
+	default:
+		amount = l.T("time.seconds", int(d/time.Second))
+	}
+	return l.T("time.ago", amount)
+}
+
+// supportedLocale maps a Discord locale onto one the catalog has, matching on
+// the language when there is no exact match (e.g. es-419 -> es-ES).
+func supportedLocale(locale discordgo.Locale) (discordgo.Locale, bool) {
+	if locale == "" {
+		return "", false
+	}
+	if _, ok := catalog[locale]; ok {
+		return locale, true
+	}
+	lang, _, _ := strings.Cut(string(locale), "-")
+	for candidate := range catalog {
+		candidateLang, _, _ := strings.Cut(string(candidate), "-")
+		if candidateLang == lang {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// guildLocale returns the locale configured for the guild, if any.
+func (b *Bot) guildLocale(ctx context.Context, guildID string) (discordgo.Locale, bool) {
+	if guildID == "" {
+		return "", false
+	}
+	settings, err := b.store.GetGuildSettings(ctx, guildID)
+	if err != nil {
+		util.LoggerFrom(ctx).Warn("⚠️ Failed to fetch guild settings", "guildID", guildID, "error", err)
+		return "", false
+	}
+	return supportedLocale(discordgo.Locale(settings.Locale))
+}
+
+// localizerForGuild picks the guild's configured locale, or the default.
+func (b *Bot) localizerForGuild(ctx context.Context, guildID string) Localizer {
+	if locale, ok := b.guildLocale(ctx, guildID); ok {
+		return Localizer{Locale: locale}
+	}
+	return Localizer{Locale: defaultLocale}
+}
+
+type localizerKey struct{}
+
+// withLocalizer picks the interaction's locale once and attaches it to the
+// context for localizerFor: the guild's configured locale, falling back to the
+// locale of the user who triggered the interaction, then the default.
+func (b *Bot) withLocalizer() Middleware[*discordgo.InteractionCreate] {
+	return func(next CommandFunc) CommandFunc {
+		return func(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+			tr := userLocalizer(i)
+			if locale, ok := b.guildLocale(ctx, i.GuildID); ok {
+				tr = Localizer{Locale: locale}
+			}
+			next(context.WithValue(ctx, localizerKey{}, tr), s, i)
+		}
+	}
+}
+
+// localizerFor returns the localizer withLocalizer picked for the interaction,
+// or the user's locale if there is none.
+func localizerFor(ctx context.Context, i *discordgo.InteractionCreate) Localizer {
+	if tr, ok := ctx.Value(localizerKey{}).(Localizer); ok {
+		return tr
+	}
+	return userLocalizer(i)
+}
+
+// userLocalizer picks the locale of the user who triggered the interaction,
+// or the default.
+func userLocalizer(i *discordgo.InteractionCreate) Localizer {
+	if locale, ok := supportedLocale(i.Locale); ok {
+		return Localizer{Locale: locale}
+	}
+	return Localizer{Locale: defaultLocale}
+}
+
+// localizeCommand fills in NameLocalizations and DescriptionLocalizations for
+// a command, its options and their choices from the "cmd." catalog keys.
+func localizeCommand(cmd *discordgo.ApplicationCommand) {
+	prefix := "cmd." + cmd.Name
+	if names := localizations(prefix + ".name"); len(names) > 0 {
+		cmd.NameLocalizations = &names
+	}
+	if descs := localizations(prefix + ".description"); len(descs) > 0 {
+		cmd.DescriptionLocalizations = &descs
+	}
+
+	localizeOptions(prefix, cmd.Options)
+}
+
+// localizeOptions localizes command options under prefix, recursing into
+// subcommands so their options use keys like cmd.<name>.opt.<sub>.opt.<o>.
+func localizeOptions(prefix string, opts []*discordgo.ApplicationCommandOption) {
+	for _, opt := range opts {
+		optPrefix := prefix + ".opt." + opt.Name
+		if names := localizations(optPrefix + ".name"); len(names) > 0 {
+			opt.NameLocalizations = names
+		}
+		if descs := localizations(optPrefix + ".description"); len(descs) > 0 {
+			opt.DescriptionLocalizations = descs
+		}
+		for _, choice := range opt.Choices {
+			if names := localizations(fmt.Sprintf("%s.choice.%v", optPrefix, choice.Value)); len(names) > 0 {
+				choice.NameLocalizations = names
+			}
+		}
+		localizeOptions(optPrefix, opt.Options)
+	}
+}
+
+// localizations collects the translations of key from every non-default locale.
+func localizations(key string) map[discordgo.Locale]string {
+	out := make(map[discordgo.Locale]string)
+	for locale, messages := range catalog {
+		if locale == defaultLocale {
+			continue
+		}
+		if msg, ok := messages[key]; ok {
+			out[locale] = msg
+		}
+	}
+	return out
+}
+
+// languageChoices lists the supported locales as command option choices.
+func languageChoices() []*discordgo.ApplicationCommandOptionChoice {
+	locales := []discordgo.Locale{discordgo.EnglishUS, discordgo.SpanishES, discordgo.French, discordgo.German}
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(locales))
+	for _, locale := range locales {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  Localizer{Locale: locale}.T("language.name"),
+			Value: string(locale),
+		})
+	}
+	return choices
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocalizerT(t *testing.T) {
+	en := Localizer{Locale: discordgo.EnglishUS}
+	es := Localizer{Locale: discordgo.SpanishES}
+
+	if got := en.T("count.desc", 3); got != "Current meow count: **3**" {
+		t.Errorf("en count.desc = %q", got)
+	}
+	if got := es.T("count.desc", 3); got != "Maullidos actuales: **3**" {
+		t.Errorf("es count.desc = %q", got)
+	}
+	if got := (Localizer{Locale: discordgo.Japanese}).T("meow.repeat"); got != "😾 You can't meow twice in a row!" {
+		t.Errorf("unsupported locale should fall back to English, got %q", got)
+	}
+	if got := en.T("missing.key"); got != "missing.key" {
+		t.Errorf("missing key should render as the key, got %q", got)
+	}
+}
+
+func TestLocalizerAgo(t *testing.T) {
+	tests := []struct {
+		locale discordgo.Locale
+		d      time.Duration
+		want   string
+	}{
+		{discordgo.EnglishUS, 42 * time.Second, "42s ago"},
+		{discordgo.EnglishUS, 5*time.Minute + 30*time.Second, "5m ago"},
+		{discordgo.EnglishUS, 3 * time.Hour, "3h ago"},
+		{discordgo.EnglishUS, 50 * time.Hour, "2d ago"},
+		{discordgo.SpanishES, 5 * time.Minute, "hace 5min"},
+		{discordgo.French, 2 * time.Hour, "il y a 2h"},
+		{discordgo.German, 3 * 24 * time.Hour, "vor 3T"},
+	}
+
+	for _, tt := range tests {
+		if got := (Localizer{Locale: tt.locale}).Ago(tt.d); got != tt.want {
+			t.Errorf("%s Ago(%s) = %q, want %q", tt.locale, tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestSupportedLocale(t *testing.T) {
+	tests := []struct {
+		in   discordgo.Locale
+		want discordgo.Locale
+		ok   bool
+	}{
+		{discordgo.EnglishUS, discordgo.EnglishUS, true},
+		{discordgo.EnglishGB, discordgo.EnglishUS, true},
+		{discordgo.SpanishLATAM, discordgo.SpanishES, true},
+		{discordgo.French, discordgo.French, true},
+		{discordgo.Japanese, "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := supportedLocale(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("supportedLocale(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCatalogsHaveEveryKey(t *testing.T) {
+	for locale, messages := range catalog {
+		if locale == defaultLocale {
+			continue
+		}
+		for key := range catalog[defaultLocale] {
+			if _, ok := messages[key]; !ok {
+				t.Errorf("locale %s is missing key %q", locale, key)
+			}
+		}
+	}
+}
+
+func TestLocalizeCommand(t *testing.T) {
+	for _, cmd := range newTestBot().NewDefaultRegistry().Commands() {
+		def := cmd.Definition
+		if def.DescriptionLocalizations == nil || (*def.DescriptionLocalizations)[discordgo.French] == "" {
+			t.Errorf("command %q has no French description", def.Name)
+		}
+		if def.NameLocalizations != nil {
+			for locale, name := range *def.NameLocalizations {
+				if name != strings.ToLower(name) || strings.Contains(name, " ") {
+					t.Errorf("command %q has invalid %s name %q", def.Name, locale, name)
+				}
+			}
+		}
+		for _, opt := range def.Options {
+			if opt.DescriptionLocalizations[discordgo.German] == "" {
+				t.Errorf("option %s.%s has no German description", def.Name, opt.Name)
+			}
+			for _, choice := range opt.Choices {
+				if choice.NameLocalizations[discordgo.SpanishES] == "" && def.Name != "language" {
+					t.Errorf("choice %s.%s=%v has no Spanish name", def.Name, opt.Name, choice.Value)
+				}
+			}
+		}
+	}
+}
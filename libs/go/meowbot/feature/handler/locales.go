@@ -0,0 +1,1282 @@
+package handler
+
+import "github.com/bwmarrin/discordgo"
+
+// defaultLocale is used when neither the guild nor the interaction specify a
+// supported locale, and for any key missing from another locale's catalog.
+const defaultLocale = discordgo.EnglishUS
+
+// catalog holds every user-facing string, keyed by locale and message key.
+// Values are fmt format strings. Keys prefixed with "cmd." localize command
+// definitions: cmd.<command>.name, cmd.<command>.description,
+// cmd.<command>.opt.<option>.description and
+// cmd.<command>.opt.<option>.choice.<value>.
+var catalog = map[discordgo.Locale]map[string]string{
+	discordgo.EnglishUS: {
+		"language.name": "English",
+
+		"meow.repeat":    "😾 You can't meow twice in a row!",
+		"meow.highscore": "🏆 New high score: %d meows by %s!",
+		"meow.count":     "%s **meow** x%d!",
+		"meow.reset":     "❌ No meow? Resetting.",
+
+		"common.na": "N/A",
+
+		"time.ago":     "%s ago",
+		"time.seconds": "%ds",
+		"time.minutes": "%dm",
+		"time.hours":   "%dh",
+		"time.days":    "%dd",
+		"time.at":      "%s (%s)",
+
+		"error.permission.title": "🚫 Permission Denied",
+		"error.permission.desc":  "You don't have permission to use this command.",
+		"error.cooldown.title":   "⏳ Slow Down",
+		"error.cooldown.desc":    "You can use `/%s` again in %s.",
+		"error.panic.title":      "💥 Something Went Wrong",
+		"error.panic.desc":       "Meow Bot tripped over its own tail. Please try again in a moment.",
+
+		"error.kind.not_found":     "We couldn't find what you were looking for.",
+		"error.kind.unavailable":   "The meow database is taking a cat nap. Please try again shortly.",
+		"error.kind.invalid_input": "That request doesn't look right. Check the options and try again.",
+		"error.incident":           "🆔 Incident ID: `%s`",
+
+		"count.title": "📈 Meow Count",
+		"count.desc":  "Current meow count: **%d**",
+
+		"highscore.title": "🏆 High Score",
+		"highscore.none":  "😿 No high score yet!",
+		"highscore.desc":  "High score: **%d** by <@%s>",
+
+		"stats.scope.guild":  "Guild Stats",
+		"stats.scope.global": "Global Stats",
+		"stats.title":        "📊 **Your Meows — %s**",
+		"stats.body": "📈 Total Meows: %d\n" +
+			"✅ Successful Meows: %d\n" +
+			"❌ Failed Meows: %d\n" +
+			"🔁 Highest Streak: %d\n" +
+			"🔥 Current Streak: %d\n" +
+			"⏱️ Last Meow: %s",
+		"stats.error.title": "❌ Failed to Fetch Stats",
+		"stats.error.desc":  "Couldn't fetch your stats. You might not have any meows yet!",
+
+		"stats.badges": "\n\n🏅 **Badges**\n%s",
+
+		"achievement.unlocked":           "%s **%s** unlocked **%s** — %s",
+		"achievement.none":               "No badges yet. Keep meowing!",
+		"achievement.line":               "%s **%s** — <t:%d:D>",
+		"achievement.first_meow.name":    "First Meow",
+		"achievement.first_meow.desc":    "Landed your first successful meow.",
+		"achievement.century.name":       "Centurion",
+		"achievement.century.desc":       "Reached 100 successful meows.",
+		"achievement.chain_50.name":      "Chain Gang",
+		"achievement.chain_50.desc":      "Took part in a chain of 50 meows.",
+		"achievement.chain_breaker.name": "Heartbreaker",
+		"achievement.chain_breaker.desc": "Broke a chain of 100 meows or more.",
+		"achievement.night_owl.name":     "Night Owl",
+		"achievement.night_owl.desc":     "Meowed at 3am.",
+
+		"guildstats.title": "🏠 **Guild Meow Stats**",
+		"guildstats.body": "🔥 Current Streak: %d\n" +
+			"🐾 Last Meower: %s\n" +
+			"🏆 High Score: %s\n" +
+			"🌍 Guild Rank: %s\n" +
+			"📈 Total Meows: %d\n" +
+			"✅ Successful Meows: %d\n" +
+			"❌ Failed Meows: %d\n" +
+			"👥 Participants: %d\n" +
+			"👑 Most Active: %s\n" +
+			"🎂 Meowing Since: %s",
+		"guildstats.highscore.by": "%d by <@%s>",
+		"guildstats.top":          "<@%s> (%d meows)",
+		"guildstats.rank":         "#%d of %d",
+		"guildstats.age":          "%d days",
+		"guildstats.empty.title":  "📉 No Guild Stats",
+		"guildstats.empty.desc":   "No one has meowed in this server yet! Be the first.",
+		"guildstats.error.title":  "❌ Failed to Fetch Guild Stats",
+		"guildstats.error.desc":   "Something went wrong while retrieving guild stats.",
+
+		"setup.invalid.title": "⚠️ Invalid Usage",
+		"setup.invalid.desc":  "You must provide a channel using `/setup channel:#channel-name`.",
+		"setup.channel.desc":  "<#%s> isn't a text channel in this server.",
+		"setup.error.title":   "❌ Failed to Set Channel",
+		"setup.error.desc":    "Failed to set meow channel. Try again later.",
+		"setup.title":         "⚙ Setup Complete",
+		"setup.desc":          "✅ Meow channel has been set to <#%s>",
+
+		"language.title":        "🌐 Language Updated",
+		"language.desc":         "✅ Meow Bot will now speak **%s** in this server.",
+		"language.error.title":  "❌ Failed to Set Language",
+		"language.error.desc":   "Failed to save the server language. Try again later.",
+		"language.invalid.desc": "That language isn't supported yet.",
+		"timezone.title":        "🕰️ Server Timezone",
+		"timezone.desc":         "✅ Dates on this server are now shown and counted in **%s**. Local time: %s.",
+		"timezone.current":      "Dates on this server are shown and counted in **%s**. Local time: %s.",
+		"timezone.error.title":  "❌ Failed to Set Timezone",
+		"timezone.error.desc":   "Failed to save the server timezone. Try again later.",
+
+		"leaderboard.error.title":        "❌ Failed to Fetch Leaderboard",
+		"leaderboard.error.desc":         "Something went wrong while retrieving leaderboard data.",
+		"leaderboard.empty.title":        "📉 Empty Leaderboard",
+		"leaderboard.empty.desc":         "No one has meowed yet! Be the first.",
+		"leaderboard.page_error":         "⚠️ Couldn't load that page of the leaderboard.",
+		"leaderboard.title":              "🏆 %s — %s",
+		"leaderboard.scope.guild":        "Guild Leaderboard 🏠",
+		"leaderboard.scope.global":       "Global Leaderboard 🌐",
+		"leaderboard.metric.total":       "Most Total Meows",
+		"leaderboard.metric.success":     "Most Successful Meows",
+		"leaderboard.metric.fail":        "Most Failed Meows",
+		"leaderboard.footer":             "📄 Page %d — Showing ranks %d–%d of %d",
+		"leaderboard.footer.rank":        " | Your Rank: #%d",
+		"leaderboard.button.first":       "⏮️ First",
+		"leaderboard.button.prev":        "◀️ Prev",
+		"leaderboard.button.next":        "Next ▶️",
+		"leaderboard.button.last":        "Last ⏭️",
+		"leaderboard.title.season":       "%s (%s)",
+		"leaderboard.season.error.title": "❌ Season Not Found",
+		"leaderboard.season.error.desc":  "Use `current` or a season ID from `/season list`.",
+
+		"season.list.title":          "📅 Seasons",
+		"season.list.empty":          "No seasons yet. Admins can start one with `/season create`.",
+		"season.list.line":           "`#%d` **%s** (%s) — <t:%d:d> → <t:%d:d> · %s",
+		"season.list.error.title":    "❌ Failed to Fetch Seasons",
+		"season.list.error.desc":     "Couldn't load the seasons for this server.",
+		"season.status.upcoming":     "upcoming",
+		"season.status.active":       "running",
+		"season.status.closed":       "finished",
+		"season.create.title":        "📅 Season Created",
+		"season.create.desc":         "**%s** (`#%d`) runs from <t:%d:f> until <t:%d:f>.",
+		"season.create.error.title":  "❌ Couldn't Create Season",
+		"season.create.error.desc":   "The season couldn't be created. It may overlap another season.",
+		"season.create.invalid.desc": "Dates must be YYYY-MM-DD, and the season must end on or after its first day.",
+		"season.recap":               "🏁 **%s** is over! %d meowers took part. Final standings:",
+		"season.recap.line":          "%s **%s** — %d meows",
+		"season.recap.footer":        "Full standings: `/leaderboard season:%d`",
+		"season.recap.empty":         "🏁 **%s** is over! Nobody meowed this season.",
+
+		"treats.reason.meow":        "Meow",
+		"treats.reason.chain_bonus": "Chain bonus",
+		"treats.reason.high_score":  "New high score",
+		"treats.reason.chain_break": "Broke the chain",
+		"treats.reason.purchase":    "Bought %s",
+		"treats.reason.refund":      "Refund for %s",
+
+		"balance.title":       "🍪 Your Cat Treats",
+		"balance.body":        "You have **%d** cat treats.",
+		"balance.ledger":      "**Recent transactions**",
+		"balance.ledger.line": "`%+d` %s · <t:%d:R>",
+		"balance.items":       "**Your items**",
+		"balance.items.line":  "%s %s",
+		"balance.error.title": "❌ Failed to Fetch Balance",
+		"balance.error.desc":  "Couldn't load your cat treats.",
+
+		"shop.title":                         "🛍️ Treat Shop",
+		"shop.body":                          "You have **%d** cat treats. Buy items with `/buy`.\n",
+		"shop.line":                          "%s **%s** — %s\n%s",
+		"shop.price":                         "🍪 %d",
+		"shop.owned":                         "✅ Owned",
+		"shop.error.title":                   "❌ Failed to Open Shop",
+		"shop.error.desc":                    "Couldn't load the shop.",
+		"shop.item.reaction_heart_eyes.name": "Heart-Eyes Reaction",
+		"shop.item.reaction_heart_eyes.desc": "Meow Bot reacts to your meows with 😻.",
+		"shop.item.reaction_lion.name":       "Lion Reaction",
+		"shop.item.reaction_lion.desc":       "Meow Bot reacts to your meows with 🦁.",
+		"shop.item.title_nap_champion.name":  "Nap Champion",
+		"shop.item.title_nap_champion.desc":  "A title for your /stats.",
+		"shop.item.title_whisker_lord.name":  "Whisker Lord",
+		"shop.item.title_whisker_lord.desc":  "A title for your /stats.",
+		"shop.item.role_cat_herder.name":     "Cat Herder",
+		"shop.item.role_cat_herder.desc":     "A cosmetic server role.",
+
+		"buy.title":             "🛍️ Purchase Complete",
+		"buy.desc":              "%s **%s** is yours! You have **%d** cat treats left.",
+		"buy.error.title":       "❌ Purchase Failed",
+		"buy.error.desc":        "Your purchase couldn't be completed. You haven't been charged.",
+		"buy.unknown.desc":      "That item isn't in the shop.",
+		"buy.insufficient.desc": "**%s** costs **%d** cat treats. Keep meowing to earn more!",
+		"buy.owned.desc":        "You already own **%s**.",
+		"buy.role.error.desc":   "Meow Bot couldn't give you the role, so your treats were refunded. Ask an admin to check that Meow Bot can manage roles.",
+
+		"recap.title.daily":      "📰 **Daily meow recap** — %s",
+		"recap.title.weekly":     "📰 **Weekly meow recap** — %s",
+		"recap.range":            "%s → %s",
+		"recap.empty":            "😿 Nobody meowed. Quiet times!",
+		"recap.meows":            "🐾 **%d** meows (%d successful)",
+		"recap.streak":           "🔥 Best streak: **%d**",
+		"recap.records":          "🏆 New high scores: **%d**",
+		"recap.top":              "**Top meowers**",
+		"recap.top.line":         "%s **%s** — %d meows",
+		"recap.breaker":          "💔 Biggest breaker: **%s** (broke a chain of %d)",
+		"recap.frequency.daily":  "daily",
+		"recap.frequency.weekly": "weekly",
+		"recap.channel.game":     "the meow channel",
+		"recap.enable.title":     "📰 Recaps Enabled",
+		"recap.enable.desc":      "A %s recap will be posted in %s, with periods ending at midnight %s.",
+		"recap.disable.title":    "📰 Recaps Disabled",
+		"recap.disable.desc":     "Meow Bot will stop posting recaps.",
+		"recap.disable.none":     "Recaps weren't enabled for this server.",
+		"recap.error.title":      "❌ Couldn't Update Recaps",
+		"recap.error.desc":       "The recap settings couldn't be saved.",
+		"timezone.invalid":       "`%s` isn't a known timezone. Use an IANA name such as `Europe/Berlin` or `America/New_York`.",
+
+		"notify.title":        "🔔 Notifications Updated",
+		"notify.status.title": "🔔 Your Notifications",
+		"notify.status":       "Leaderboard changes: %s\nStreak milestones: %s",
+		"notify.on":           "✅ on",
+		"notify.off":          "❌ off",
+		"notify.error.title":  "❌ Couldn't Update Notifications",
+		"notify.error.desc":   "Your notification settings couldn't be saved.",
+		"notify.rank.passed":  "📉 **%s** just passed you on the **%s** leaderboard! You're now #%d (was #%d).",
+		"notify.streak.close": "🔥 A chain you helped build in **%s** is at **%d** meows, just %d away from the record of %d!",
+
+		"meowban.title":            "🔨 User Banned",
+		"meowban.desc":             "<@%s> can't play the meow game until <t:%d:f>.\nReason: %s",
+		"meowban.desc.permanent":   "<@%s> can't play the meow game anymore.\nReason: %s",
+		"meowban.reason.none":      "No reason given",
+		"meowban.error.title":      "❌ Couldn't Ban User",
+		"meowban.error.desc":       "The ban couldn't be saved.",
+		"meowban.duration.invalid": "`%s` isn't a valid duration. Use something like `30m`, `12h`, `7d` or `2w`.",
+		"meowunban.title":          "🕊️ User Unbanned",
+		"meowunban.desc":           "<@%s> can play the meow game again.",
+		"meowunban.none":           "<@%s> isn't banned from the meow game.",
+		"meowunban.error.title":    "❌ Couldn't Unban User",
+		"meowunban.error.desc":     "The ban couldn't be lifted.",
+
+		"audit.title":                "📜 Audit Log",
+		"audit.empty":                "No admin actions match.",
+		"audit.line":                 "<t:%d:f> <@%s> **%s**%s: %s → %s",
+		"audit.value.none":           "none",
+		"audit.error.title":          "❌ Failed to Fetch Audit Log",
+		"audit.error.desc":           "Couldn't load the audit log.",
+		"audit.invalid.desc":         "Dates must be YYYY-MM-DD, and `until` can't be before `since`.",
+		"privacy.none":               "Meow Bot doesn't store anything about you.",
+		"privacy.export.title":       "📦 Your Data",
+		"privacy.export.sent":        "Check your DMs for a file with everything Meow Bot stores about you.",
+		"privacy.export.dm":          "📦 Here's everything Meow Bot stores about you.",
+		"privacy.export.dm.error":    "Couldn't DM you. Allow direct messages from this server's members and try again.",
+		"privacy.export.error.title": "❌ Couldn't Export Your Data",
+		"privacy.export.error.desc":  "Your data couldn't be exported.",
+		"privacy.delete.title":       "🗑️ Delete Your Data",
+		"privacy.delete.confirm":     "This deletes your profile, name history, treats, items, achievements and settings everywhere, and can't be undone. Run `/privacy delete confirm:true` to go ahead.",
+		"privacy.delete.done":        "Your data is deleted. Your past meows still count toward server and season totals, under an anonymous player.",
+		"privacy.delete.error.title": "❌ Couldn't Delete Your Data",
+		"privacy.delete.error.desc":  "Your data couldn't be deleted.",
+	},
+
+	discordgo.SpanishES: {
+		"language.name": "Español",
+
+		"meow.repeat":    "😾 ¡No puedes maullar dos veces seguidas!",
+		"meow.highscore": "🏆 ¡Nuevo récord: %d maullidos de %s!",
+		"meow.count":     "%s **miau** x%d!",
+		"meow.reset":     "❌ ¿Sin miau? Reiniciando.",
+
+		"common.na": "N/D",
+
+		"time.ago":     "hace %s",
+		"time.seconds": "%ds",
+		"time.minutes": "%dmin",
+		"time.hours":   "%dh",
+		"time.days":    "%dd",
+		"time.at":      "%s (%s)",
+
+		"error.permission.title": "🚫 Permiso denegado",
+		"error.permission.desc":  "No tienes permiso para usar este comando.",
+		"error.cooldown.title":   "⏳ Más despacio",
+		"error.cooldown.desc":    "Podrás usar `/%s` de nuevo en %s.",
+		"error.panic.title":      "💥 Algo salió mal",
+		"error.panic.desc":       "Meow Bot se tropezó con su propia cola. Inténtalo de nuevo en un momento.",
+
+		"error.kind.not_found":     "No encontramos lo que buscabas.",
+		"error.kind.unavailable":   "La base de datos de maullidos está durmiendo la siesta. Inténtalo de nuevo en breve.",
+		"error.kind.invalid_input": "Esa solicitud no parece correcta. Revisa las opciones e inténtalo de nuevo.",
+		"error.incident":           "🆔 ID de incidente: `%s`",
+
+		"count.title": "📈 Contador de maullidos",
+		"count.desc":  "Maullidos actuales: **%d**",
+
+		"highscore.title": "🏆 Récord",
+		"highscore.none":  "😿 ¡Todavía no hay récord!",
+		"highscore.desc":  "Récord: **%d** de <@%s>",
+
+		"stats.scope.guild":  "Estadísticas del servidor",
+		"stats.scope.global": "Estadísticas globales",
+		"stats.title":        "📊 **Tus maullidos — %s**",
+		"stats.body": "📈 Maullidos totales: %d\n" +
+			"✅ Maullidos correctos: %d\n" +
+			"❌ Maullidos fallidos: %d\n" +
+			"🔁 Mejor racha: %d\n" +
+			"🔥 Racha actual: %d\n" +
+			"⏱️ Último maullido: %s",
+		"stats.error.title": "❌ No se pudieron obtener las estadísticas",
+		"stats.error.desc":  "No pudimos obtener tus estadísticas. ¡Puede que aún no hayas maullado!",
+
+		"stats.badges": "\n\n🏅 **Insignias**\n%s",
+
+		"achievement.unlocked":           "%s **%s** desbloqueó **%s** — %s",
+		"achievement.none":               "Aún no tienes insignias. ¡Sigue maullando!",
+		"achievement.line":               "%s **%s** — <t:%d:D>",
+		"achievement.first_meow.name":    "Primer maullido",
+		"achievement.first_meow.desc":    "Lograste tu primer maullido exitoso.",
+		"achievement.century.name":       "Centurión",
+		"achievement.century.desc":       "Alcanzaste 100 maullidos exitosos.",
+		"achievement.chain_50.name":      "Eslabón fuerte",
+		"achievement.chain_50.desc":      "Participaste en una cadena de 50 maullidos.",
+		"achievement.chain_breaker.name": "Rompecorazones",
+		"achievement.chain_breaker.desc": "Rompiste una cadena de 100 maullidos o más.",
+		"achievement.night_owl.name":     "Búho nocturno",
+		"achievement.night_owl.desc":     "Maullaste a las 3 de la madrugada.",
+
+		"guildstats.title": "🏠 **Estadísticas del servidor**",
+		"guildstats.body": "🔥 Racha actual: %d\n" +
+			"🐾 Último en maullar: %s\n" +
+			"🏆 Récord: %s\n" +
+			"🌍 Posición del servidor: %s\n" +
+			"📈 Maullidos totales: %d\n" +
+			"✅ Maullidos correctos: %d\n" +
+			"❌ Maullidos fallidos: %d\n" +
+			"👥 Participantes: %d\n" +
+			"👑 Más activo: %s\n" +
+			"🎂 Maullando desde hace: %s",
+		"guildstats.highscore.by": "%d de <@%s>",
+		"guildstats.top":          "<@%s> (%d maullidos)",
+		"guildstats.rank":         "#%d de %d",
+		"guildstats.age":          "%d días",
+		"guildstats.empty.title":  "📉 Sin estadísticas",
+		"guildstats.empty.desc":   "¡Nadie ha maullado todavía en este servidor! Sé el primero.",
+		"guildstats.error.title":  "❌ No se pudieron obtener las estadísticas del servidor",
+		"guildstats.error.desc":   "Algo salió mal al obtener las estadísticas del servidor.",
+
+		"setup.invalid.title": "⚠️ Uso incorrecto",
+		"setup.invalid.desc":  "Debes indicar un canal con `/setup channel:#nombre-del-canal`.",
+		"setup.channel.desc":  "<#%s> no es un canal de texto de este servidor.",
+		"setup.error.title":   "❌ No se pudo configurar el canal",
+		"setup.error.desc":    "No se pudo configurar el canal de maullidos. Inténtalo más tarde.",
+		"setup.title":         "⚙ Configuración completa",
+		"setup.desc":          "✅ El canal de maullidos ahora es <#%s>",
+
+		"language.title":        "🌐 Idioma actualizado",
+		"language.desc":         "✅ Meow Bot ahora hablará **%s** en este servidor.",
+		"language.error.title":  "❌ No se pudo cambiar el idioma",
+		"language.error.desc":   "No se pudo guardar el idioma del servidor. Inténtalo más tarde.",
+		"language.invalid.desc": "Ese idioma todavía no está disponible.",
+		"timezone.title":        "🕰️ Zona horaria del servidor",
+		"timezone.desc":         "✅ Las fechas de este servidor se muestran y cuentan ahora en **%s**. Hora local: %s.",
+		"timezone.current":      "Las fechas de este servidor se muestran y cuentan en **%s**. Hora local: %s.",
+		"timezone.error.title":  "❌ No se pudo cambiar la zona horaria",
+		"timezone.error.desc":   "No se pudo guardar la zona horaria del servidor. Inténtalo más tarde.",
+
+		"leaderboard.error.title":        "❌ No se pudo obtener la clasificación",
+		"leaderboard.error.desc":         "Algo salió mal al obtener la clasificación.",
+		"leaderboard.empty.title":        "📉 Clasificación vacía",
+		"leaderboard.empty.desc":         "¡Nadie ha maullado todavía! Sé el primero.",
+		"leaderboard.page_error":         "⚠️ No se pudo cargar esa página de la clasificación.",
+		"leaderboard.title":              "🏆 %s — %s",
+		"leaderboard.scope.guild":        "Clasificación del servidor 🏠",
+		"leaderboard.scope.global":       "Clasificación global 🌐",
+		"leaderboard.metric.total":       "Más maullidos totales",
+		"leaderboard.metric.success":     "Más maullidos correctos",
+		"leaderboard.metric.fail":        "Más maullidos fallidos",
+		"leaderboard.footer":             "📄 Página %d — Posiciones %d–%d de %d",
+		"leaderboard.footer.rank":        " | Tu posición: #%d",
+		"leaderboard.button.first":       "⏮️ Primera",
+		"leaderboard.button.prev":        "◀️ Anterior",
+		"leaderboard.button.next":        "Siguiente ▶️",
+		"leaderboard.button.last":        "Última ⏭️",
+		"leaderboard.title.season":       "%s (%s)",
+		"leaderboard.season.error.title": "❌ Temporada no encontrada",
+		"leaderboard.season.error.desc":  "Usa `current` o un ID de temporada de `/season list`.",
+
+		"season.list.title":          "📅 Temporadas",
+		"season.list.empty":          "Aún no hay temporadas. Los administradores pueden crear una con `/season create`.",
+		"season.list.line":           "`#%d` **%s** (%s) — <t:%d:d> → <t:%d:d> · %s",
+		"season.list.error.title":    "❌ No se pudieron obtener las temporadas",
+		"season.list.error.desc":     "No pudimos cargar las temporadas de este servidor.",
+		"season.status.upcoming":     "próxima",
+		"season.status.active":       "en curso",
+		"season.status.closed":       "terminada",
+		"season.create.title":        "📅 Temporada creada",
+		"season.create.desc":         "**%s** (`#%d`) va desde <t:%d:f> hasta <t:%d:f>.",
+		"season.create.error.title":  "❌ No se pudo crear la temporada",
+		"season.create.error.desc":   "No se pudo crear la temporada. Puede que se solape con otra.",
+		"season.create.invalid.desc": "Las fechas deben tener el formato AAAA-MM-DD y la temporada debe terminar el mismo día o después de empezar.",
+		"season.recap":               "🏁 ¡**%s** ha terminado! Participaron %d maulladores. Clasificación final:",
+		"season.recap.line":          "%s **%s** — %d maullidos",
+		"season.recap.footer":        "Clasificación completa: `/leaderboard season:%d`",
+		"season.recap.empty":         "🏁 ¡**%s** ha terminado! Nadie maulló esta temporada.",
+
+		"treats.reason.meow":        "Maullido",
+		"treats.reason.chain_bonus": "Bonificación de cadena",
+		"treats.reason.high_score":  "Nuevo récord",
+		"treats.reason.chain_break": "Rompiste la cadena",
+		"treats.reason.purchase":    "Compraste %s",
+		"treats.reason.refund":      "Reembolso de %s",
+
+		"balance.title":       "🍪 Tus golosinas",
+		"balance.body":        "Tienes **%d** golosinas para gatos.",
+		"balance.ledger":      "**Movimientos recientes**",
+		"balance.ledger.line": "`%+d` %s · <t:%d:R>",
+		"balance.items":       "**Tus artículos**",
+		"balance.items.line":  "%s %s",
+		"balance.error.title": "❌ No se pudo obtener el saldo",
+		"balance.error.desc":  "No pudimos cargar tus golosinas.",
+
+		"shop.title":                         "🛍️ Tienda de golosinas",
+		"shop.body":                          "Tienes **%d** golosinas. Compra artículos con `/buy`.\n",
+		"shop.line":                          "%s **%s** — %s\n%s",
+		"shop.price":                         "🍪 %d",
+		"shop.owned":                         "✅ Comprado",
+		"shop.error.title":                   "❌ No se pudo abrir la tienda",
+		"shop.error.desc":                    "No pudimos cargar la tienda.",
+		"shop.item.reaction_heart_eyes.name": "Reacción de ojos de corazón",
+		"shop.item.reaction_heart_eyes.desc": "Meow Bot reacciona a tus maullidos con 😻.",
+		"shop.item.reaction_lion.name":       "Reacción de león",
+		"shop.item.reaction_lion.desc":       "Meow Bot reacciona a tus maullidos con 🦁.",
+		"shop.item.title_nap_champion.name":  "Campeón de la siesta",
+		"shop.item.title_nap_champion.desc":  "Un título para tus /stats.",
+		"shop.item.title_whisker_lord.name":  "Señor de los bigotes",
+		"shop.item.title_whisker_lord.desc":  "Un título para tus /stats.",
+		"shop.item.role_cat_herder.name":     "Pastor de gatos",
+		"shop.item.role_cat_herder.desc":     "Un rol decorativo del servidor.",
+
+		"buy.title":             "🛍️ Compra completada",
+		"buy.desc":              "¡%s **%s** es tuyo! Te quedan **%d** golosinas.",
+		"buy.error.title":       "❌ Compra fallida",
+		"buy.error.desc":        "No se pudo completar la compra. No se te ha cobrado nada.",
+		"buy.unknown.desc":      "Ese artículo no está en la tienda.",
+		"buy.insufficient.desc": "**%s** cuesta **%d** golosinas. ¡Sigue maullando para ganar más!",
+		"buy.owned.desc":        "Ya tienes **%s**.",
+		"buy.role.error.desc":   "Meow Bot no pudo darte el rol, así que se te devolvieron las golosinas. Pide a un administrador que compruebe que Meow Bot puede gestionar roles.",
+
+		"recap.title.daily":      "📰 **Resumen diario de maullidos** — %s",
+		"recap.title.weekly":     "📰 **Resumen semanal de maullidos** — %s",
+		"recap.range":            "%s → %s",
+		"recap.empty":            "😿 Nadie maulló. ¡Qué tranquilidad!",
+		"recap.meows":            "🐾 **%d** maullidos (%d correctos)",
+		"recap.streak":           "🔥 Mejor racha: **%d**",
+		"recap.records":          "🏆 Nuevos récords: **%d**",
+		"recap.top":              "**Los que más maullaron**",
+		"recap.top.line":         "%s **%s** — %d maullidos",
+		"recap.breaker":          "💔 Mayor rompecadenas: **%s** (rompió una cadena de %d)",
+		"recap.frequency.daily":  "diario",
+		"recap.frequency.weekly": "semanal",
+		"recap.channel.game":     "el canal de maullidos",
+		"recap.enable.title":     "📰 Resúmenes activados",
+		"recap.enable.desc":      "Se publicará un resumen %s en %s, con periodos que terminan a medianoche (%s).",
+		"recap.disable.title":    "📰 Resúmenes desactivados",
+		"recap.disable.desc":     "Meow Bot dejará de publicar resúmenes.",
+		"recap.disable.none":     "Los resúmenes no estaban activados en este servidor.",
+		"recap.error.title":      "❌ No se pudieron actualizar los resúmenes",
+		"recap.error.desc":       "No se pudo guardar la configuración de los resúmenes.",
+		"timezone.invalid":       "`%s` no es una zona horaria conocida. Usa un nombre IANA como `Europe/Madrid` o `America/Mexico_City`.",
+
+		"notify.title":        "🔔 Notificaciones actualizadas",
+		"notify.status.title": "🔔 Tus notificaciones",
+		"notify.status":       "Cambios en la clasificación: %s\nHitos de racha: %s",
+		"notify.on":           "✅ activadas",
+		"notify.off":          "❌ desactivadas",
+		"notify.error.title":  "❌ No se pudieron actualizar las notificaciones",
+		"notify.error.desc":   "No se pudo guardar tu configuración de notificaciones.",
+		"notify.rank.passed":  "📉 ¡**%s** acaba de superarte en la clasificación de **%s**! Ahora eres #%d (antes #%d).",
+		"notify.streak.close": "🔥 ¡Una cadena que ayudaste a construir en **%s** va por **%d** maullidos, a solo %d del récord de %d!",
+
+		"meowban.title":            "🔨 Usuario expulsado",
+		"meowban.desc":             "<@%s> no puede jugar al juego de maullidos hasta <t:%d:f>.\nMotivo: %s",
+		"meowban.desc.permanent":   "<@%s> ya no puede jugar al juego de maullidos.\nMotivo: %s",
+		"meowban.reason.none":      "Sin motivo",
+		"meowban.error.title":      "❌ No se pudo expulsar al usuario",
+		"meowban.error.desc":       "No se pudo guardar la expulsión.",
+		"meowban.duration.invalid": "`%s` no es una duración válida. Usa algo como `30m`, `12h`, `7d` o `2w`.",
+		"meowunban.title":          "🕊️ Expulsión retirada",
+		"meowunban.desc":           "<@%s> puede volver a jugar al juego de maullidos.",
+		"meowunban.none":           "<@%s> no está expulsado del juego de maullidos.",
+		"meowunban.error.title":    "❌ No se pudo retirar la expulsión",
+		"meowunban.error.desc":     "No se pudo retirar la expulsión.",
+
+		"audit.title":                "📜 Registro de auditoría",
+		"audit.empty":                "Ninguna acción de administración coincide.",
+		"audit.line":                 "<t:%d:f> <@%s> **%s**%s: %s → %s",
+		"audit.value.none":           "nada",
+		"audit.error.title":          "❌ No se pudo obtener el registro",
+		"audit.error.desc":           "No pudimos cargar el registro de auditoría.",
+		"audit.invalid.desc":         "Las fechas deben tener el formato AAAA-MM-DD y `until` no puede ser anterior a `since`.",
+		"privacy.none":               "Meow Bot no guarda nada sobre ti.",
+		"privacy.export.title":       "📦 Tus datos",
+		"privacy.export.sent":        "Revisa tus MD: te enviamos un archivo con todo lo que Meow Bot guarda sobre ti.",
+		"privacy.export.dm":          "📦 Esto es todo lo que Meow Bot guarda sobre ti.",
+		"privacy.export.dm.error":    "No pudimos enviarte un MD. Permite mensajes directos de los miembros de este servidor e inténtalo de nuevo.",
+		"privacy.export.error.title": "❌ No se pudieron exportar tus datos",
+		"privacy.export.error.desc":  "No pudimos exportar tus datos.",
+		"privacy.delete.title":       "🗑️ Borrar tus datos",
+		"privacy.delete.confirm":     "Esto borra tu perfil, historial de nombres, premios, artículos, logros y ajustes en todas partes, y no se puede deshacer. Usa `/privacy delete confirm:true` para continuar.",
+		"privacy.delete.done":        "Tus datos se borraron. Tus maullidos pasados siguen contando para los totales del servidor y de la temporada, como un jugador anónimo.",
+		"privacy.delete.error.title": "❌ No se pudieron borrar tus datos",
+		"privacy.delete.error.desc":  "No pudimos borrar tus datos.",
+
+		"cmd.count.description":                            "Consulta el contador de maullidos de este servidor",
+		"cmd.highscore.description":                        "Consulta la racha de maullidos más alta de este servidor",
+		"cmd.stats.name":                                   "estadisticas",
+		"cmd.stats.description":                            "Consulta tus estadísticas de maullidos",
+		"cmd.stats.opt.scope.description":                  "Mostrar estadísticas del servidor o globales",
+		"cmd.stats.opt.scope.choice.guild":                 "Servidor",
+		"cmd.stats.opt.scope.choice.global":                "Global",
+		"cmd.guildstats.description":                       "Consulta las estadísticas de maullidos de este servidor",
+		"cmd.setup.description":                            "Configura Meow Bot en este servidor",
+		"cmd.setup.opt.channel.description":                "Canal donde Meow Bot escuchará los maullidos",
+		"cmd.language.name":                                "idioma",
+		"cmd.language.description":                         "Cambia el idioma de Meow Bot en este servidor",
+		"cmd.language.opt.locale.description":              "Idioma que usará Meow Bot",
+		"cmd.timezone.name":                                "zonahoraria",
+		"cmd.timezone.description":                         "Muestra o cambia la zona horaria de las fechas de este servidor",
+		"cmd.timezone.opt.zone.description":                "Zona horaria IANA, p. ej. Europe/Madrid o America/Mexico_City",
+		"cmd.leaderboard.name":                             "clasificacion",
+		"cmd.leaderboard.description":                      "Muestra a los que más maúllan",
+		"cmd.leaderboard.opt.scope.description":            "Mostrar la clasificación del servidor o global",
+		"cmd.leaderboard.opt.scope.choice.guild":           "Servidor",
+		"cmd.leaderboard.opt.scope.choice.global":          "Global",
+		"cmd.leaderboard.opt.metric.description":           "Métrica de la clasificación",
+		"cmd.leaderboard.opt.metric.choice.total":          "Maullidos totales",
+		"cmd.leaderboard.opt.metric.choice.success":        "Maullidos correctos",
+		"cmd.leaderboard.opt.metric.choice.fail":           "Maullidos fallidos",
+		"cmd.leaderboard.opt.page.description":             "Número de página de la clasificación",
+		"cmd.leaderboard.opt.season.description":           "ID de temporada de /season list, o \"current\". Por defecto, histórico",
+		"cmd.season.name":                                  "temporada",
+		"cmd.season.description":                           "Lista o crea temporadas de clasificación",
+		"cmd.season.opt.list.description":                  "Lista las temporadas de este servidor y las globales",
+		"cmd.season.opt.create.description":                "Crea una temporada para este servidor (requiere Gestionar servidor)",
+		"cmd.season.opt.create.opt.name.description":       "Nombre de la temporada",
+		"cmd.season.opt.create.opt.start.description":      "Primer día de la temporada (AAAA-MM-DD, zona horaria del servidor)",
+		"cmd.season.opt.create.opt.end.description":        "Último día de la temporada (AAAA-MM-DD, zona horaria del servidor)",
+		"cmd.balance.name":                                 "saldo",
+		"cmd.balance.description":                          "Consulta tus golosinas y tus movimientos recientes",
+		"cmd.shop.name":                                    "tienda",
+		"cmd.shop.description":                             "Explora los artículos que puedes comprar con golosinas",
+		"cmd.buy.name":                                     "comprar",
+		"cmd.buy.description":                              "Compra un artículo de la tienda",
+		"cmd.buy.opt.item.description":                     "Artículo que quieres comprar",
+		"cmd.recap.name":                                   "resumen",
+		"cmd.recap.description":                            "Configura resúmenes diarios o semanales",
+		"cmd.recap.opt.enable.description":                 "Publica un resumen de los maullidos del servidor cada día o semana",
+		"cmd.recap.opt.enable.opt.frequency.description":   "Cada cuánto publicar un resumen",
+		"cmd.recap.opt.enable.opt.frequency.choice.daily":  "Diario",
+		"cmd.recap.opt.enable.opt.frequency.choice.weekly": "Semanal",
+		"cmd.recap.opt.enable.opt.channel.description":     "Canal donde publicar los resúmenes. Por defecto, el canal de maullidos",
+		"cmd.recap.opt.enable.opt.timezone.description":    "Zona horaria IANA en la que terminan los periodos, p. ej. Europe/Madrid. Por defecto, UTC",
+		"cmd.recap.opt.disable.description":                "Deja de publicar resúmenes",
+		"cmd.notify.name":                                  "avisos",
+		"cmd.notify.description":                           "Gestiona tus notificaciones por MD",
+		"cmd.notify.opt.on.name":                           "activar",
+		"cmd.notify.opt.on.description":                    "Recibe un MD cuando alguien te supere o una cadena tuya se acerque al récord",
+		"cmd.notify.opt.on.opt.type.description":           "Qué notificaciones. Por defecto, todas",
+		"cmd.notify.opt.on.opt.type.choice.rank":           "Cambios en la clasificación",
+		"cmd.notify.opt.on.opt.type.choice.streak":         "Hitos de racha",
+		"cmd.notify.opt.on.opt.type.choice.all":            "Todas",
+		"cmd.notify.opt.off.name":                          "desactivar",
+		"cmd.notify.opt.off.description":                   "Deja de recibir notificaciones por MD",
+		"cmd.notify.opt.off.opt.type.description":          "Qué notificaciones. Por defecto, todas",
+		"cmd.notify.opt.off.opt.type.choice.rank":          "Cambios en la clasificación",
+		"cmd.notify.opt.off.opt.type.choice.streak":        "Hitos de racha",
+		"cmd.notify.opt.off.opt.type.choice.all":           "Todas",
+		"cmd.notify.opt.status.name":                       "estado",
+		"cmd.notify.opt.status.description":                "Muestra qué notificaciones por MD recibes",
+		"cmd.meowban.name":                                 "expulsar-maullidos",
+		"cmd.meowban.description":                          "Expulsa a un usuario del juego de maullidos",
+		"cmd.meowban.opt.user.description":                 "Usuario que expulsar",
+		"cmd.meowban.opt.duration.description":             "Cuánto tiempo, p. ej. 30m, 12h, 7d o 2w. Por defecto, permanente",
+		"cmd.meowban.opt.reason.description":               "Motivo de la expulsión",
+		"cmd.meowban.opt.delete.description":               "Borra sus mensajes en el canal de maullidos en lugar de ignorarlos",
+		"cmd.meowunban.name":                               "readmitir-maullidos",
+		"cmd.meowunban.description":                        "Permite a un usuario expulsado volver a jugar",
+		"cmd.meowunban.opt.user.description":               "Usuario al que readmitir",
+		"cmd.audit.name":                                   "auditoria",
+		"cmd.audit.description":                            "Muestra las acciones de administración recientes del servidor",
+		"cmd.audit.opt.actor.description":                  "Mostrar solo las acciones de este usuario",
+		"cmd.audit.opt.since.description":                  "Primer día a mostrar (AAAA-MM-DD, zona horaria del servidor)",
+		"cmd.audit.opt.until.description":                  "Último día a mostrar (AAAA-MM-DD, zona horaria del servidor)",
+		"cmd.privacy.name":                                 "privacidad",
+		"cmd.privacy.description":                          "Consulta o borra lo que Meow Bot guarda sobre ti",
+		"cmd.privacy.opt.export.name":                      "exportar",
+		"cmd.privacy.opt.export.description":               "Recibe un MD con todo lo que Meow Bot guarda sobre ti",
+		"cmd.privacy.opt.delete.name":                      "borrar",
+		"cmd.privacy.opt.delete.description":               "Borra todo lo que Meow Bot guarda sobre ti",
+		"cmd.privacy.opt.delete.opt.confirm.description":   "Pon true para borrar tus datos. No se puede deshacer",
+	},
+
+	discordgo.French: {
+		"language.name": "Français",
+
+		"meow.repeat":    "😾 Tu ne peux pas miauler deux fois de suite !",
+		"meow.highscore": "🏆 Nouveau record : %d miaous par %s !",
+		"meow.count":     "%s **miaou** x%d !",
+		"meow.reset":     "❌ Pas de miaou ? On recommence.",
+
+		"common.na": "N/D",
+
+		"time.ago":     "il y a %s",
+		"time.seconds": "%ds",
+		"time.minutes": "%dmin",
+		"time.hours":   "%dh",
+		"time.days":    "%dj",
+		"time.at":      "%s (%s)",
+
+		"error.permission.title": "🚫 Permission refusée",
+		"error.permission.desc":  "Tu n'as pas la permission d'utiliser cette commande.",
+		"error.cooldown.title":   "⏳ Doucement",
+		"error.cooldown.desc":    "Tu pourras utiliser `/%s` à nouveau dans %s.",
+		"error.panic.title":      "💥 Une erreur est survenue",
+		"error.panic.desc":       "Meow Bot s'est pris les pattes dans sa queue. Réessaie dans un instant.",
+
+		"error.kind.not_found":     "Impossible de trouver ce que tu cherches.",
+		"error.kind.unavailable":   "La base de données des miaous fait la sieste. Réessaie dans un instant.",
+		"error.kind.invalid_input": "Cette demande semble incorrecte. Vérifie les options et réessaie.",
+		"error.incident":           "🆔 Identifiant d'incident : `%s`",
+
+		"count.title": "📈 Compteur de miaous",
+		"count.desc":  "Compteur actuel : **%d**",
+
+		"highscore.title": "🏆 Record",
+		"highscore.none":  "😿 Pas encore de record !",
+		"highscore.desc":  "Record : **%d** par <@%s>",
+
+		"stats.scope.guild":  "Statistiques du serveur",
+		"stats.scope.global": "Statistiques globales",
+		"stats.title":        "📊 **Tes miaous — %s**",
+		"stats.body": "📈 Miaous au total : %d\n" +
+			"✅ Miaous réussis : %d\n" +
+			"❌ Miaous ratés : %d\n" +
+			"🔁 Meilleure série : %d\n" +
+			"🔥 Série actuelle : %d\n" +
+			"⏱️ Dernier miaou : %s",
+		"stats.error.title": "❌ Impossible de récupérer les statistiques",
+		"stats.error.desc":  "Impossible de récupérer tes statistiques. Tu n'as peut-être pas encore miaulé !",
+
+		"stats.badges": "\n\n🏅 **Badges**\n%s",
+
+		"achievement.unlocked":           "%s **%s** a débloqué **%s** — %s",
+		"achievement.none":               "Aucun badge pour l'instant. Continue de miauler !",
+		"achievement.line":               "%s **%s** — <t:%d:D>",
+		"achievement.first_meow.name":    "Premier miaou",
+		"achievement.first_meow.desc":    "Tu as réussi ton premier miaou.",
+		"achievement.century.name":       "Centurion",
+		"achievement.century.desc":       "Tu as atteint 100 miaous réussis.",
+		"achievement.chain_50.name":      "Maillon fort",
+		"achievement.chain_50.desc":      "Tu as participé à une chaîne de 50 miaous.",
+		"achievement.chain_breaker.name": "Briseur de cœurs",
+		"achievement.chain_breaker.desc": "Tu as brisé une chaîne de 100 miaous ou plus.",
+		"achievement.night_owl.name":     "Oiseau de nuit",
+		"achievement.night_owl.desc":     "Tu as miaulé à 3 h du matin.",
+
+		"guildstats.title": "🏠 **Statistiques du serveur**",
+		"guildstats.body": "🔥 Série actuelle : %d\n" +
+			"🐾 Dernier à miauler : %s\n" +
+			"🏆 Record : %s\n" +
+			"🌍 Classement du serveur : %s\n" +
+			"📈 Miaous au total : %d\n" +
+			"✅ Miaous réussis : %d\n" +
+			"❌ Miaous ratés : %d\n" +
+			"👥 Participants : %d\n" +
+			"👑 Le plus actif : %s\n" +
+			"🎂 Miaule depuis : %s",
+		"guildstats.highscore.by": "%d par <@%s>",
+		"guildstats.top":          "<@%s> (%d miaous)",
+		"guildstats.rank":         "n°%d sur %d",
+		"guildstats.age":          "%d jours",
+		"guildstats.empty.title":  "📉 Pas de statistiques",
+		"guildstats.empty.desc":   "Personne n'a encore miaulé sur ce serveur ! Sois le premier.",
+		"guildstats.error.title":  "❌ Impossible de récupérer les statistiques du serveur",
+		"guildstats.error.desc":   "Une erreur est survenue lors de la récupération des statistiques du serveur.",
+
+		"setup.invalid.title": "⚠️ Utilisation incorrecte",
+		"setup.invalid.desc":  "Tu dois indiquer un salon avec `/setup channel:#nom-du-salon`.",
+		"setup.channel.desc":  "<#%s> n'est pas un salon textuel de ce serveur.",
+		"setup.error.title":   "❌ Impossible de configurer le salon",
+		"setup.error.desc":    "Impossible de configurer le salon des miaous. Réessaie plus tard.",
+		"setup.title":         "⚙ Configuration terminée",
+		"setup.desc":          "✅ Le salon des miaous est maintenant <#%s>",
+
+		"language.title":        "🌐 Langue mise à jour",
+		"language.desc":         "✅ Meow Bot parlera désormais **%s** sur ce serveur.",
+		"language.error.title":  "❌ Impossible de changer la langue",
+		"language.error.desc":   "Impossible d'enregistrer la langue du serveur. Réessaie plus tard.",
+		"language.invalid.desc": "Cette langue n'est pas encore prise en charge.",
+		"timezone.title":        "🕰️ Fuseau horaire du serveur",
+		"timezone.desc":         "✅ Les dates de ce serveur sont désormais affichées et comptées en **%s**. Heure locale : %s.",
+		"timezone.current":      "Les dates de ce serveur sont affichées et comptées en **%s**. Heure locale : %s.",
+		"timezone.error.title":  "❌ Impossible de changer le fuseau horaire",
+		"timezone.error.desc":   "Impossible d'enregistrer le fuseau horaire du serveur. Réessaie plus tard.",
+
+		"leaderboard.error.title":        "❌ Impossible de récupérer le classement",
+		"leaderboard.error.desc":         "Une erreur est survenue lors de la récupération du classement.",
+		"leaderboard.empty.title":        "📉 Classement vide",
+		"leaderboard.empty.desc":         "Personne n'a encore miaulé ! Sois le premier.",
+		"leaderboard.page_error":         "⚠️ Impossible de charger cette page du classement.",
+		"leaderboard.title":              "🏆 %s — %s",
+		"leaderboard.scope.guild":        "Classement du serveur 🏠",
+		"leaderboard.scope.global":       "Classement global 🌐",
+		"leaderboard.metric.total":       "Le plus de miaous",
+		"leaderboard.metric.success":     "Le plus de miaous réussis",
+		"leaderboard.metric.fail":        "Le plus de miaous ratés",
+		"leaderboard.footer":             "📄 Page %d — Rangs %d à %d sur %d",
+		"leaderboard.footer.rank":        " | Ton rang : n°%d",
+		"leaderboard.button.first":       "⏮️ Début",
+		"leaderboard.button.prev":        "◀️ Préc.",
+		"leaderboard.button.next":        "Suiv. ▶️",
+		"leaderboard.button.last":        "Fin ⏭️",
+		"leaderboard.title.season":       "%s (%s)",
+		"leaderboard.season.error.title": "❌ Saison introuvable",
+		"leaderboard.season.error.desc":  "Utilise `current` ou un identifiant de saison de `/season list`.",
+
+		"season.list.title":          "📅 Saisons",
+		"season.list.empty":          "Aucune saison pour l'instant. Les admins peuvent en lancer une avec `/season create`.",
+		"season.list.line":           "`#%d` **%s** (%s) — <t:%d:d> → <t:%d:d> · %s",
+		"season.list.error.title":    "❌ Impossible de récupérer les saisons",
+		"season.list.error.desc":     "Impossible de charger les saisons de ce serveur.",
+		"season.status.upcoming":     "à venir",
+		"season.status.active":       "en cours",
+		"season.status.closed":       "terminée",
+		"season.create.title":        "📅 Saison créée",
+		"season.create.desc":         "**%s** (`#%d`) se déroule du <t:%d:f> au <t:%d:f>.",
+		"season.create.error.title":  "❌ Impossible de créer la saison",
+		"season.create.error.desc":   "La saison n'a pas pu être créée. Elle chevauche peut-être une autre saison.",
+		"season.create.invalid.desc": "Les dates doivent être au format AAAA-MM-JJ et la saison doit se terminer le jour de son début ou après.",
+		"season.recap":               "🏁 **%s** est terminée ! %d miauleurs ont participé. Classement final :",
+		"season.recap.line":          "%s **%s** — %d miaous",
+		"season.recap.footer":        "Classement complet : `/leaderboard season:%d`",
+		"season.recap.empty":         "🏁 **%s** est terminée ! Personne n'a miaulé cette saison.",
+
+		"treats.reason.meow":        "Miaou",
+		"treats.reason.chain_bonus": "Bonus de chaîne",
+		"treats.reason.high_score":  "Nouveau record",
+		"treats.reason.chain_break": "Chaîne brisée",
+		"treats.reason.purchase":    "Achat : %s",
+		"treats.reason.refund":      "Remboursement : %s",
+
+		"balance.title":       "🍪 Tes friandises",
+		"balance.body":        "Tu as **%d** friandises pour chat.",
+		"balance.ledger":      "**Transactions récentes**",
+		"balance.ledger.line": "`%+d` %s · <t:%d:R>",
+		"balance.items":       "**Tes articles**",
+		"balance.items.line":  "%s %s",
+		"balance.error.title": "❌ Impossible de récupérer le solde",
+		"balance.error.desc":  "Impossible de charger tes friandises.",
+
+		"shop.title":                         "🛍️ Boutique de friandises",
+		"shop.body":                          "Tu as **%d** friandises. Achète des articles avec `/buy`.\n",
+		"shop.line":                          "%s **%s** — %s\n%s",
+		"shop.price":                         "🍪 %d",
+		"shop.owned":                         "✅ Acheté",
+		"shop.error.title":                   "❌ Impossible d'ouvrir la boutique",
+		"shop.error.desc":                    "Impossible de charger la boutique.",
+		"shop.item.reaction_heart_eyes.name": "Réaction yeux en cœur",
+		"shop.item.reaction_heart_eyes.desc": "Meow Bot réagit à tes miaous avec 😻.",
+		"shop.item.reaction_lion.name":       "Réaction lion",
+		"shop.item.reaction_lion.desc":       "Meow Bot réagit à tes miaous avec 🦁.",
+		"shop.item.title_nap_champion.name":  "Champion de la sieste",
+		"shop.item.title_nap_champion.desc":  "Un titre pour tes /stats.",
+		"shop.item.title_whisker_lord.name":  "Seigneur des moustaches",
+		"shop.item.title_whisker_lord.desc":  "Un titre pour tes /stats.",
+		"shop.item.role_cat_herder.name":     "Berger de chats",
+		"shop.item.role_cat_herder.desc":     "Un rôle décoratif sur le serveur.",
+
+		"buy.title":             "🛍️ Achat effectué",
+		"buy.desc":              "%s **%s** est à toi ! Il te reste **%d** friandises.",
+		"buy.error.title":       "❌ Échec de l'achat",
+		"buy.error.desc":        "L'achat n'a pas pu aboutir. Tu n'as pas été débité.",
+		"buy.unknown.desc":      "Cet article n'est pas en boutique.",
+		"buy.insufficient.desc": "**%s** coûte **%d** friandises. Continue de miauler pour en gagner !",
+		"buy.owned.desc":        "Tu possèdes déjà **%s**.",
+		"buy.role.error.desc":   "Meow Bot n'a pas pu te donner le rôle, tes friandises ont donc été remboursées. Demande à un admin de vérifier que Meow Bot peut gérer les rôles.",
+
+		"recap.title.daily":      "📰 **Récap quotidien des miaous** — %s",
+		"recap.title.weekly":     "📰 **Récap hebdomadaire des miaous** — %s",
+		"recap.range":            "%s → %s",
+		"recap.empty":            "😿 Personne n'a miaulé. C'était calme !",
+		"recap.meows":            "🐾 **%d** miaous (%d réussis)",
+		"recap.streak":           "🔥 Meilleure série : **%d**",
+		"recap.records":          "🏆 Nouveaux records : **%d**",
+		"recap.top":              "**Meilleurs miauleurs**",
+		"recap.top.line":         "%s **%s** — %d miaous",
+		"recap.breaker":          "💔 Plus gros briseur : **%s** (a brisé une chaîne de %d)",
+		"recap.frequency.daily":  "quotidien",
+		"recap.frequency.weekly": "hebdomadaire",
+		"recap.channel.game":     "le salon des miaous",
+		"recap.enable.title":     "📰 Récaps activés",
+		"recap.enable.desc":      "Un récap %s sera publié dans %s, les périodes se terminant à minuit (%s).",
+		"recap.disable.title":    "📰 Récaps désactivés",
+		"recap.disable.desc":     "Meow Bot ne publiera plus de récaps.",
+		"recap.disable.none":     "Les récaps n'étaient pas activés sur ce serveur.",
+		"recap.error.title":      "❌ Impossible de mettre à jour les récaps",
+		"recap.error.desc":       "Les paramètres des récaps n'ont pas pu être enregistrés.",
+		"timezone.invalid":       "`%s` n'est pas un fuseau horaire connu. Utilise un nom IANA comme `Europe/Paris` ou `America/Montreal`.",
+
+		"notify.title":        "🔔 Notifications mises à jour",
+		"notify.status.title": "🔔 Tes notifications",
+		"notify.status":       "Changements de classement : %s\nPaliers de série : %s",
+		"notify.on":           "✅ activées",
+		"notify.off":          "❌ désactivées",
+		"notify.error.title":  "❌ Impossible de mettre à jour les notifications",
+		"notify.error.desc":   "Tes paramètres de notification n'ont pas pu être enregistrés.",
+		"notify.rank.passed":  "📉 **%s** vient de te dépasser au classement de **%s** ! Tu es maintenant #%d (avant #%d).",
+		"notify.streak.close": "🔥 Une chaîne que tu as aidé à construire sur **%s** en est à **%d** miaous, à seulement %d du record de %d !",
+
+		"meowban.title":            "🔨 Utilisateur banni",
+		"meowban.desc":             "<@%s> ne peut plus jouer aux miaous jusqu'au <t:%d:f>.\nRaison : %s",
+		"meowban.desc.permanent":   "<@%s> ne peut plus jouer aux miaous.\nRaison : %s",
+		"meowban.reason.none":      "Aucune raison donnée",
+		"meowban.error.title":      "❌ Impossible de bannir l'utilisateur",
+		"meowban.error.desc":       "Le bannissement n'a pas pu être enregistré.",
+		"meowban.duration.invalid": "`%s` n'est pas une durée valide. Utilise par exemple `30m`, `12h`, `7d` ou `2w`.",
+		"meowunban.title":          "🕊️ Utilisateur débanni",
+		"meowunban.desc":           "<@%s> peut de nouveau jouer aux miaous.",
+		"meowunban.none":           "<@%s> n'est pas banni du jeu des miaous.",
+		"meowunban.error.title":    "❌ Impossible de débannir l'utilisateur",
+		"meowunban.error.desc":     "Le bannissement n'a pas pu être levé.",
+
+		"audit.title":                "📜 Journal d'audit",
+		"audit.empty":                "Aucune action d'administration ne correspond.",
+		"audit.line":                 "<t:%d:f> <@%s> **%s**%s : %s → %s",
+		"audit.value.none":           "rien",
+		"audit.error.title":          "❌ Impossible de charger le journal",
+		"audit.error.desc":           "Le journal d'audit n'a pas pu être chargé.",
+		"audit.invalid.desc":         "Les dates doivent être au format AAAA-MM-JJ et `until` ne peut pas précéder `since`.",
+		"privacy.none":               "Meow Bot ne conserve rien sur toi.",
+		"privacy.export.title":       "📦 Tes données",
+		"privacy.export.sent":        "Regarde tes MP : tu y trouveras un fichier avec tout ce que Meow Bot conserve sur toi.",
+		"privacy.export.dm":          "📦 Voici tout ce que Meow Bot conserve sur toi.",
+		"privacy.export.dm.error":    "Impossible de t'envoyer un MP. Autorise les messages privés des membres de ce serveur et réessaie.",
+		"privacy.export.error.title": "❌ Impossible d'exporter tes données",
+		"privacy.export.error.desc":  "Tes données n'ont pas pu être exportées.",
+		"privacy.delete.title":       "🗑️ Supprimer tes données",
+		"privacy.delete.confirm":     "Cela supprime ton profil, l'historique de tes noms, tes friandises, objets, succès et réglages partout, sans retour possible. Lance `/privacy delete confirm:true` pour continuer.",
+		"privacy.delete.done":        "Tes données sont supprimées. Tes miaous passés comptent toujours dans les totaux du serveur et des saisons, sous un joueur anonyme.",
+		"privacy.delete.error.title": "❌ Impossible de supprimer tes données",
+		"privacy.delete.error.desc":  "Tes données n'ont pas pu être supprimées.",
+
+		"cmd.count.description":                            "Affiche le compteur de miaous de ce serveur",
+		"cmd.highscore.description":                        "Affiche la meilleure série de miaous de ce serveur",
+		"cmd.stats.name":                                   "statistiques",
+		"cmd.stats.description":                            "Affiche tes statistiques de miaous",
+		"cmd.stats.opt.scope.description":                  "Afficher les statistiques du serveur ou globales",
+		"cmd.stats.opt.scope.choice.guild":                 "Serveur",
+		"cmd.stats.opt.scope.choice.global":                "Global",
+		"cmd.guildstats.description":                       "Affiche les statistiques de miaous de ce serveur",
+		"cmd.setup.description":                            "Configure Meow Bot pour ce serveur",
+		"cmd.setup.opt.channel.description":                "Salon où Meow Bot écoute les miaous",
+		"cmd.language.name":                                "langue",
+		"cmd.language.description":                         "Change la langue de Meow Bot sur ce serveur",
+		"cmd.language.opt.locale.description":              "Langue utilisée par Meow Bot",
+		"cmd.timezone.name":                                "fuseau",
+		"cmd.timezone.description":                         "Affiche ou change le fuseau horaire des dates sur ce serveur",
+		"cmd.timezone.opt.zone.description":                "Fuseau horaire IANA, ex. Europe/Paris ou America/Montreal",
+		"cmd.leaderboard.name":                             "classement",
+		"cmd.leaderboard.description":                      "Affiche les meilleurs miauleurs",
+		"cmd.leaderboard.opt.scope.description":            "Afficher le classement du serveur ou global",
+		"cmd.leaderboard.opt.scope.choice.guild":           "Serveur",
+		"cmd.leaderboard.opt.scope.choice.global":          "Global",
+		"cmd.leaderboard.opt.metric.description":           "Critère du classement",
+		"cmd.leaderboard.opt.metric.choice.total":          "Miaous au total",
+		"cmd.leaderboard.opt.metric.choice.success":        "Miaous réussis",
+		"cmd.leaderboard.opt.metric.choice.fail":           "Miaous ratés",
+		"cmd.leaderboard.opt.page.description":             "Numéro de page du classement",
+		"cmd.leaderboard.opt.season.description":           "Identifiant de saison de /season list, ou \"current\". Par défaut, tout temps",
+		"cmd.season.name":                                  "saison",
+		"cmd.season.description":                           "Liste ou crée des saisons de classement",
+		"cmd.season.opt.list.description":                  "Liste les saisons de ce serveur et les saisons globales",
+		"cmd.season.opt.create.description":                "Crée une saison pour ce serveur (nécessite Gérer le serveur)",
+		"cmd.season.opt.create.opt.name.description":       "Nom de la saison",
+		"cmd.season.opt.create.opt.start.description":      "Premier jour de la saison (AAAA-MM-JJ, fuseau du serveur)",
+		"cmd.season.opt.create.opt.end.description":        "Dernier jour de la saison (AAAA-MM-JJ, fuseau du serveur)",
+		"cmd.balance.name":                                 "solde",
+		"cmd.balance.description":                          "Consulte tes friandises et tes transactions récentes",
+		"cmd.shop.name":                                    "boutique",
+		"cmd.shop.description":                             "Parcours les articles à acheter avec tes friandises",
+		"cmd.buy.name":                                     "acheter",
+		"cmd.buy.description":                              "Achète un article de la boutique",
+		"cmd.buy.opt.item.description":                     "Article à acheter",
+		"cmd.recap.name":                                   "recap",
+		"cmd.recap.description":                            "Configure des récaps quotidiens ou hebdomadaires",
+		"cmd.recap.opt.enable.description":                 "Publie un récap des miaous du serveur chaque jour ou semaine",
+		"cmd.recap.opt.enable.opt.frequency.description":   "Fréquence des récaps",
+		"cmd.recap.opt.enable.opt.frequency.choice.daily":  "Quotidien",
+		"cmd.recap.opt.enable.opt.frequency.choice.weekly": "Hebdomadaire",
+		"cmd.recap.opt.enable.opt.channel.description":     "Salon où publier les récaps. Par défaut, le salon des miaous",
+		"cmd.recap.opt.enable.opt.timezone.description":    "Fuseau horaire IANA des périodes, ex. Europe/Paris. Par défaut, UTC",
+		"cmd.recap.opt.disable.description":                "Arrête de publier des récaps",
+		"cmd.notify.name":                                  "notifs",
+		"cmd.notify.description":                           "Gère tes notifications en MP",
+		"cmd.notify.opt.on.name":                           "activer",
+		"cmd.notify.opt.on.description":                    "Reçois un MP quand quelqu'un te dépasse ou qu'une de tes chaînes approche du record",
+		"cmd.notify.opt.on.opt.type.description":           "Quelles notifications. Par défaut, toutes",
+		"cmd.notify.opt.on.opt.type.choice.rank":           "Changements de classement",
+		"cmd.notify.opt.on.opt.type.choice.streak":         "Paliers de série",
+		"cmd.notify.opt.on.opt.type.choice.all":            "Toutes",
+		"cmd.notify.opt.off.name":                          "desactiver",
+		"cmd.notify.opt.off.description":                   "Arrête les notifications en MP",
+		"cmd.notify.opt.off.opt.type.description":          "Quelles notifications. Par défaut, toutes",
+		"cmd.notify.opt.off.opt.type.choice.rank":          "Changements de classement",
+		"cmd.notify.opt.off.opt.type.choice.streak":        "Paliers de série",
+		"cmd.notify.opt.off.opt.type.choice.all":           "Toutes",
+		"cmd.notify.opt.status.name":                       "etat",
+		"cmd.notify.opt.status.description":                "Affiche les notifications en MP que tu reçois",
+		"cmd.meowban.name":                                 "bannir-miaou",
+		"cmd.meowban.description":                          "Bannit un utilisateur du jeu des miaous",
+		"cmd.meowban.opt.user.description":                 "Utilisateur à bannir",
+		"cmd.meowban.opt.duration.description":             "Durée, ex. 30m, 12h, 7d ou 2w. Par défaut, permanent",
+		"cmd.meowban.opt.reason.description":               "Raison du bannissement",
+		"cmd.meowban.opt.delete.description":               "Supprime ses messages dans le salon des miaous au lieu de les ignorer",
+		"cmd.meowunban.name":                               "debannir-miaou",
+		"cmd.meowunban.description":                        "Permet à un utilisateur banni de rejouer",
+		"cmd.meowunban.opt.user.description":               "Utilisateur à débannir",
+		"cmd.audit.name":                                   "audit",
+		"cmd.audit.description":                            "Affiche les actions d'administration récentes du serveur",
+		"cmd.audit.opt.actor.description":                  "N'afficher que les actions de cet utilisateur",
+		"cmd.audit.opt.since.description":                  "Premier jour à afficher (AAAA-MM-JJ, fuseau du serveur)",
+		"cmd.audit.opt.until.description":                  "Dernier jour à afficher (AAAA-MM-JJ, fuseau du serveur)",
+		"cmd.privacy.name":                                 "confidentialite",
+		"cmd.privacy.description":                          "Consulte ou supprime ce que Meow Bot conserve sur toi",
+		"cmd.privacy.opt.export.name":                      "exporter",
+		"cmd.privacy.opt.export.description":               "Reçois en MP tout ce que Meow Bot conserve sur toi",
+		"cmd.privacy.opt.delete.name":                      "supprimer",
+		"cmd.privacy.opt.delete.description":               "Supprime tout ce que Meow Bot conserve sur toi",
+		"cmd.privacy.opt.delete.opt.confirm.description":   "Mets true pour supprimer tes données. Sans retour possible",
+	},
+
+	discordgo.German: {
+		"language.name": "Deutsch",
+
+		"meow.repeat":    "😾 Du kannst nicht zweimal hintereinander miauen!",
+		"meow.highscore": "🏆 Neuer Rekord: %d Miaus von %s!",
+		"meow.count":     "%s **miau** x%d!",
+		"meow.reset":     "❌ Kein Miau? Zurückgesetzt.",
+
+		"common.na": "k. A.",
+
+		"time.ago":     "vor %s",
+		"time.seconds": "%ds",
+		"time.minutes": "%dmin",
+		"time.hours":   "%dh",
+		"time.days":    "%dT",
+		"time.at":      "%s (%s)",
+
+		"error.permission.title": "🚫 Zugriff verweigert",
+		"error.permission.desc":  "Du hast keine Berechtigung, diesen Befehl zu verwenden.",
+		"error.cooldown.title":   "⏳ Langsam",
+		"error.cooldown.desc":    "Du kannst `/%s` in %s wieder verwenden.",
+		"error.panic.title":      "💥 Etwas ist schiefgelaufen",
+		"error.panic.desc":       "Meow Bot ist über den eigenen Schwanz gestolpert. Versuch es gleich noch einmal.",
+
+		"error.kind.not_found":     "Wir konnten nicht finden, wonach du suchst.",
+		"error.kind.unavailable":   "Die Miau-Datenbank hält gerade ein Nickerchen. Versuch es gleich noch einmal.",
+		"error.kind.invalid_input": "Diese Anfrage sieht nicht richtig aus. Prüfe die Optionen und versuch es erneut.",
+		"error.incident":           "🆔 Vorfall-ID: `%s`",
+
+		"count.title": "📈 Miau-Zähler",
+		"count.desc":  "Aktueller Miau-Zähler: **%d**",
+
+		"highscore.title": "🏆 Rekord",
+		"highscore.none":  "😿 Noch kein Rekord!",
+		"highscore.desc":  "Rekord: **%d** von <@%s>",
+
+		"stats.scope.guild":  "Server-Statistiken",
+		"stats.scope.global": "Globale Statistiken",
+		"stats.title":        "📊 **Deine Miaus — %s**",
+		"stats.body": "📈 Miaus gesamt: %d\n" +
+			"✅ Erfolgreiche Miaus: %d\n" +
+			"❌ Fehlgeschlagene Miaus: %d\n" +
+			"🔁 Längste Serie: %d\n" +
+			"🔥 Aktuelle Serie: %d\n" +
+			"⏱️ Letztes Miau: %s",
+		"stats.error.title": "❌ Statistiken konnten nicht geladen werden",
+		"stats.error.desc":  "Deine Statistiken konnten nicht geladen werden. Vielleicht hast du noch nicht miaut!",
+
+		"stats.badges": "\n\n🏅 **Abzeichen**\n%s",
+
+		"achievement.unlocked":           "%s **%s** hat **%s** freigeschaltet — %s",
+		"achievement.none":               "Noch keine Abzeichen. Miau weiter!",
+		"achievement.line":               "%s **%s** — <t:%d:D>",
+		"achievement.first_meow.name":    "Erstes Miau",
+		"achievement.first_meow.desc":    "Dein erstes erfolgreiches Miau.",
+		"achievement.century.name":       "Zenturio",
+		"achievement.century.desc":       "100 erfolgreiche Miaus erreicht.",
+		"achievement.chain_50.name":      "Kettenglied",
+		"achievement.chain_50.desc":      "An einer Kette von 50 Miaus teilgenommen.",
+		"achievement.chain_breaker.name": "Herzensbrecher",
+		"achievement.chain_breaker.desc": "Eine Kette von 100 oder mehr Miaus unterbrochen.",
+		"achievement.night_owl.name":     "Nachteule",
+		"achievement.night_owl.desc":     "Um 3 Uhr nachts miaut.",
+
+		"guildstats.title": "🏠 **Server-Miau-Statistiken**",
+		"guildstats.body": "🔥 Aktuelle Serie: %d\n" +
+			"🐾 Zuletzt miaut: %s\n" +
+			"🏆 Rekord: %s\n" +
+			"🌍 Server-Rang: %s\n" +
+			"📈 Miaus gesamt: %d\n" +
+			"✅ Erfolgreiche Miaus: %d\n" +
+			"❌ Fehlgeschlagene Miaus: %d\n" +
+			"👥 Teilnehmer: %d\n" +
+			"👑 Am aktivsten: %s\n" +
+			"🎂 Miaut seit: %s",
+		"guildstats.highscore.by": "%d von <@%s>",
+		"guildstats.top":          "<@%s> (%d Miaus)",
+		"guildstats.rank":         "#%d von %d",
+		"guildstats.age":          "%d Tagen",
+		"guildstats.empty.title":  "📉 Keine Server-Statistiken",
+		"guildstats.empty.desc":   "Auf diesem Server hat noch niemand miaut! Sei der Erste.",
+		"guildstats.error.title":  "❌ Server-Statistiken konnten nicht geladen werden",
+		"guildstats.error.desc":   "Beim Laden der Server-Statistiken ist etwas schiefgelaufen.",
+
+		"setup.invalid.title": "⚠️ Ungültige Verwendung",
+		"setup.invalid.desc":  "Du musst einen Kanal mit `/setup channel:#kanal-name` angeben.",
+		"setup.channel.desc":  "<#%s> ist kein Textkanal dieses Servers.",
+		"setup.error.title":   "❌ Kanal konnte nicht gesetzt werden",
+		"setup.error.desc":    "Der Miau-Kanal konnte nicht gesetzt werden. Versuch es später noch einmal.",
+		"setup.title":         "⚙ Einrichtung abgeschlossen",
+		"setup.desc":          "✅ Der Miau-Kanal ist jetzt <#%s>",
+
+		"language.title":        "🌐 Sprache aktualisiert",
+		"language.desc":         "✅ Meow Bot spricht auf diesem Server jetzt **%s**.",
+		"language.error.title":  "❌ Sprache konnte nicht gesetzt werden",
+		"language.error.desc":   "Die Server-Sprache konnte nicht gespeichert werden. Versuch es später noch einmal.",
+		"language.invalid.desc": "Diese Sprache wird noch nicht unterstützt.",
+		"timezone.title":        "🕰️ Server-Zeitzone",
+		"timezone.desc":         "✅ Daten auf diesem Server werden jetzt in **%s** angezeigt und gezählt. Ortszeit: %s.",
+		"timezone.current":      "Daten auf diesem Server werden in **%s** angezeigt und gezählt. Ortszeit: %s.",
+		"timezone.error.title":  "❌ Zeitzone konnte nicht gesetzt werden",
+		"timezone.error.desc":   "Die Server-Zeitzone konnte nicht gespeichert werden. Versuch es später noch einmal.",
+
+		"leaderboard.error.title":        "❌ Bestenliste konnte nicht geladen werden",
+		"leaderboard.error.desc":         "Beim Laden der Bestenliste ist etwas schiefgelaufen.",
+		"leaderboard.empty.title":        "📉 Leere Bestenliste",
+		"leaderboard.empty.desc":         "Noch hat niemand miaut! Sei der Erste.",
+		"leaderboard.page_error":         "⚠️ Diese Seite der Bestenliste konnte nicht geladen werden.",
+		"leaderboard.title":              "🏆 %s — %s",
+		"leaderboard.scope.guild":        "Server-Bestenliste 🏠",
+		"leaderboard.scope.global":       "Globale Bestenliste 🌐",
+		"leaderboard.metric.total":       "Meiste Miaus",
+		"leaderboard.metric.success":     "Meiste erfolgreiche Miaus",
+		"leaderboard.metric.fail":        "Meiste fehlgeschlagene Miaus",
+		"leaderboard.footer":             "📄 Seite %d — Ränge %d–%d von %d",
+		"leaderboard.footer.rank":        " | Dein Rang: #%d",
+		"leaderboard.button.first":       "⏮️ Anfang",
+		"leaderboard.button.prev":        "◀️ Zurück",
+		"leaderboard.button.next":        "Weiter ▶️",
+		"leaderboard.button.last":        "Ende ⏭️",
+		"leaderboard.title.season":       "%s (%s)",
+		"leaderboard.season.error.title": "❌ Saison nicht gefunden",
+		"leaderboard.season.error.desc":  "Nutze `current` oder eine Saison-ID aus `/season list`.",
+
+		"season.list.title":          "📅 Saisons",
+		"season.list.empty":          "Noch keine Saisons. Admins können mit `/season create` eine starten.",
+		"season.list.line":           "`#%d` **%s** (%s) — <t:%d:d> → <t:%d:d> · %s",
+		"season.list.error.title":    "❌ Saisons konnten nicht geladen werden",
+		"season.list.error.desc":     "Die Saisons dieses Servers konnten nicht geladen werden.",
+		"season.status.upcoming":     "demnächst",
+		"season.status.active":       "läuft",
+		"season.status.closed":       "beendet",
+		"season.create.title":        "📅 Saison erstellt",
+		"season.create.desc":         "**%s** (`#%d`) läuft vom <t:%d:f> bis <t:%d:f>.",
+		"season.create.error.title":  "❌ Saison konnte nicht erstellt werden",
+		"season.create.error.desc":   "Die Saison konnte nicht erstellt werden. Vielleicht überschneidet sie sich mit einer anderen.",
+		"season.create.invalid.desc": "Daten müssen im Format JJJJ-MM-TT sein, und die Saison muss am oder nach ihrem ersten Tag enden.",
+		"season.recap":               "🏁 **%s** ist vorbei! %d Miauer haben mitgemacht. Endstand:",
+		"season.recap.line":          "%s **%s** — %d Miaus",
+		"season.recap.footer":        "Vollständige Tabelle: `/leaderboard season:%d`",
+		"season.recap.empty":         "🏁 **%s** ist vorbei! Diese Saison hat niemand miaut.",
+
+		"treats.reason.meow":        "Miau",
+		"treats.reason.chain_bonus": "Kettenbonus",
+		"treats.reason.high_score":  "Neuer Rekord",
+		"treats.reason.chain_break": "Kette unterbrochen",
+		"treats.reason.purchase":    "%s gekauft",
+		"treats.reason.refund":      "Erstattung für %s",
+
+		"balance.title":       "🍪 Deine Katzenleckerlis",
+		"balance.body":        "Du hast **%d** Katzenleckerlis.",
+		"balance.ledger":      "**Letzte Buchungen**",
+		"balance.ledger.line": "`%+d` %s · <t:%d:R>",
+		"balance.items":       "**Deine Artikel**",
+		"balance.items.line":  "%s %s",
+		"balance.error.title": "❌ Kontostand konnte nicht geladen werden",
+		"balance.error.desc":  "Deine Katzenleckerlis konnten nicht geladen werden.",
+
+		"shop.title":                         "🛍️ Leckerli-Laden",
+		"shop.body":                          "Du hast **%d** Katzenleckerlis. Kaufe Artikel mit `/buy`.\n",
+		"shop.line":                          "%s **%s** — %s\n%s",
+		"shop.price":                         "🍪 %d",
+		"shop.owned":                         "✅ Gekauft",
+		"shop.error.title":                   "❌ Laden konnte nicht geöffnet werden",
+		"shop.error.desc":                    "Der Laden konnte nicht geladen werden.",
+		"shop.item.reaction_heart_eyes.name": "Herzaugen-Reaktion",
+		"shop.item.reaction_heart_eyes.desc": "Meow Bot reagiert auf deine Miaus mit 😻.",
+		"shop.item.reaction_lion.name":       "Löwen-Reaktion",
+		"shop.item.reaction_lion.desc":       "Meow Bot reagiert auf deine Miaus mit 🦁.",
+		"shop.item.title_nap_champion.name":  "Nickerchen-Champion",
+		"shop.item.title_nap_champion.desc":  "Ein Titel für deine /stats.",
+		"shop.item.title_whisker_lord.name":  "Schnurrhaar-Fürst",
+		"shop.item.title_whisker_lord.desc":  "Ein Titel für deine /stats.",
+		"shop.item.role_cat_herder.name":     "Katzenhirte",
+		"shop.item.role_cat_herder.desc":     "Eine kosmetische Serverrolle.",
+
+		"buy.title":             "🛍️ Kauf abgeschlossen",
+		"buy.desc":              "%s **%s** gehört dir! Du hast noch **%d** Katzenleckerlis.",
+		"buy.error.title":       "❌ Kauf fehlgeschlagen",
+		"buy.error.desc":        "Der Kauf konnte nicht abgeschlossen werden. Dir wurde nichts berechnet.",
+		"buy.unknown.desc":      "Diesen Artikel gibt es im Laden nicht.",
+		"buy.insufficient.desc": "**%s** kostet **%d** Katzenleckerlis. Miau weiter, um mehr zu verdienen!",
+		"buy.owned.desc":        "Du besitzt **%s** bereits.",
+		"buy.role.error.desc":   "Meow Bot konnte dir die Rolle nicht geben, deine Leckerlis wurden erstattet. Bitte einen Admin zu prüfen, ob Meow Bot Rollen verwalten darf.",
+
+		"recap.title.daily":      "📰 **Tägliche Miau-Zusammenfassung** — %s",
+		"recap.title.weekly":     "📰 **Wöchentliche Miau-Zusammenfassung** — %s",
+		"recap.range":            "%s → %s",
+		"recap.empty":            "😿 Niemand hat miaut. Ganz schön ruhig!",
+		"recap.meows":            "🐾 **%d** Miaus (%d erfolgreich)",
+		"recap.streak":           "🔥 Beste Serie: **%d**",
+		"recap.records":          "🏆 Neue Rekorde: **%d**",
+		"recap.top":              "**Top-Miauer**",
+		"recap.top.line":         "%s **%s** — %d Miaus",
+		"recap.breaker":          "💔 Größter Kettenbrecher: **%s** (hat eine Kette von %d gebrochen)",
+		"recap.frequency.daily":  "tägliche",
+		"recap.frequency.weekly": "wöchentliche",
+		"recap.channel.game":     "den Miau-Kanal",
+		"recap.enable.title":     "📰 Zusammenfassungen aktiviert",
+		"recap.enable.desc":      "Eine %s Zusammenfassung wird in %s gepostet; Zeiträume enden um Mitternacht (%s).",
+		"recap.disable.title":    "📰 Zusammenfassungen deaktiviert",
+		"recap.disable.desc":     "Meow Bot postet keine Zusammenfassungen mehr.",
+		"recap.disable.none":     "Zusammenfassungen waren für diesen Server nicht aktiviert.",
+		"recap.error.title":      "❌ Zusammenfassungen konnten nicht aktualisiert werden",
+		"recap.error.desc":       "Die Einstellungen konnten nicht gespeichert werden.",
+		"timezone.invalid":       "`%s` ist keine bekannte Zeitzone. Nutze einen IANA-Namen wie `Europe/Berlin` oder `America/New_York`.",
+
+		"notify.title":        "🔔 Benachrichtigungen aktualisiert",
+		"notify.status.title": "🔔 Deine Benachrichtigungen",
+		"notify.status":       "Ranglistenänderungen: %s\nSerien-Meilensteine: %s",
+		"notify.on":           "✅ an",
+		"notify.off":          "❌ aus",
+		"notify.error.title":  "❌ Benachrichtigungen konnten nicht aktualisiert werden",
+		"notify.error.desc":   "Deine Benachrichtigungseinstellungen konnten nicht gespeichert werden.",
+		"notify.rank.passed":  "📉 **%s** hat dich gerade in der Rangliste von **%s** überholt! Du bist jetzt #%d (vorher #%d).",
+		"notify.streak.close": "🔥 Eine Kette, die du in **%s** mitgebaut hast, steht bei **%d** Miaus, nur noch %d vom Rekord von %d entfernt!",
+
+		"meowban.title":            "🔨 Nutzer gesperrt",
+		"meowban.desc":             "<@%s> darf bis <t:%d:f> nicht mehr am Miau-Spiel teilnehmen.\nGrund: %s",
+		"meowban.desc.permanent":   "<@%s> darf nicht mehr am Miau-Spiel teilnehmen.\nGrund: %s",
+		"meowban.reason.none":      "Kein Grund angegeben",
+		"meowban.error.title":      "❌ Nutzer konnte nicht gesperrt werden",
+		"meowban.error.desc":       "Die Sperre konnte nicht gespeichert werden.",
+		"meowban.duration.invalid": "`%s` ist keine gültige Dauer. Nutze z. B. `30m`, `12h`, `7d` oder `2w`.",
+		"meowunban.title":          "🕊️ Sperre aufgehoben",
+		"meowunban.desc":           "<@%s> darf wieder am Miau-Spiel teilnehmen.",
+		"meowunban.none":           "<@%s> ist nicht vom Miau-Spiel gesperrt.",
+		"meowunban.error.title":    "❌ Sperre konnte nicht aufgehoben werden",
+		"meowunban.error.desc":     "Die Sperre konnte nicht aufgehoben werden.",
+
+		"audit.title":                "📜 Audit-Log",
+		"audit.empty":                "Keine Admin-Aktionen gefunden.",
+		"audit.line":                 "<t:%d:f> <@%s> **%s**%s: %s → %s",
+		"audit.value.none":           "nichts",
+		"audit.error.title":          "❌ Audit-Log konnte nicht geladen werden",
+		"audit.error.desc":           "Das Audit-Log konnte nicht geladen werden.",
+		"audit.invalid.desc":         "Daten müssen im Format JJJJ-MM-TT sein, und `until` darf nicht vor `since` liegen.",
+		"privacy.none":               "Meow Bot speichert nichts über dich.",
+		"privacy.export.title":       "📦 Deine Daten",
+		"privacy.export.sent":        "Schau in deine DMs: Dort liegt eine Datei mit allem, was Meow Bot über dich speichert.",
+		"privacy.export.dm":          "📦 Das ist alles, was Meow Bot über dich speichert.",
+		"privacy.export.dm.error":    "Wir konnten dir keine DM schicken. Erlaube Direktnachrichten von Mitgliedern dieses Servers und versuche es erneut.",
+		"privacy.export.error.title": "❌ Daten konnten nicht exportiert werden",
+		"privacy.export.error.desc":  "Deine Daten konnten nicht exportiert werden.",
+		"privacy.delete.title":       "🗑️ Daten löschen",
+		"privacy.delete.confirm":     "Das löscht dein Profil, deine Namenshistorie, Leckerlis, Items, Erfolge und Einstellungen überall und lässt sich nicht rückgängig machen. Führe `/privacy delete confirm:true` aus, um fortzufahren.",
+		"privacy.delete.done":        "Deine Daten sind gelöscht. Deine bisherigen Miaus zählen weiter zu den Server- und Saisonsummen, als anonymer Spieler.",
+		"privacy.delete.error.title": "❌ Daten konnten nicht gelöscht werden",
+		"privacy.delete.error.desc":  "Deine Daten konnten nicht gelöscht werden.",
+
+		"cmd.count.description":                            "Zeigt den aktuellen Miau-Zähler dieses Servers",
+		"cmd.highscore.description":                        "Zeigt die längste Miau-Serie dieses Servers",
+		"cmd.stats.name":                                   "statistiken",
+		"cmd.stats.description":                            "Zeigt deine persönlichen Miau-Statistiken",
+		"cmd.stats.opt.scope.description":                  "Server- oder globale Statistiken anzeigen",
+		"cmd.stats.opt.scope.choice.guild":                 "Server",
+		"cmd.stats.opt.scope.choice.global":                "Global",
+		"cmd.guildstats.description":                       "Zeigt die Miau-Statistiken dieses Servers",
+		"cmd.setup.description":                            "Richtet Meow Bot für diesen Server ein",
+		"cmd.setup.opt.channel.description":                "Kanal, in dem Meow Bot auf Miaus hört",
+		"cmd.language.name":                                "sprache",
+		"cmd.language.description":                         "Ändert die Sprache von Meow Bot auf diesem Server",
+		"cmd.language.opt.locale.description":              "Sprache, die Meow Bot verwenden soll",
+		"cmd.timezone.name":                                "zeitzone",
+		"cmd.timezone.description":                         "Zeigt oder ändert die Zeitzone für Daten auf diesem Server",
+		"cmd.timezone.opt.zone.description":                "IANA-Zeitzone, z. B. Europe/Berlin oder America/New_York",
+		"cmd.leaderboard.name":                             "bestenliste",
+		"cmd.leaderboard.description":                      "Zeigt die besten Miauer",
+		"cmd.leaderboard.opt.scope.description":            "Server- oder globale Bestenliste anzeigen",
+		"cmd.leaderboard.opt.scope.choice.guild":           "Server",
+		"cmd.leaderboard.opt.scope.choice.global":          "Global",
+		"cmd.leaderboard.opt.metric.description":           "Kriterium der Bestenliste",
+		"cmd.leaderboard.opt.metric.choice.total":          "Miaus gesamt",
+		"cmd.leaderboard.opt.metric.choice.success":        "Erfolgreiche Miaus",
+		"cmd.leaderboard.opt.metric.choice.fail":           "Fehlgeschlagene Miaus",
+		"cmd.leaderboard.opt.page.description":             "Seitennummer der Bestenliste",
+		"cmd.leaderboard.opt.season.description":           "Saison-ID aus /season list oder \"current\". Standard: gesamte Zeit",
+		"cmd.season.name":                                  "saison",
+		"cmd.season.description":                           "Bestenlisten-Saisons anzeigen oder erstellen",
+		"cmd.season.opt.list.description":                  "Zeigt die Saisons dieses Servers und die globalen",
+		"cmd.season.opt.create.description":                "Erstellt eine Saison für diesen Server (erfordert Server verwalten)",
+		"cmd.season.opt.create.opt.name.description":       "Name der Saison",
+		"cmd.season.opt.create.opt.start.description":      "Erster Tag der Saison (JJJJ-MM-TT, Server-Zeitzone)",
+		"cmd.season.opt.create.opt.end.description":        "Letzter Tag der Saison (JJJJ-MM-TT, Server-Zeitzone)",
+		"cmd.balance.name":                                 "kontostand",
+		"cmd.balance.description":                          "Zeigt deine Katzenleckerlis und letzten Buchungen",
+		"cmd.shop.name":                                    "laden",
+		"cmd.shop.description":                             "Zeigt Artikel, die du mit Leckerlis kaufen kannst",
+		"cmd.buy.name":                                     "kaufen",
+		"cmd.buy.description":                              "Kauft einen Artikel aus dem Laden",
+		"cmd.buy.opt.item.description":                     "Artikel, den du kaufen willst",
+		"cmd.recap.name":                                   "zusammenfassung",
+		"cmd.recap.description":                            "Tägliche oder wöchentliche Zusammenfassungen einrichten",
+		"cmd.recap.opt.enable.description":                 "Postet jeden Tag oder jede Woche eine Zusammenfassung der Miaus",
+		"cmd.recap.opt.enable.opt.frequency.description":   "Wie oft eine Zusammenfassung gepostet wird",
+		"cmd.recap.opt.enable.opt.frequency.choice.daily":  "Täglich",
+		"cmd.recap.opt.enable.opt.frequency.choice.weekly": "Wöchentlich",
+		"cmd.recap.opt.enable.opt.channel.description":     "Kanal für die Zusammenfassungen. Standard ist der Miau-Kanal",
+		"cmd.recap.opt.enable.opt.timezone.description":    "IANA-Zeitzone, in der Zeiträume enden, z. B. Europe/Berlin. Standard ist UTC",
+		"cmd.recap.opt.disable.description":                "Keine Zusammenfassungen mehr posten",
+		"cmd.notify.name":                                  "benachrichtigungen",
+		"cmd.notify.description":                           "Verwalte deine DM-Benachrichtigungen",
+		"cmd.notify.opt.on.name":                           "an",
+		"cmd.notify.opt.on.description":                    "Erhalte eine DM, wenn dich jemand überholt oder deine Kette den Rekord fast erreicht",
+		"cmd.notify.opt.on.opt.type.description":           "Welche Benachrichtigungen. Standard: alle",
+		"cmd.notify.opt.on.opt.type.choice.rank":           "Ranglistenänderungen",
+		"cmd.notify.opt.on.opt.type.choice.streak":         "Serien-Meilensteine",
+		"cmd.notify.opt.on.opt.type.choice.all":            "Alle",
+		"cmd.notify.opt.off.name":                          "aus",
+		"cmd.notify.opt.off.description":                   "Keine DM-Benachrichtigungen mehr",
+		"cmd.notify.opt.off.opt.type.description":          "Welche Benachrichtigungen. Standard: alle",
+		"cmd.notify.opt.off.opt.type.choice.rank":          "Ranglistenänderungen",
+		"cmd.notify.opt.off.opt.type.choice.streak":        "Serien-Meilensteine",
+		"cmd.notify.opt.off.opt.type.choice.all":           "Alle",
+		"cmd.notify.opt.status.name":                       "status",
+		"cmd.notify.opt.status.description":                "Zeigt, welche DM-Benachrichtigungen du erhältst",
+		"cmd.meowban.name":                                 "miau-sperre",
+		"cmd.meowban.description":                          "Sperrt einen Nutzer vom Miau-Spiel",
+		"cmd.meowban.opt.user.description":                 "Zu sperrender Nutzer",
+		"cmd.meowban.opt.duration.description":             "Wie lange, z. B. 30m, 12h, 7d oder 2w. Standard: dauerhaft",
+		"cmd.meowban.opt.reason.description":               "Grund der Sperre",
+		"cmd.meowban.opt.delete.description":               "Löscht die Nachrichten im Miau-Kanal, statt sie zu ignorieren",
+		"cmd.meowunban.name":                               "miau-entsperren",
+		"cmd.meowunban.description":                        "Lässt einen gesperrten Nutzer wieder mitspielen",
+		"cmd.meowunban.opt.user.description":               "Zu entsperrender Nutzer",
+		"cmd.audit.name":                                   "audit",
+		"cmd.audit.description":                            "Zeigt die letzten Admin-Aktionen auf diesem Server",
+		"cmd.audit.opt.actor.description":                  "Nur Aktionen dieses Nutzers zeigen",
+		"cmd.audit.opt.since.description":                  "Erster Tag (JJJJ-MM-TT, Server-Zeitzone)",
+		"cmd.audit.opt.until.description":                  "Letzter Tag (JJJJ-MM-TT, Server-Zeitzone)",
+		"cmd.privacy.name":                                 "datenschutz",
+		"cmd.privacy.description":                          "Sieh oder lösche, was Meow Bot über dich speichert",
+		"cmd.privacy.opt.export.name":                      "export",
+		"cmd.privacy.opt.export.description":               "Erhalte per DM alles, was Meow Bot über dich speichert",
+		"cmd.privacy.opt.delete.name":                      "loeschen",
+		"cmd.privacy.opt.delete.description":               "Lösche alles, was Meow Bot über dich speichert",
+		"cmd.privacy.opt.delete.opt.confirm.description":   "Auf true setzen, um deine Daten zu löschen. Nicht rückgängig zu machen",
+	},
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"libs/go/meowbot/util"
+	"time"
+)
+
+// userFromDiscord is the profile of u as the db stores it.
+func userFromDiscord(u *discordgo.User) db.User {
+	return db.User{ID: u.ID, Username: u.Username, DisplayName: u.GlobalName, Avatar: u.Avatar}
+}
+
+// memberFromDiscord is the membership of userID in guildID as the db stores
+// it. member.User isn't needed, as Discord leaves it out of messages.
+func memberFromDiscord(guildID, userID string, member *discordgo.Member) db.GuildMember {
+	gm := db.GuildMember{GuildID: guildID, UserID: userID, Nickname: member.Nick, UpdatedAt: time.Now()}
+	if !member.JoinedAt.IsZero() {
+		joined := member.JoinedAt
+		gm.JoinedAt = &joined
+	}
+	return gm
+}
+
+// upsertMember records the nickname and join date of a user in a guild. The
+// guild and user must already be stored.
+func (b *Bot) upsertMember(ctx context.Context, member db.GuildMember) {
+	if err := b.store.UpsertGuildMember(ctx, member); err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to upsert guild member", "guildID", member.GuildID, "userID", member.UserID, "error", err)
+	}
+}
+
+// isKnownUser reports whether userID has been stored, i.e. has played or
+// used a command. Profile events refresh those users only, so members who
+// never play aren't stored.
+func (b *Bot) isKnownUser(ctx context.Context, userID string) bool {
+	_, err := b.store.GetUserGlobalStats(ctx, userID)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		util.LoggerFrom(ctx).Error("❌ Failed to look up user", "userID", userID, "error", err)
+	}
+	return err == nil
+}
+
+// GuildMemberUpdateHandler refreshes the profile and the guild nickname of
+// known users when they change. It needs the Guild Members intent.
+func (b *Bot) GuildMemberUpdateHandler(ctx context.Context) func(*discordgo.Session, *discordgo.GuildMemberUpdate) {
+	h := Chain(b.handleMemberUpdate,
+		WithCorrelationID[*discordgo.GuildMemberUpdate](),
+		WithRecovery(memberUpdateName, nil),
+		WithTiming(memberUpdateName),
+	)
+	return func(s *discordgo.Session, e *discordgo.GuildMemberUpdate) {
+		h(ctx, s, e)
+	}
+}
+
+func (b *Bot) handleMemberUpdate(ctx context.Context, _ Discord, e *discordgo.GuildMemberUpdate) {
+	if e.Member == nil || e.User == nil || e.User.Bot || !b.isKnownUser(ctx, e.User.ID) {
+		return
+	}
+
+	b.upsertEntities(ctx, e.User, e.GuildID)
+	b.upsertMember(ctx, memberFromDiscord(e.GuildID, e.User.ID, e.Member))
+	util.LoggerFrom(ctx).Debug("👤 Guild member updated", "guildID", e.GuildID, "userID", e.User.ID)
+}
+
+// UserUpdateHandler refreshes the profile of a known user when it changes.
+// Discord sends it for the bot's own user; other users' profile changes
+// arrive as guild member updates.
+func (b *Bot) UserUpdateHandler(ctx context.Context) func(*discordgo.Session, *discordgo.UserUpdate) {
+	h := Chain(b.handleUserUpdate,
+		WithCorrelationID[*discordgo.UserUpdate](),
+		WithRecovery(userUpdateName, nil),
+		WithTiming(userUpdateName),
+	)
+	return func(s *discordgo.Session, e *discordgo.UserUpdate) {
+		h(ctx, s, e)
+	}
+}
+
+func (b *Bot) handleUserUpdate(ctx context.Context, _ Discord, e *discordgo.UserUpdate) {
+	if e.User == nil || !b.isKnownUser(ctx, e.User.ID) {
+		return
+	}
+
+	if err := b.store.UpsertUser(ctx, userFromDiscord(e.User)); err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to upsert user", "userID", e.User.ID, "error", err)
+		return
+	}
+	util.LoggerFrom(ctx).Debug("👤 User updated", "userID", e.User.ID)
+}
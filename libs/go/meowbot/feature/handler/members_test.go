@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"testing"
+	"time"
+)
+
+func TestUserFromDiscord(t *testing.T) {
+	u := userFromDiscord(&discordgo.User{ID: "u1", Username: "kitty", GlobalName: "Kitty", Avatar: "abc"})
+	want := db.User{ID: "u1", Username: "kitty", DisplayName: "Kitty", Avatar: "abc"}
+	if u != want {
+		t.Errorf("userFromDiscord = %+v, want %+v", u, want)
+	}
+}
+
+func TestMemberFromDiscord(t *testing.T) {
+	joined := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	m := memberFromDiscord("g1", "u1", &discordgo.Member{Nick: "Kit", JoinedAt: joined})
+	if m.GuildID != "g1" || m.UserID != "u1" || m.Nickname != "Kit" || m.JoinedAt == nil || !m.JoinedAt.Equal(joined) {
+		t.Errorf("memberFromDiscord = %+v", m)
+	}
+	if m := memberFromDiscord("g1", "u1", &discordgo.Member{}); m.JoinedAt != nil {
+		t.Errorf("JoinedAt = %v, want nil", m.JoinedAt)
+	}
+}
+
+func TestHandleMemberUpdate(t *testing.T) {
+	ctx := context.Background()
+	b := newTestBot()
+	if err := b.store.UpsertUser(ctx, db.User{ID: "u1", Username: "kitty"}); err != nil {
+		t.Fatal(err)
+	}
+
+	update := func(id, username, globalName, nick string) {
+		b.handleMemberUpdate(ctx, nil, &discordgo.GuildMemberUpdate{Member: &discordgo.Member{
+			GuildID: "g1",
+			User:    &discordgo.User{ID: id, Username: username, GlobalName: globalName},
+			Nick:    nick,
+		}})
+	}
+	update("u1", "kitty2", "Kitty", "Kit")
+	update("u2", "stranger", "", "Stranger")
+
+	users, err := b.store.GetAllUsers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || users[0].Username != "kitty2" || users[0].Name() != "Kitty" {
+		t.Fatalf("users = %+v, want only u1 renamed", users)
+	}
+	member, err := b.store.GetGuildMember(ctx, "g1", "u1")
+	if err != nil || member == nil || member.Nickname != "Kit" {
+		t.Errorf("GetGuildMember = %+v, %v; want nickname Kit", member, err)
+	}
+	history, err := b.store.GetUsernameHistory(ctx, "u1")
+	if err != nil || len(history) != 2 {
+		t.Errorf("GetUsernameHistory = %+v, %v; want kitty then kitty2", history, err)
+	}
+	if member, _ := b.store.GetGuildMember(ctx, "g1", "u2"); member != nil {
+		t.Errorf("unknown user's membership was stored: %+v", member)
+	}
+}
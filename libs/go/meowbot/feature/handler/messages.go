@@ -2,19 +2,19 @@ package handler
 
 import (
 	"context"
-	"fmt"
 	"github.com/bwmarrin/discordgo"
 	"libs/go/meowbot/feature/db"
 	"libs/go/meowbot/feature/state"
 	"libs/go/meowbot/util"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 )
 
 var meowRegex = regexp.MustCompile(`(?i)^m+e+o+w+$`)
 
-func sendMessage(s *discordgo.Session, channelID, message, guildID string) error {
+func sendMessage(s Discord, channelID, message, guildID string) error {
 	if !util.Cfg.IsAllowedGuild(guildID) {
 		util.Cfg.Logger.Debug("✉️ [DEV] Skipped sending message", "guildID", guildID, "channelID", channelID, "message", message)
 		return nil
@@ -26,7 +26,7 @@ func sendMessage(s *discordgo.Session, channelID, message, guildID string) error
 	return err
 }
 
-func safeReact(s *discordgo.Session, channelID, messageID, emoji, guildID string) {
+func safeReact(s Discord, channelID, messageID, emoji, guildID string) {
 	if !util.Cfg.IsAllowedGuild(guildID) {
 		util.Cfg.Logger.Debug("🔕 [DEV] Skipped reaction", "guildID", guildID, "emoji", emoji)
 		return
@@ -36,88 +36,129 @@ func safeReact(s *discordgo.Session, channelID, messageID, emoji, guildID string
 	}
 }
 
-func upsertEntities(ctx context.Context, user *discordgo.User, guildID string) {
-	if err := db.UpsertGuild(ctx, db.DB, db.Guild{ID: guildID}); err != nil {
-		util.Cfg.Logger.Error("❌ Failed to upsert guild", "guildID", guildID, "error", err)
+func safeDelete(s Discord, channelID, messageID, guildID string) {
+	if !util.Cfg.IsAllowedGuild(guildID) {
+		util.Cfg.Logger.Debug("🗑️ [DEV] Skipped deleting message", "guildID", guildID, "messageID", messageID)
+		return
+	}
+	if err := s.ChannelMessageDelete(channelID, messageID); err != nil {
+		util.Cfg.Logger.Warn("⚠️ Failed to delete message", "channelID", channelID, "messageID", messageID, "error", err)
+	}
+}
+
+func (b *Bot) upsertEntities(ctx context.Context, user *discordgo.User, guildID string) {
+	if err := b.store.UpsertGuild(ctx, db.Guild{ID: guildID}); err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to upsert guild", "guildID", guildID, "error", err)
 	}
-	if err := db.UpsertUser(ctx, db.DB, db.User{
-		ID:       user.ID,
-		Username: user.Username,
-	}); err != nil {
-		util.Cfg.Logger.Error("❌ Failed to upsert user", "userID", user.ID, "username", user.Username, "error", err)
+	if err := b.store.UpsertUser(ctx, userFromDiscord(user)); err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to upsert user", "userID", user.ID, "username", user.Username, "error", err)
 	}
 }
 
-func incrementMeow(ctx context.Context, guildID string, userID string, isMeow bool, timestamp time.Time) {
-	if err := db.IncrementMeow(ctx, db.DB, guildID, userID, isMeow, timestamp); err != nil {
-		util.Cfg.Logger.Error("❌ Failed to increment meow", "guildID", guildID, "userID", userID, "error", err)
+func (b *Bot) incrementMeow(ctx context.Context, guildID string, userID string, isMeow bool, timestamp time.Time) {
+	if err := b.store.IncrementMeow(ctx, guildID, userID, isMeow, timestamp); err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to increment meow", "guildID", guildID, "userID", userID, "error", err)
+	}
+	if err := b.store.IncrementSeasonMeow(ctx, guildID, userID, isMeow, timestamp); err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to increment season meow", "guildID", guildID, "userID", userID, "error", err)
 	}
 }
 
-func isInAllowedChannel(ctx context.Context, m *discordgo.MessageCreate) bool {
-	allowedChannelID, err := db.GetChannelForGuild(ctx, db.DB, m.GuildID)
+func (b *Bot) recordMeowEvent(ctx context.Context, m *discordgo.MessageCreate, outcome meowOutcome) {
+	err := b.store.RecordMeowEvent(ctx, db.MeowEvent{
+		GuildID:   m.GuildID,
+		UserID:    m.Author.ID,
+		Success:   outcome.Success,
+		Chain:     outcome.Chain,
+		Broken:    outcome.Broken,
+		HighScore: outcome.HighScore,
+		CreatedAt: m.Timestamp,
+	})
 	if err != nil {
-		util.Cfg.Logger.Error("❌ Could not fetch allowed channel", "guildID", m.GuildID, "channelID", m.ChannelID, "error", err)
+		util.LoggerFrom(ctx).Error("❌ Failed to record meow event", "guildID", m.GuildID, "userID", m.Author.ID, "error", err)
+	}
+}
+
+func (b *Bot) isInAllowedChannel(ctx context.Context, m *discordgo.MessageCreate) bool {
+	allowedChannelID, err := b.store.GetChannelForGuild(ctx, m.GuildID)
+	if err != nil {
+		util.LoggerFrom(ctx).Error("❌ Could not fetch allowed channel", "guildID", m.GuildID, "channelID", m.ChannelID, "error", err)
 		return false
 	}
 	if allowedChannelID == "" || m.ChannelID != allowedChannelID {
-		util.Cfg.Logger.Debug("🚫 Message in unauthorized channel", "guildID", m.GuildID, "channelID", m.ChannelID, "allowedChannelID", allowedChannelID)
+		util.LoggerFrom(ctx).Debug("🚫 Message in unauthorized channel", "guildID", m.GuildID, "channelID", m.ChannelID, "allowedChannelID", allowedChannelID)
 		return false
 	}
 	return true
 }
 
-func processMeowMessage(ctx context.Context, s *discordgo.Session, m *discordgo.MessageCreate) {
+func (b *Bot) processMeowMessage(ctx context.Context, s Discord, m *discordgo.MessageCreate) {
 	content := strings.ToLower(strings.TrimSpace(m.Content))
 	guildID := m.GuildID
 	user := m.Author
-	gs := state.GetOrCreate(ctx, guildID)
+	gs := b.guilds.GetOrCreate(ctx, guildID)
+	// Store the time as UTC; it is shown in the guild's timezone.
+	m.Timestamp = m.Timestamp.UTC()
 
-	util.Cfg.Logger.Info("📬 Message received", "guildID", guildID, "channelID", m.ChannelID, "userID", user.ID, "username", user.Username, "content", m.Content)
+	util.LoggerFrom(ctx).Info("📬 Message received", "guildID", guildID, "channelID", m.ChannelID, "userID", user.ID, "username", user.Username, "content", m.Content)
 
+	var outcome meowOutcome
 	if meowRegex.MatchString(content) {
-		handleMeow(ctx, s, m, gs)
+		outcome = b.handleMeow(ctx, s, m, gs)
 	} else {
-		handleNonMeow(ctx, s, m)
+		outcome = b.handleNonMeow(ctx, s, m, gs)
 	}
+
+	b.recordMeowEvent(ctx, m, outcome)
+	b.checkAchievements(ctx, s, m, outcome)
+	b.awardTreats(ctx, m, outcome)
+	b.notifyRankChanges(ctx, s, m)
+	b.notifyStreakMilestone(ctx, s, m, gs, outcome)
 }
 
-func handleMeow(ctx context.Context, s *discordgo.Session, m *discordgo.MessageCreate, gs *state.GuildState) {
+func (b *Bot) handleMeow(ctx context.Context, s Discord, m *discordgo.MessageCreate, gs *state.GuildState) meowOutcome {
 	user := m.Author
 	guildID := m.GuildID
+	tr := b.localizerForGuild(ctx, guildID)
 
 	if user.ID == gs.LastUserID {
-		incrementMeow(ctx, guildID, user.ID, false, m.Timestamp)
+		outcome := meowOutcome{Broken: gs.MeowCount}
+		b.incrementMeow(ctx, guildID, user.ID, false, m.Timestamp)
 		safeReact(s, m.ChannelID, m.ID, "❌", guildID)
-		err := sendMessage(s, m.ChannelID, "😾 You can't meow twice in a row!", guildID)
+		err := sendMessage(s, m.ChannelID, tr.T("meow.repeat"), guildID)
 		if err != nil {
-			return
+			return outcome
 		}
-		util.Cfg.Logger.Warn("🔂 Repeat meow", "guildID", guildID, "userID", user.ID)
-		state.Reset(guildID)
-		return
+		util.LoggerFrom(ctx).Warn("🔂 Repeat meow", "guildID", guildID, "userID", user.ID)
+		b.guilds.Reset(guildID)
+		return outcome
 	}
 
 	gs.MeowCount++
+	outcome := meowOutcome{Success: true, Chain: gs.MeowCount}
 	if gs.MeowCount > gs.HighScore {
 		gs.HighScore = gs.MeowCount
 		gs.HighScoreUserID = user.ID
-		err := sendMessage(s, m.ChannelID, fmt.Sprintf("🏆 New high score: %d meows by %s!", gs.HighScore, user.Username), guildID)
+		outcome.HighScore = true
+		err := sendMessage(s, m.ChannelID, tr.T("meow.highscore", gs.HighScore, user.Username), guildID)
 		if err != nil {
-			return
+			return outcome
 		}
-		util.Cfg.Logger.Info("🏆 New high score", "guildID", guildID, "userID", user.ID, "score", gs.HighScore)
+		util.LoggerFrom(ctx).Info("🏆 New high score", "guildID", guildID, "userID", user.ID, "score", gs.HighScore)
 	}
 
 	gs.LastUserID = user.ID
-	incrementMeow(ctx, guildID, user.ID, true, m.Timestamp)
-	err := sendMessage(s, m.ChannelID, fmt.Sprintf("%s **meow** x%d!", util.RandomEmoji(), gs.MeowCount), guildID)
+	if !slices.Contains(gs.Participants, user.ID) {
+		gs.Participants = append(gs.Participants, user.ID)
+	}
+	b.incrementMeow(ctx, guildID, user.ID, true, m.Timestamp)
+	err := sendMessage(s, m.ChannelID, tr.T("meow.count", util.RandomEmoji(), gs.MeowCount), guildID)
 	if err != nil {
-		return
+		return outcome
 	}
-	safeReact(s, m.ChannelID, m.ID, "🐱", guildID)
+	safeReact(s, m.ChannelID, m.ID, b.meowReaction(ctx, guildID, user.ID), guildID)
 
-	err = db.UpsertGuildStreak(ctx, db.DB, db.GuildStreak{
+	err = b.store.UpsertGuildStreak(ctx, db.GuildStreak{
 		GuildID:         guildID,
 		MeowCount:       gs.MeowCount,
 		LastUserID:      &gs.LastUserID,
@@ -125,45 +166,65 @@ func handleMeow(ctx context.Context, s *discordgo.Session, m *discordgo.MessageC
 		HighScoreUserID: &gs.HighScoreUserID,
 	})
 	if err != nil {
-		util.Cfg.Logger.Error("❌ Failed to upsert guild streak", "guildID", guildID, "error", err)
-		return
+		util.LoggerFrom(ctx).Error("❌ Failed to upsert guild streak", "guildID", guildID, "error", err)
 	}
+	return outcome
 }
 
-func handleNonMeow(ctx context.Context, s *discordgo.Session, m *discordgo.MessageCreate) {
+func (b *Bot) handleNonMeow(ctx context.Context, s Discord, m *discordgo.MessageCreate, gs *state.GuildState) meowOutcome {
 	user := m.Author
 	guildID := m.GuildID
+	tr := b.localizerForGuild(ctx, guildID)
+	outcome := meowOutcome{Broken: gs.MeowCount}
 
 	safeReact(s, m.ChannelID, m.ID, "❌", guildID)
-	err := sendMessage(s, m.ChannelID, "❌ No meow? Resetting.", guildID)
+	err := sendMessage(s, m.ChannelID, tr.T("meow.reset"), guildID)
 	if err != nil {
-		return
+		return outcome
 	}
-	incrementMeow(ctx, guildID, user.ID, false, m.Timestamp)
-	state.Reset(guildID)
+	b.incrementMeow(ctx, guildID, user.ID, false, m.Timestamp)
+	b.guilds.Reset(guildID)
 
-	util.Cfg.Logger.Info("🔄 Reset triggered", "guildID", guildID, "userID", user.ID)
+	util.LoggerFrom(ctx).Info("🔄 Reset triggered", "guildID", guildID, "userID", user.ID)
+	return outcome
 }
 
 func logIgnoreBotMessage(m *discordgo.MessageCreate) {
 	util.Cfg.Logger.Debug("🤖 Ignored bot message", "guildID", m.GuildID, "channelID", m.ChannelID)
 }
 
-func MessageHandler(ctx context.Context) func(*discordgo.Session, *discordgo.MessageCreate) {
+func (b *Bot) MessageHandler(ctx context.Context) func(*discordgo.Session, *discordgo.MessageCreate) {
+	h := Chain(b.handleMessage,
+		WithCorrelationID[*discordgo.MessageCreate](),
+		WithRecovery(messageName, nil),
+		WithTiming(messageName),
+	)
 	return func(s *discordgo.Session, m *discordgo.MessageCreate) {
-		// skip bot messages
-		if m.Author.Bot {
-			logIgnoreBotMessage(m)
-			return
-		}
+		h(ctx, s, m)
+	}
+}
 
-		if !isInAllowedChannel(ctx, m) {
-			return
-		}
+func (b *Bot) handleMessage(ctx context.Context, s Discord, m *discordgo.MessageCreate) {
+	// skip bot messages
+	if m.Author.Bot {
+		logIgnoreBotMessage(m)
+		return
+	}
 
-		// Upsert user + guild
-		upsertEntities(ctx, m.Author, m.GuildID)
+	if !b.isInAllowedChannel(ctx, m) {
+		return
+	}
 
-		processMeowMessage(ctx, s, m)
+	// Banned users can't touch the streak
+	if b.rejectBanned(ctx, s, m) {
+		return
 	}
+
+	// Upsert user + guild
+	b.upsertEntities(ctx, m.Author, m.GuildID)
+	if m.Member != nil {
+		b.upsertMember(ctx, memberFromDiscord(m.GuildID, m.Author.ID, m.Member))
+	}
+
+	b.processMeowMessage(ctx, s, m)
 }
@@ -1,8 +1,16 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"libs/go/meowbot/util"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestMeowRegex(t *testing.T) {
@@ -31,3 +39,170 @@ func TestMeowRegex(t *testing.T) {
 		}
 	}
 }
+
+// testMessage is a message posted in guild g1; channel defaults to the meow
+// channel c1.
+type testMessage struct {
+	author  string
+	content string
+	channel string
+	bot     bool
+}
+
+func TestMeowFlow(t *testing.T) {
+	ctx := context.Background()
+	cfg := util.Cfg
+	t.Cleanup(func() {
+		util.Cfg = cfg
+		util.InitEmojis()
+	})
+	util.Cfg.IsProd = true
+	util.Cfg.EmojiList = "😺"
+	util.InitEmojis()
+
+	const (
+		highScore1 = "c1: 🏆 New high score: 1 meows by u1!"
+		count1     = "c1: 😺 **meow** x1!"
+		firstMeow1 = "c1: 🐣 **u1** unlocked **First Meow** — Landed your first successful meow."
+		highScore2 = "c1: 🏆 New high score: 2 meows by u2!"
+		count2     = "c1: 😺 **meow** x2!"
+		firstMeow2 = "c1: 🐣 **u2** unlocked **First Meow** — Landed your first successful meow."
+		repeat     = "c1: 😾 You can't meow twice in a row!"
+		reset      = "c1: ❌ No meow? Resetting."
+	)
+
+	tests := []struct {
+		name          string
+		setup         func(t *testing.T, store db.Store)
+		messages      []testMessage
+		wantMessages  []string
+		wantReactions []string
+		wantDeleted   []string
+		wantChain     int
+		wantHighScore int
+		// wantStats holds each user's successful and failed meows.
+		wantStats map[string][2]int
+	}{
+		{
+			name:          "chain",
+			messages:      []testMessage{{author: "u1", content: "meow"}, {author: "u2", content: "MEEOOW"}},
+			wantMessages:  []string{highScore1, count1, firstMeow1, highScore2, count2, firstMeow2},
+			wantReactions: []string{"m1 🐱", "m2 🐱"},
+			wantChain:     2,
+			wantHighScore: 2,
+			wantStats:     map[string][2]int{"u1": {1, 0}, "u2": {1, 0}},
+		},
+		{
+			name:          "meowing twice in a row",
+			messages:      []testMessage{{author: "u1", content: "meow"}, {author: "u1", content: "meow"}},
+			wantMessages:  []string{highScore1, count1, firstMeow1, repeat},
+			wantReactions: []string{"m1 🐱", "m2 ❌"},
+			wantChain:     0,
+			wantHighScore: 1,
+			wantStats:     map[string][2]int{"u1": {1, 1}},
+		},
+		{
+			name:          "other message resets",
+			messages:      []testMessage{{author: "u1", content: "meow"}, {author: "u2", content: "woof"}, {author: "u1", content: "meow"}},
+			wantMessages:  []string{highScore1, count1, firstMeow1, reset, count1},
+			wantReactions: []string{"m1 🐱", "m2 ❌", "m3 🐱"},
+			wantChain:     1,
+			wantHighScore: 1,
+			wantStats:     map[string][2]int{"u1": {2, 0}, "u2": {0, 1}},
+		},
+		{
+			name:      "other channel ignored",
+			messages:  []testMessage{{author: "u1", content: "meow", channel: "c2"}, {author: "u2", content: "woof", channel: "c2"}},
+			wantStats: map[string][2]int{"u1": {0, 0}, "u2": {0, 0}},
+		},
+		{
+			name:      "bots ignored",
+			messages:  []testMessage{{author: "u1", content: "meow", bot: true}},
+			wantStats: map[string][2]int{"u1": {0, 0}},
+		},
+		{
+			name: "banned user's message deleted",
+			setup: func(t *testing.T, store db.Store) {
+				if err := store.UpsertGuild(ctx, db.Guild{ID: "g1"}); err != nil {
+					t.Fatal(err)
+				}
+				if err := store.UpsertUser(ctx, db.User{ID: "u2", Username: "u2"}); err != nil {
+					t.Fatal(err)
+				}
+				ban := db.MeowBan{GuildID: "g1", UserID: "u2", DeleteMessages: true, CreatedAt: time.Now()}
+				if err := store.BanUser(ctx, ban); err != nil {
+					t.Fatal(err)
+				}
+			},
+			messages:      []testMessage{{author: "u1", content: "meow"}, {author: "u2", content: "meow"}},
+			wantMessages:  []string{highScore1, count1, firstMeow1},
+			wantReactions: []string{"m1 🐱"},
+			wantDeleted:   []string{"m2"},
+			wantChain:     1,
+			wantHighScore: 1,
+			wantStats:     map[string][2]int{"u1": {1, 0}, "u2": {0, 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestBot()
+			if err := b.store.UpsertGuildChannel(ctx, "g1", "c1"); err != nil {
+				t.Fatal(err)
+			}
+			if tt.setup != nil {
+				tt.setup(t, b.store)
+			}
+
+			s := &fakeDiscord{}
+			start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+			for i, msg := range tt.messages {
+				channel := msg.channel
+				if channel == "" {
+					channel = "c1"
+				}
+				b.handleMessage(ctx, s, &discordgo.MessageCreate{Message: &discordgo.Message{
+					ID:        "m" + strconv.Itoa(i+1),
+					GuildID:   "g1",
+					ChannelID: channel,
+					Content:   msg.content,
+					Author:    &discordgo.User{ID: msg.author, Username: msg.author, Bot: msg.bot},
+					Timestamp: start.Add(time.Duration(i) * time.Minute),
+				}})
+			}
+
+			if got := s.Messages(); !slices.Equal(got, tt.wantMessages) {
+				t.Errorf("messages = %q, want %q", got, tt.wantMessages)
+			}
+			if got := s.Reactions(); !slices.Equal(got, tt.wantReactions) {
+				t.Errorf("reactions = %q, want %q", got, tt.wantReactions)
+			}
+			if got := s.Deleted(); !slices.Equal(got, tt.wantDeleted) {
+				t.Errorf("deleted = %q, want %q", got, tt.wantDeleted)
+			}
+			if got := b.guilds.GetOrCreate(ctx, "g1").MeowCount; got != tt.wantChain {
+				t.Errorf("chain = %d, want %d", got, tt.wantChain)
+			}
+			streak, err := b.store.GetGuildStreak(ctx, "g1")
+			if err != nil && !errors.Is(err, db.ErrNotFound) {
+				t.Fatal(err)
+			}
+			highScore := 0
+			if streak != nil {
+				highScore = streak.HighScore
+			}
+			if highScore != tt.wantHighScore {
+				t.Errorf("stored high score = %d, want %d", highScore, tt.wantHighScore)
+			}
+			guildID := "g1"
+			for userID, want := range tt.wantStats {
+				stats, err := b.store.GetUserStats(ctx, &guildID, userID)
+				if err != nil && !errors.Is(err, db.ErrNotFound) {
+					t.Fatal(err)
+				}
+				if got := [2]int{stats.SuccessfulMeows, stats.FailedMeows}; got != want {
+					t.Errorf("%s successful and failed meows = %v, want %v", userID, got, want)
+				}
+			}
+		})
+	}
+}
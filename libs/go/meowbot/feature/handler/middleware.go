@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/util"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// HandlerFunc handles a single Discord event of type E.
+type HandlerFunc[E any] func(ctx context.Context, s Discord, e E)
+
+// Middleware wraps a HandlerFunc with cross-cutting behavior.
+type Middleware[E any] func(next HandlerFunc[E]) HandlerFunc[E]
+
+// Chain wraps h with the given middleware. The first middleware is the outermost.
+func Chain[E any](h HandlerFunc[E], mws ...Middleware[E]) HandlerFunc[E] {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// WithCorrelationID attaches a fresh correlation ID to the context so that
+// every log line, including those from the db layer, can be traced back to
+// the event that caused it.
+func WithCorrelationID[E any]() Middleware[E] {
+	return func(next HandlerFunc[E]) HandlerFunc[E] {
+		return func(ctx context.Context, s Discord, e E) {
+			if util.CorrelationID(ctx) == "" {
+				ctx = util.WithCorrelationID(ctx, util.NewCorrelationID())
+			}
+			next(ctx, s, e)
+		}
+	}
+}
+
+// WithRecovery recovers from panics in the wrapped handler, logs them with a
+// stack trace and calls onPanic (if set) so the user can be told something
+// went wrong.
+func WithRecovery[E any](name func(E) string, onPanic HandlerFunc[E]) Middleware[E] {
+	return func(next HandlerFunc[E]) HandlerFunc[E] {
+		return func(ctx context.Context, s Discord, e E) {
+			defer func() {
+				if r := recover(); r != nil {
+					util.LoggerFrom(ctx).Error("💥 Recovered from panic in handler",
+						"handler", name(e),
+						"panic", fmt.Sprint(r),
+						"stack", string(debug.Stack()),
+					)
+					if onPanic != nil {
+						onPanic(ctx, s, e)
+					}
+				}
+			}()
+			next(ctx, s, e)
+		}
+	}
+}
+
+// WithTiming logs how long the wrapped handler took.
+func WithTiming[E any](name func(E) string) Middleware[E] {
+	return func(next HandlerFunc[E]) HandlerFunc[E] {
+		return func(ctx context.Context, s Discord, e E) {
+			start := time.Now()
+			next(ctx, s, e)
+			util.LoggerFrom(ctx).Debug("⏱️ Handler finished", "handler", name(e), "duration", time.Since(start))
+		}
+	}
+}
+
+// WithCooldown rejects commands a user runs again before the window has passed.
+func WithCooldown(cooldowns *Cooldowns, window time.Duration) Middleware[*discordgo.InteractionCreate] {
+	return func(next CommandFunc) CommandFunc {
+		if window <= 0 {
+			return next
+		}
+		return func(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+			name := interactionName(i)
+			ok, remaining := cooldowns.Allow(interactionUserID(i), name, window)
+			if !ok {
+				util.LoggerFrom(ctx).Debug("⏳ Command on cooldown", "command", name, "userID", interactionUserID(i), "remaining", remaining)
+				tr := localizerFor(ctx, i)
+				embed := formatSimpleEmbed(tr.T("error.cooldown.title"), tr.T("error.cooldown.desc", name, remaining.Round(time.Second)), 0xFEE75C)
+				sendResponseEmbed(ctx, s, i, embed, i.GuildID, name)
+				return
+			}
+			next(ctx, s, i)
+		}
+	}
+}
+
+// Cooldowns tracks when each user may run each command again.
+type Cooldowns struct {
+	mu    sync.Mutex
+	until map[string]time.Time
+	now   func() time.Time
+}
+
+func NewCooldowns() *Cooldowns {
+	return &Cooldowns{
+		until: make(map[string]time.Time),
+		now:   time.Now,
+	}
+}
+
+// Allow reports whether userID may run command now, and if not, how long they
+// have to wait. An allowed call starts a new cooldown window.
+func (c *Cooldowns) Allow(userID, command string, window time.Duration) (bool, time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	key := userID + ":" + command
+	if until, ok := c.until[key]; ok && now.Before(until) {
+		return false, until.Sub(now)
+	}
+
+	if len(c.until) > 1024 {
+		for k, until := range c.until {
+			if !now.Before(until) {
+				delete(c.until, k)
+			}
+		}
+	}
+
+	c.until[key] = now.Add(window)
+	return true, 0
+}
+
+// respondPanic tells the user their interaction failed after a recovered panic.
+func respondPanic(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	tr := localizerFor(ctx, i)
+	embed := formatSimpleEmbed(tr.T("error.panic.title"), tr.T("error.panic.desc")+"\n\n"+tr.T("error.incident", incidentID(ctx)), 0xED4245)
+	sendResponseEmbed(ctx, s, i, embed, i.GuildID, interactionName(i))
+}
+
+// interactionName returns the slash command name or component CustomID of an interaction.
+func interactionName(i *discordgo.InteractionCreate) string {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		return i.ApplicationCommandData().Name
+	case discordgo.InteractionMessageComponent:
+		return i.MessageComponentData().CustomID
+	default:
+		return i.Type.String()
+	}
+}
+
+// interactionUserID returns the ID of the user who triggered the interaction,
+// whether it came from a guild (Member) or a DM (User).
+func interactionUserID(i *discordgo.InteractionCreate) string {
+	if user := interactionUser(i); user != nil {
+		return user.ID
+	}
+	return ""
+}
+
+// interactionUser is the user who triggered the interaction, in a guild or a DM.
+func interactionUser(i *discordgo.InteractionCreate) *discordgo.User {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User
+	}
+	return i.User
+}
+
+func messageName(*discordgo.MessageCreate) string {
+	return "message"
+}
+
+func guildCreateName(*discordgo.GuildCreate) string {
+	return "guildCreate"
+}
+
+func guildUpdateName(*discordgo.GuildUpdate) string {
+	return "guildUpdate"
+}
+
+func guildDeleteName(*discordgo.GuildDelete) string {
+	return "guildDelete"
+}
+
+func memberUpdateName(*discordgo.GuildMemberUpdate) string {
+	return "guildMemberUpdate"
+}
+
+func userUpdateName(*discordgo.UserUpdate) string {
+	return "userUpdate"
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/util"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestChainOrder(t *testing.T) {
+	var calls []string
+	mw := func(name string) Middleware[string] {
+		return func(next HandlerFunc[string]) HandlerFunc[string] {
+			return func(ctx context.Context, s Discord, e string) {
+				calls = append(calls, name)
+				next(ctx, s, e)
+			}
+		}
+	}
+
+	h := Chain(func(context.Context, Discord, string) {
+		calls = append(calls, "handler")
+	}, mw("outer"), mw("inner"))
+	h(context.Background(), nil, "event")
+
+	want := []string{"outer", "inner", "handler"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestWithRecovery(t *testing.T) {
+	var recovered bool
+	h := Chain(func(context.Context, Discord, string) {
+		var m *discordgo.Member
+		_ = m.User.ID // nil dereference, like a DM interaction without a Member
+	}, WithRecovery(func(string) string { return "test" }, func(context.Context, Discord, string) {
+		recovered = true
+	}))
+
+	h(context.Background(), nil, "event")
+
+	if !recovered {
+		t.Error("expected onPanic to be called")
+	}
+}
+
+func TestWithCorrelationID(t *testing.T) {
+	var got string
+	h := Chain(func(ctx context.Context, _ Discord, _ string) {
+		got = util.CorrelationID(ctx)
+	}, WithCorrelationID[string]())
+
+	h(context.Background(), nil, "event")
+	if got == "" {
+		t.Error("expected a correlation ID in the context")
+	}
+
+	h(util.WithCorrelationID(context.Background(), "existing"), nil, "event")
+	if got != "existing" {
+		t.Errorf("expected existing correlation ID to be kept, got %q", got)
+	}
+}
+
+func TestCooldowns(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewCooldowns()
+	c.now = func() time.Time { return now }
+
+	if ok, _ := c.Allow("u1", "stats", 3*time.Second); !ok {
+		t.Fatal("first call should be allowed")
+	}
+
+	now = now.Add(time.Second)
+	ok, remaining := c.Allow("u1", "stats", 3*time.Second)
+	if ok {
+		t.Fatal("second call within the window should be rejected")
+	}
+	if remaining != 2*time.Second {
+		t.Errorf("remaining = %s, want 2s", remaining)
+	}
+
+	if ok, _ := c.Allow("u2", "stats", 3*time.Second); !ok {
+		t.Error("cooldowns should be per user")
+	}
+	if ok, _ := c.Allow("u1", "leaderboard", 3*time.Second); !ok {
+		t.Error("cooldowns should be per command")
+	}
+
+	now = now.Add(2 * time.Second)
+	if ok, _ := c.Allow("u1", "stats", 3*time.Second); !ok {
+		t.Error("call after the window should be allowed")
+	}
+}
+
+func TestInteractionUserID(t *testing.T) {
+	guild := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		Member: &discordgo.Member{User: &discordgo.User{ID: "member"}},
+	}}
+	dm := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		User: &discordgo.User{ID: "dm-user"},
+	}}
+
+	if got := interactionUserID(guild); got != "member" {
+		t.Errorf("guild interaction user = %q, want member", got)
+	}
+	if got := interactionUserID(dm); got != "dm-user" {
+		t.Errorf("DM interaction user = %q, want dm-user", got)
+	}
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"context"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"libs/go/meowbot/feature/state"
+	"libs/go/meowbot/util"
+	"time"
+)
+
+const (
+	notifyRank   = "rank"
+	notifyStreak = "streak"
+	notifyAll    = "all"
+
+	// notifyThrottle is the minimum time between two DMs to the same user.
+	notifyThrottle = 10 * time.Minute
+	// streakAlertMargin is how close to the record a chain has to get before
+	// the users who built it are told.
+	streakAlertMargin = 5
+)
+
+func (b *Bot) handleNotify(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	tr := localizerFor(ctx, i)
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return
+	}
+
+	sub := options[0]
+	kind := notifyAll
+	for _, opt := range sub.Options {
+		if opt.Name == "type" {
+			kind = opt.StringValue()
+		}
+	}
+
+	user := interactionUser(i)
+	if user == nil {
+		return
+	}
+	prefs, err := b.store.GetNotificationPrefs(ctx, user.ID)
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("notify.error.title"), tr.T("notify.error.desc"), i.GuildID, "notify", err)
+		return
+	}
+
+	switch sub.Name {
+	case "status":
+		embed := formatSimpleEmbed(tr.T("notify.status.title"), formatNotifyStatus(tr, prefs))
+		sendResponseEmbed(ctx, s, i, embed, i.GuildID, "notify")
+		return
+	case "on", "off":
+		setNotifyPrefs(prefs, kind, sub.Name == "on")
+	default:
+		return
+	}
+
+	// Preferences reference the user, who may never have meowed yet.
+	if err := b.store.UpsertUser(ctx, userFromDiscord(user)); err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("notify.error.title"), tr.T("notify.error.desc"), i.GuildID, "notify", err)
+		return
+	}
+	if err := b.store.UpsertNotificationPrefs(ctx, *prefs); err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("notify.error.title"), tr.T("notify.error.desc"), i.GuildID, "notify", err)
+		return
+	}
+
+	util.LoggerFrom(ctx).Info("🔔 Notification prefs updated", "userID", user.ID, "rankChanges", prefs.RankChanges, "streakMilestones", prefs.StreakMilestones)
+	sendSuccessEmbed(ctx, s, i, tr.T("notify.title"), formatNotifyStatus(tr, prefs), i.GuildID, "notify")
+}
+
+// notifyTypeOption is the notification type option of /notify on and off.
+func notifyTypeOption() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "type",
+		Description: "Which notifications. Defaults to all",
+		Required:    false,
+		Choices: []*discordgo.ApplicationCommandOptionChoice{
+			{Name: "Leaderboard changes", Value: notifyRank},
+			{Name: "Streak milestones", Value: notifyStreak},
+			{Name: "All", Value: notifyAll},
+		},
+	}
+}
+
+func setNotifyPrefs(prefs *db.NotificationPrefs, kind string, enabled bool) {
+	if kind == notifyRank || kind == notifyAll {
+		prefs.RankChanges = enabled
+	}
+	if kind == notifyStreak || kind == notifyAll {
+		prefs.StreakMilestones = enabled
+	}
+}
+
+func formatNotifyStatus(tr Localizer, prefs *db.NotificationPrefs) string {
+	status := func(enabled bool) string {
+		if enabled {
+			return tr.T("notify.on")
+		}
+		return tr.T("notify.off")
+	}
+	return tr.T("notify.status", status(prefs.RankChanges), status(prefs.StreakMilestones))
+}
+
+// notifyRankChanges DMs the guild's rank watchers who dropped on the total
+// meows leaderboard since their rank was last seen, i.e. whom the author of m
+// just passed. It runs on every meow, so the watchers come with their current
+// ranks from a single query.
+func (b *Bot) notifyRankChanges(ctx context.Context, s Discord, m *discordgo.MessageCreate) {
+	guildID := m.GuildID
+	watchers, err := b.store.GetRankWatchers(ctx, guildID)
+	if err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to fetch rank watchers", "guildID", guildID, "error", err)
+		return
+	}
+
+	for _, w := range watchers {
+		if w.Rank == w.LastRank {
+			continue
+		}
+		if err := b.store.SetWatchedRank(ctx, guildID, w.UserID, w.Rank); err != nil {
+			util.LoggerFrom(ctx).Error("❌ Failed to store watched rank", "guildID", guildID, "userID", w.UserID, "error", err)
+		}
+		if w.LastRank == 0 || w.Rank < w.LastRank || w.UserID == m.Author.ID {
+			continue
+		}
+
+		tr := b.localizerForGuild(ctx, guildID)
+		b.notifyUser(ctx, s, w.UserID, guildID, tr.T("notify.rank.passed", m.Author.Username, b.guildName(ctx, guildID), w.Rank, w.LastRank))
+	}
+}
+
+// notifyStreakMilestone DMs the users who built the current chain once it is
+// streakAlertMargin meows away from the guild's record.
+func (b *Bot) notifyStreakMilestone(ctx context.Context, s Discord, m *discordgo.MessageCreate, gs *state.GuildState, outcome meowOutcome) {
+	if !outcome.Success || outcome.Chain < streakAlertMargin || gs.HighScore-outcome.Chain != streakAlertMargin {
+		return
+	}
+
+	tr := b.localizerForGuild(ctx, m.GuildID)
+	message := tr.T("notify.streak.close", b.guildName(ctx, m.GuildID), outcome.Chain, streakAlertMargin, gs.HighScore)
+	for _, userID := range gs.Participants {
+		if userID == m.Author.ID {
+			continue
+		}
+		prefs, err := b.store.GetNotificationPrefs(ctx, userID)
+		if err != nil {
+			util.LoggerFrom(ctx).Error("❌ Failed to fetch notification prefs", "userID", userID, "error", err)
+			continue
+		}
+		if prefs.StreakMilestones {
+			b.notifyUser(ctx, s, userID, m.GuildID, message)
+		}
+	}
+}
+
+// notifyUser DMs the user unless they were notified within notifyThrottle.
+func (b *Bot) notifyUser(ctx context.Context, s Discord, userID, guildID, message string) {
+	claimed, err := b.store.ClaimNotification(ctx, userID, time.Now(), notifyThrottle)
+	if err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to claim notification", "userID", userID, "error", err)
+		return
+	}
+	if !claimed {
+		util.LoggerFrom(ctx).Debug("🔕 Notification throttled", "userID", userID)
+		return
+	}
+	if err := sendDirectMessage(s, userID, message, guildID); err == nil {
+		util.LoggerFrom(ctx).Info("🔔 Notification sent", "userID", userID, "guildID", guildID)
+	}
+}
+
+func sendDirectMessage(s Discord, userID, message, guildID string) error {
+	if !util.Cfg.IsAllowedGuild(guildID) {
+		util.Cfg.Logger.Debug("✉️ [DEV] Skipped sending DM", "guildID", guildID, "userID", userID, "message", message)
+		return nil
+	}
+	channel, err := s.UserChannelCreate(userID)
+	if err != nil {
+		util.Cfg.Logger.Warn("⚠️ Failed to open DM channel", "userID", userID, "error", err)
+		return err
+	}
+	return sendMessage(s, channel.ID, message, guildID)
+}
+
+// guildName is the guild's stored name, or its ID if it has none.
+func (b *Bot) guildName(ctx context.Context, guildID string) string {
+	if guild, err := b.store.GetGuild(ctx, guildID); err == nil && guild.Name != "" {
+		return guild.Name
+	}
+	return guildID
+}
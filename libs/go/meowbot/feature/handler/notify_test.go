@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"libs/go/meowbot/feature/state"
+	"libs/go/meowbot/util"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSetNotifyPrefs(t *testing.T) {
+	tests := []struct {
+		kind    string
+		enabled bool
+		start   db.NotificationPrefs
+		want    db.NotificationPrefs
+	}{
+		{notifyAll, true, db.NotificationPrefs{}, db.NotificationPrefs{RankChanges: true, StreakMilestones: true}},
+		{notifyRank, true, db.NotificationPrefs{}, db.NotificationPrefs{RankChanges: true}},
+		{notifyStreak, false, db.NotificationPrefs{RankChanges: true, StreakMilestones: true}, db.NotificationPrefs{RankChanges: true}},
+		{notifyAll, false, db.NotificationPrefs{RankChanges: true, StreakMilestones: true}, db.NotificationPrefs{}},
+	}
+	for _, tc := range tests {
+		prefs := tc.start
+		setNotifyPrefs(&prefs, tc.kind, tc.enabled)
+		if prefs != tc.want {
+			t.Errorf("setNotifyPrefs(%v, %q, %v) = %+v, want %+v", tc.start, tc.kind, tc.enabled, prefs, tc.want)
+		}
+	}
+}
+
+func TestFormatNotifyStatus(t *testing.T) {
+	got := formatNotifyStatus(Localizer{Locale: defaultLocale}, &db.NotificationPrefs{RankChanges: true})
+	if want := "Leaderboard changes: ✅ on\nStreak milestones: ❌ off"; got != want {
+		t.Errorf("formatNotifyStatus() = %q, want %q", got, want)
+	}
+}
+
+// newNotifyBot returns a Bot whose guild g1, "Cats", has users u1 to u4 with
+// the given notification preferences, and a fake that records the DMs.
+func newNotifyBot(t *testing.T, prefs ...db.NotificationPrefs) (*Bot, *fakeDiscord) {
+	t.Helper()
+	ctx := context.Background()
+	cfg := util.Cfg
+	t.Cleanup(func() { util.Cfg = cfg })
+	util.Cfg.IsProd = true
+
+	b := newTestBot()
+	if err := b.store.SyncGuild(ctx, db.Guild{ID: "g1", Name: "Cats"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"u1", "u2", "u3", "u4"} {
+		if err := b.store.UpsertUser(ctx, db.User{ID: id, Username: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, p := range prefs {
+		if err := b.store.UpsertNotificationPrefs(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return b, &fakeDiscord{}
+}
+
+// meowAs counts n successful meows by the user and runs the rank check for
+// the last of them.
+func meowAs(t *testing.T, b *Bot, s *fakeDiscord, userID string, n int) {
+	t.Helper()
+	ctx := context.Background()
+	for range n {
+		if err := b.store.IncrementMeow(ctx, "g1", userID, true, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b.notifyRankChanges(ctx, s, &discordgo.MessageCreate{Message: &discordgo.Message{
+		GuildID: "g1",
+		Author:  &discordgo.User{ID: userID, Username: userID},
+	}})
+}
+
+func TestNotifyRankChanges(t *testing.T) {
+	b, s := newNotifyBot(t,
+		db.NotificationPrefs{UserID: "u1", RankChanges: true},
+		db.NotificationPrefs{UserID: "u2", RankChanges: true},
+		db.NotificationPrefs{UserID: "u3", StreakMilestones: true},
+	)
+
+	// u2 leads u3 leads u1. First sightings of a rank aren't news.
+	meowAs(t, b, s, "u2", 3)
+	meowAs(t, b, s, "u3", 2)
+	meowAs(t, b, s, "u1", 1)
+	if got := s.Messages(); len(got) != 0 {
+		t.Fatalf("DMs before anyone was passed = %q, want none", got)
+	}
+
+	// u1 passes u3, who doesn't watch ranks, and then u2. u1 moved up and is
+	// the author, so only u2 hears of it.
+	meowAs(t, b, s, "u1", 4)
+	want := []string{"dm-u2: 📉 **u1** just passed you on the **Cats** leaderboard! You're now #2 (was #1)."}
+	if got := s.Messages(); !slices.Equal(got, want) {
+		t.Errorf("DMs = %q, want %q", got, want)
+	}
+
+	// u2 takes the lead back, and u1 is passed in turn.
+	meowAs(t, b, s, "u2", 3)
+	want = append(want, "dm-u1: 📉 **u2** just passed you on the **Cats** leaderboard! You're now #2 (was #1).")
+	if got := s.Messages(); !slices.Equal(got, want) {
+		t.Errorf("DMs = %q, want %q", got, want)
+	}
+
+	// u1 passes u2 again within notifyThrottle: u2's rank is updated, but
+	// no second DM is sent.
+	meowAs(t, b, s, "u1", 2)
+	if got := s.Messages(); !slices.Equal(got, want) {
+		t.Errorf("DMs = %q, want %q: u2 was already notified within the throttle", got, want)
+	}
+	watchers, err := b.store.GetRankWatchers(context.Background(), "g1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range watchers {
+		if w.LastRank != w.Rank {
+			t.Errorf("%s last seen rank = %d, want the current %d", w.UserID, w.LastRank, w.Rank)
+		}
+	}
+}
+
+func TestNotifyStreakMilestone(t *testing.T) {
+	ctx := context.Background()
+	b, s := newNotifyBot(t,
+		db.NotificationPrefs{UserID: "u1", StreakMilestones: true},
+		db.NotificationPrefs{UserID: "u2", RankChanges: true},
+		db.NotificationPrefs{UserID: "u3", StreakMilestones: true},
+	)
+	gs := &state.GuildState{HighScore: 10, Participants: []string{"u1", "u2", "u3", "u4"}}
+	m := &discordgo.MessageCreate{Message: &discordgo.Message{GuildID: "g1", Author: &discordgo.User{ID: "u3", Username: "u3"}}}
+
+	// Only a successful meow exactly streakAlertMargin short of the record counts.
+	for _, outcome := range []meowOutcome{
+		{Success: true, Chain: 4},
+		{Success: true, Chain: 6},
+		{Success: false, Broken: 5},
+	} {
+		b.notifyStreakMilestone(ctx, s, m, gs, outcome)
+	}
+	if got := s.Messages(); len(got) != 0 {
+		t.Fatalf("DMs away from the margin = %q, want none", got)
+	}
+
+	// u3 is the author, u2 only watches ranks and u4 has no preferences.
+	b.notifyStreakMilestone(ctx, s, m, gs, meowOutcome{Success: true, Chain: 5})
+	want := []string{"dm-u1: 🔥 A chain you helped build in **Cats** is at **5** meows, just 5 away from the record of 10!"}
+	if got := s.Messages(); !slices.Equal(got, want) {
+		t.Errorf("DMs = %q, want %q", got, want)
+	}
+}
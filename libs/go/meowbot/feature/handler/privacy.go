@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"libs/go/meowbot/util"
+)
+
+func (b *Bot) handlePrivacy(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	options := i.ApplicationCommandData().Options
+	user := interactionUser(i)
+	if len(options) == 0 || user == nil {
+		return
+	}
+
+	switch sub := options[0]; sub.Name {
+	case "export":
+		b.handlePrivacyExport(ctx, s, i, user)
+	case "delete":
+		confirm := false
+		for _, opt := range sub.Options {
+			if opt.Name == "confirm" {
+				confirm = opt.BoolValue()
+			}
+		}
+		b.handlePrivacyDelete(ctx, s, i, user, confirm)
+	}
+}
+
+// handlePrivacyExport DMs the user a JSON file with every row stored about
+// them.
+func (b *Bot) handlePrivacyExport(ctx context.Context, s Discord, i *discordgo.InteractionCreate, user *discordgo.User) {
+	tr := localizerFor(ctx, i)
+
+	data, err := b.store.ExportUserData(ctx, user.ID)
+	if errors.Is(err, db.ErrNotFound) {
+		sendResponseEmbed(ctx, s, i, formatSimpleEmbed(tr.T("privacy.export.title"), tr.T("privacy.none")), i.GuildID, "privacy")
+		return
+	}
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("privacy.export.error.title"), tr.T("privacy.export.error.desc"), i.GuildID, "privacy", err)
+		return
+	}
+
+	body, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("privacy.export.error.title"), tr.T("privacy.export.error.desc"), i.GuildID, "privacy", err)
+		return
+	}
+	file := &discordgo.File{Name: "meowbot-data-" + user.ID + ".json", ContentType: "application/json", Reader: bytes.NewReader(body)}
+	if err := sendDirectFile(s, user.ID, tr.T("privacy.export.dm"), file, i.GuildID); err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("privacy.export.error.title"), tr.T("privacy.export.dm.error"), i.GuildID, "privacy", err)
+		return
+	}
+
+	util.LoggerFrom(ctx).Info("📦 Exported user data", "userID", user.ID, "tables", len(data.Tables))
+	sendResponseEmbed(ctx, s, i, formatSimpleEmbed(tr.T("privacy.export.title"), tr.T("privacy.export.sent")), i.GuildID, "privacy")
+}
+
+// handlePrivacyDelete deletes the user's data once they confirm.
+func (b *Bot) handlePrivacyDelete(ctx context.Context, s Discord, i *discordgo.InteractionCreate, user *discordgo.User, confirm bool) {
+	tr := localizerFor(ctx, i)
+	if !confirm {
+		sendResponseEmbed(ctx, s, i, formatSimpleEmbed(tr.T("privacy.delete.title"), tr.T("privacy.delete.confirm")), i.GuildID, "privacy")
+		return
+	}
+
+	anonID, err := b.store.DeleteUserData(ctx, user.ID)
+	if errors.Is(err, db.ErrNotFound) {
+		sendResponseEmbed(ctx, s, i, formatSimpleEmbed(tr.T("privacy.delete.title"), tr.T("privacy.none")), i.GuildID, "privacy")
+		return
+	}
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("privacy.delete.error.title"), tr.T("privacy.delete.error.desc"), i.GuildID, "privacy", err)
+		return
+	}
+	b.ForgetUser(user.ID, anonID)
+
+	util.LoggerFrom(ctx).Info("🗑️ Deleted user data", "userID", user.ID)
+	sendResponseEmbed(ctx, s, i, formatSimpleEmbed(tr.T("privacy.delete.title"), tr.T("privacy.delete.done")), i.GuildID, "privacy")
+}
+
+// ForgetUser replaces a user whose data was deleted with their stand-in in
+// the bot's in-memory guild state, so running chains don't write the user
+// back.
+func (b *Bot) ForgetUser(userID, anonID string) {
+	b.guilds.ReplaceUser(userID, anonID)
+}
+
+func sendDirectFile(s Discord, userID, message string, file *discordgo.File, guildID string) error {
+	if !util.Cfg.IsAllowedGuild(guildID) {
+		util.Cfg.Logger.Debug("✉️ [DEV] Skipped sending DM", "guildID", guildID, "userID", userID, "file", file.Name)
+		return nil
+	}
+	channel, err := s.UserChannelCreate(userID)
+	if err != nil {
+		util.Cfg.Logger.Warn("⚠️ Failed to open DM channel", "userID", userID, "error", err)
+		return err
+	}
+	_, err = s.ChannelMessageSendComplex(channel.ID, &discordgo.MessageSend{Content: message, Files: []*discordgo.File{file}})
+	return err
+}
@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"libs/go/meowbot/util"
+	"strings"
+	"time"
+)
+
+const (
+	recapDaily   = "daily"
+	recapWeekly  = "weekly"
+	recapTopSize = 3
+
+	recapDateLayout = "2006-01-02"
+)
+
+// recapPeriod is the last complete recap period before now: the previous day,
+// or the previous Monday-to-Sunday week, in loc.
+func recapPeriod(frequency string, now time.Time, loc *time.Location) (time.Time, time.Time) {
+	local := now.In(loc)
+	end := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	if frequency == recapWeekly {
+		daysSinceMonday := (int(end.Weekday()) + 6) % 7
+		end = end.AddDate(0, 0, -daysSinceMonday)
+		return end.AddDate(0, 0, -7), end
+	}
+	return end.AddDate(0, 0, -1), end
+}
+
+func (b *Bot) handleRecap(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	tr := localizerFor(ctx, i)
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return
+	}
+
+	sub := options[0]
+	switch sub.Name {
+	case "enable":
+		b.handleRecapEnable(ctx, s, i, tr, sub.Options)
+	case "disable":
+		b.handleRecapDisable(ctx, s, i, tr)
+	}
+}
+
+func (b *Bot) handleRecapEnable(ctx context.Context, s Discord, i *discordgo.InteractionCreate, tr Localizer, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	guildID := i.GuildID
+	frequency := recapWeekly
+	var channelID, timezone string
+	for _, opt := range options {
+		switch opt.Name {
+		case "frequency":
+			frequency = opt.StringValue()
+		case "channel":
+			channelID = opt.ChannelValue(nil).ID
+		case "timezone":
+			timezone = strings.TrimSpace(opt.StringValue())
+		}
+	}
+
+	loc := b.guildLocation(ctx, guildID)
+	if timezone != "" {
+		var err error
+		if loc, err = loadTimezone(timezone); err != nil {
+			embed := formatSimpleEmbed(tr.T("recap.error.title"), tr.T("timezone.invalid", timezone), 0xED4245)
+			sendResponseEmbed(ctx, s, i, embed, guildID, "recap")
+			return
+		}
+		previous := b.guildLocation(ctx, guildID)
+		if err := b.store.UpsertGuildTimezone(ctx, guildID, loc.String()); err != nil {
+			sendErrorEmbed(ctx, s, i, tr.T("recap.error.title"), tr.T("recap.error.desc"), guildID, "recap", err)
+			return
+		}
+		b.recordAudit(ctx, i, auditTimezoneSet, "", previous.String(), loc.String())
+	}
+
+	previous, err := b.store.GetGuildRecapSettings(ctx, guildID)
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("recap.error.title"), tr.T("recap.error.desc"), guildID, "recap", err)
+		return
+	}
+
+	// Only periods that end after opting in are recapped.
+	_, end := recapPeriod(frequency, time.Now(), loc)
+	settings := db.RecapSettings{
+		GuildID:       guildID,
+		Frequency:     frequency,
+		ChannelID:     channelID,
+		LastPeriodEnd: &end,
+	}
+	if err := b.store.UpsertRecapSettings(ctx, settings); err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("recap.error.title"), tr.T("recap.error.desc"), guildID, "recap", err)
+		return
+	}
+	b.recordAudit(ctx, i, auditRecapEnable, "", recapAuditValue(previous), recapAuditValue(&settings))
+
+	channel := tr.T("recap.channel.game")
+	if channelID != "" {
+		channel = fmt.Sprintf("<#%s>", channelID)
+	}
+	util.LoggerFrom(ctx).Info("📰 Recaps enabled", "guildID", guildID, "frequency", frequency, "channelID", channelID, "timezone", loc.String())
+	sendSuccessEmbed(ctx, s, i, tr.T("recap.enable.title"), tr.T("recap.enable.desc", tr.T("recap.frequency."+frequency), channel, loc.String()), guildID, "recap")
+}
+
+func (b *Bot) handleRecapDisable(ctx context.Context, s Discord, i *discordgo.InteractionCreate, tr Localizer) {
+	previous, err := b.store.GetGuildRecapSettings(ctx, i.GuildID)
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("recap.error.title"), tr.T("recap.error.desc"), i.GuildID, "recap", err)
+		return
+	}
+	deleted, err := b.store.DeleteRecapSettings(ctx, i.GuildID)
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("recap.error.title"), tr.T("recap.error.desc"), i.GuildID, "recap", err)
+		return
+	}
+	if !deleted {
+		embed := formatSimpleEmbed(tr.T("recap.disable.title"), tr.T("recap.disable.none"), 0xFEE75C)
+		sendResponseEmbed(ctx, s, i, embed, i.GuildID, "recap")
+		return
+	}
+
+	b.recordAudit(ctx, i, auditRecapDisable, "", recapAuditValue(previous), nil)
+	util.LoggerFrom(ctx).Info("📰 Recaps disabled", "guildID", i.GuildID)
+	sendSuccessEmbed(ctx, s, i, tr.T("recap.disable.title"), tr.T("recap.disable.desc"), i.GuildID, "recap")
+}
+
+// recapAuditValue is what the audit log records of recap settings.
+func recapAuditValue(rs *db.RecapSettings) any {
+	if rs == nil {
+		return nil
+	}
+	return struct {
+		Frequency string `json:"frequency"`
+		ChannelID string `json:"channel_id,omitempty"`
+	}{rs.Frequency, rs.ChannelID}
+}
+
+// RunRecaps posts the daily or weekly recap of every guild that opted in once
+// its period is over in the guild's timezone. It checks every interval until
+// ctx is cancelled.
+func (b *Bot) RunRecaps(ctx context.Context, s Discord, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		b.postDueRecaps(util.WithCorrelationID(ctx, util.NewCorrelationID()), s, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (b *Bot) postDueRecaps(ctx context.Context, s Discord, now time.Time) {
+	settings, err := b.store.GetRecapSettings(ctx)
+	if err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to fetch recap settings", "error", err)
+		return
+	}
+
+	for _, rs := range settings {
+		loc := b.guildLocation(ctx, rs.GuildID)
+		start, end := recapPeriod(rs.Frequency, now, loc)
+		if rs.LastPeriodEnd != nil && !end.After(*rs.LastPeriodEnd) {
+			continue
+		}
+
+		// Claim the period before posting, so a restart or a second instance
+		// never posts it again.
+		claimed, err := b.store.ClaimRecapPeriod(ctx, rs.GuildID, end)
+		if err != nil {
+			util.LoggerFrom(ctx).Error("❌ Failed to claim recap period", "guildID", rs.GuildID, "periodEnd", end, "error", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		b.postGuildRecap(ctx, s, rs, start, end, loc)
+	}
+}
+
+// postGuildRecap posts the recap of [start, end) to the guild's recap channel,
+// or its meow channel if none is set.
+func (b *Bot) postGuildRecap(ctx context.Context, s Discord, rs db.RecapSettings, start, end time.Time, loc *time.Location) {
+	recap, err := b.store.GetGuildRecap(ctx, rs.GuildID, start, end, recapTopSize)
+	if err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to build recap", "guildID", rs.GuildID, "error", err)
+		return
+	}
+
+	channelID := rs.ChannelID
+	if channelID == "" {
+		if channelID, err = b.store.GetChannelForGuild(ctx, rs.GuildID); err != nil || channelID == "" {
+			util.LoggerFrom(ctx).Warn("⚠️ No channel to post recap in", "guildID", rs.GuildID, "error", err)
+			return
+		}
+	}
+
+	tr := b.localizerForGuild(ctx, rs.GuildID)
+	if err := sendMessage(s, channelID, formatGuildRecap(tr, rs.Frequency, recap, loc), rs.GuildID); err == nil {
+		util.LoggerFrom(ctx).Info("📰 Recap posted", "guildID", rs.GuildID, "frequency", rs.Frequency, "start", start, "end", end)
+	}
+}
+
+func formatGuildRecap(tr Localizer, frequency string, recap *db.GuildRecap, loc *time.Location) string {
+	first := recap.Start.In(loc).Format(recapDateLayout)
+	last := recap.End.In(loc).AddDate(0, 0, -1).Format(recapDateLayout)
+	period := first
+	if first != last {
+		period = tr.T("recap.range", first, last)
+	}
+
+	var b strings.Builder
+	b.WriteString(tr.T("recap.title."+frequency, period))
+	if recap.TotalMeows == 0 {
+		b.WriteString("\n")
+		b.WriteString(tr.T("recap.empty"))
+		return b.String()
+	}
+
+	b.WriteString("\n")
+	b.WriteString(tr.T("recap.meows", recap.TotalMeows, recap.SuccessfulMeows))
+	b.WriteString("\n")
+	b.WriteString(tr.T("recap.streak", recap.BestStreak))
+	if recap.NewRecords > 0 {
+		b.WriteString("\n")
+		b.WriteString(tr.T("recap.records", recap.NewRecords))
+	}
+
+	if len(recap.TopContributors) > 0 {
+		medals := []string{"🥇", "🥈", "🥉"}
+		b.WriteString("\n")
+		b.WriteString(tr.T("recap.top"))
+		for i, entry := range recap.TopContributors {
+			medal := fmt.Sprintf("%d.", i+1)
+			if i < len(medals) {
+				medal = medals[i]
+			}
+			b.WriteString("\n")
+			b.WriteString(tr.T("recap.top.line", medal, entry.User.Name(), entry.SuccessfulMeows))
+		}
+	}
+
+	if recap.Breaker != nil {
+		b.WriteString("\n")
+		b.WriteString(tr.T("recap.breaker", recap.Breaker.Name(), recap.BrokenStreak))
+	}
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"libs/go/meowbot/feature/db"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecapPeriod(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	// Wednesday 2025-06-11 00:30 in Berlin is still Tuesday in UTC.
+	now := time.Date(2025, 6, 10, 22, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		frequency  string
+		loc        *time.Location
+		start, end time.Time
+	}{
+		{"daily utc", recapDaily, time.UTC, time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC), time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)},
+		{"daily berlin", recapDaily, berlin, time.Date(2025, 6, 10, 0, 0, 0, 0, berlin), time.Date(2025, 6, 11, 0, 0, 0, 0, berlin)},
+		{"weekly utc", recapWeekly, time.UTC, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC)},
+		{"weekly berlin", recapWeekly, berlin, time.Date(2025, 6, 2, 0, 0, 0, 0, berlin), time.Date(2025, 6, 9, 0, 0, 0, 0, berlin)},
+	}
+	for _, tc := range tests {
+		start, end := recapPeriod(tc.frequency, now, tc.loc)
+		if !start.Equal(tc.start) || !end.Equal(tc.end) {
+			t.Errorf("%s: recapPeriod() = [%v, %v), want [%v, %v)", tc.name, start, end, tc.start, tc.end)
+		}
+	}
+
+	// On a Monday the week that just ended is recapped.
+	monday := time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC)
+	if start, end := recapPeriod(recapWeekly, monday, time.UTC); !end.Equal(monday) || !start.Equal(monday.AddDate(0, 0, -7)) {
+		t.Errorf("recapPeriod(weekly, Monday) = [%v, %v)", start, end)
+	}
+}
+
+func TestFormatGuildRecap(t *testing.T) {
+	tr := Localizer{Locale: defaultLocale}
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	recap := &db.GuildRecap{
+		Start:           start,
+		End:             start.AddDate(0, 0, 7),
+		TotalMeows:      30,
+		SuccessfulMeows: 27,
+		BestStreak:      12,
+		NewRecords:      1,
+		TopContributors: []db.LeaderboardEntry{
+			{User: &db.User{Username: "alice"}, SuccessfulMeows: 15},
+			{User: &db.User{Username: "bob"}, SuccessfulMeows: 12},
+		},
+		Breaker:      &db.User{Username: "mallory"},
+		BrokenStreak: 9,
+	}
+
+	got := formatGuildRecap(tr, recapWeekly, recap, time.UTC)
+	for _, want := range []string{
+		"Weekly meow recap** — 2025-06-02 → 2025-06-08",
+		"**30** meows (27 successful)",
+		"Best streak: **12**",
+		"New high scores: **1**",
+		"🥇 **alice** — 15 meows",
+		"🥈 **bob** — 12 meows",
+		"**mallory** (broke a chain of 9)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("recap missing %q:\n%s", want, got)
+		}
+	}
+
+	daily := &db.GuildRecap{Start: start, End: start.AddDate(0, 0, 1)}
+	got = formatGuildRecap(tr, recapDaily, daily, time.UTC)
+	if !strings.Contains(got, "Daily meow recap** — 2025-06-02\n") || !strings.Contains(got, "Nobody meowed") {
+		t.Errorf("empty daily recap = %q", got)
+	}
+}
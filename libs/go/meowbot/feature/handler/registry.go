@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"context"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/util"
+	"strings"
+	"time"
+)
+
+// CommandFunc handles a single slash command or component interaction.
+type CommandFunc = HandlerFunc[*discordgo.InteractionCreate]
+
+// Command declares everything the bot needs to know about a slash command:
+// its Discord definition, how to handle it, who may run it and which
+// message components it owns.
+type Command struct {
+	Definition *discordgo.ApplicationCommand
+	Handler    CommandFunc
+
+	// Permission is the set of permission bits a member needs to run the
+	// command. Zero means anyone can use it.
+	Permission int64
+
+	// Cooldown is how long a user has to wait before running the command
+	// again. Zero disables the cooldown.
+	Cooldown time.Duration
+
+	// Timeout bounds how long the command may run before its context is
+	// cancelled. Zero uses defaultCommandTimeout.
+	Timeout time.Duration
+
+	// ComponentPrefixes lists the CustomID prefixes (e.g. "lb_") of message
+	// components created by this command. Matching interactions are routed
+	// to ComponentHandler.
+	ComponentPrefixes []string
+	ComponentHandler  CommandFunc
+}
+
+// Registry holds the set of commands the bot exposes.
+type Registry struct {
+	commands  []*Command
+	byName    map[string]*Command
+	cooldowns *Cooldowns
+}
+
+// NewRegistry builds a registry from the given commands. Commands with a
+// Permission also get it set as their DefaultMemberPermissions so Discord
+// hides them from members who can't use them, and every definition gets its
+// name and description localizations from the message catalog.
+func NewRegistry(cmds ...*Command) *Registry {
+	r := &Registry{
+		byName:    make(map[string]*Command, len(cmds)),
+		cooldowns: NewCooldowns(),
+	}
+	for _, cmd := range cmds {
+		if cmd.Permission != 0 && cmd.Definition.DefaultMemberPermissions == nil {
+			perm := cmd.Permission
+			cmd.Definition.DefaultMemberPermissions = &perm
+		}
+		localizeCommand(cmd.Definition)
+		r.commands = append(r.commands, cmd)
+		r.byName[cmd.Definition.Name] = cmd
+	}
+	return r
+}
+
+// Commands returns the registered commands in registration order.
+func (r *Registry) Commands() []*Command {
+	return r.commands
+}
+
+// Lookup finds a command by its slash command name.
+func (r *Registry) Lookup(name string) (*Command, bool) {
+	cmd, ok := r.byName[name]
+	return cmd, ok
+}
+
+// LookupComponent finds the command owning a message component CustomID.
+func (r *Registry) LookupComponent(customID string) (*Command, bool) {
+	for _, cmd := range r.commands {
+		for _, prefix := range cmd.ComponentPrefixes {
+			if strings.HasPrefix(customID, prefix) {
+				return cmd, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Definitions returns the Discord definitions of every registered command.
+func (r *Registry) Definitions() []*discordgo.ApplicationCommand {
+	defs := make([]*discordgo.ApplicationCommand, 0, len(r.commands))
+	for _, cmd := range r.commands {
+		defs = append(defs, cmd.Definition)
+	}
+	return defs
+}
+
+// hasPermission reports whether the interacting member holds the command's
+// required permission bits. Administrators can run everything.
+func hasPermission(i *discordgo.InteractionCreate, required int64) bool {
+	if required == 0 {
+		return true
+	}
+	if i.Member == nil {
+		return false
+	}
+	if i.Member.Permissions&discordgo.PermissionAdministrator != 0 {
+		return true
+	}
+	return i.Member.Permissions&required == required
+}
+
+// eventMiddleware is wrapped around every interaction the bot receives.
+func (b *Bot) eventMiddleware() []Middleware[*discordgo.InteractionCreate] {
+	return []Middleware[*discordgo.InteractionCreate]{
+		WithCorrelationID[*discordgo.InteractionCreate](),
+		withResponder(),
+		b.withLocalizer(),
+		WithRecovery(interactionName, respondPanic),
+		WithTiming(interactionName),
+	}
+}
+
+// CommandHandler routes slash commands to their registered handler
+func (b *Bot) CommandHandler(ctx context.Context, registry *Registry) func(*discordgo.Session, *discordgo.InteractionCreate) {
+	h := Chain(registry.dispatchCommand, b.eventMiddleware()...)
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		if i.Type != discordgo.InteractionApplicationCommand {
+			return
+		}
+		h(ctx, s, i)
+	}
+}
+
+// ComponentHandler routes message component interactions to the command that owns them
+func (b *Bot) ComponentHandler(ctx context.Context, registry *Registry) func(*discordgo.Session, *discordgo.InteractionCreate) {
+	h := Chain(registry.dispatchComponent, b.eventMiddleware()...)
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		if i.Type != discordgo.InteractionMessageComponent {
+			return
+		}
+		h(ctx, s, i)
+	}
+}
+
+func (r *Registry) dispatchCommand(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	name := i.ApplicationCommandData().Name
+	cmd, ok := r.Lookup(name)
+	if !ok {
+		util.LoggerFrom(ctx).Warn("⚠️ Unknown command", "guildID", i.GuildID, "command", name)
+		return
+	}
+
+	if !hasPermission(i, cmd.Permission) {
+		tr := localizerFor(ctx, i)
+		embed := formatSimpleEmbed(tr.T("error.permission.title"), tr.T("error.permission.desc"))
+		sendResponseEmbed(ctx, s, i, embed, i.GuildID, name)
+		return
+	}
+
+	Chain(cmd.Handler,
+		WithCooldown(r.cooldowns, cmd.Cooldown),
+		WithTimeout(cmd.timeout()),
+		WithDeferral(deferThreshold),
+	)(ctx, s, i)
+}
+
+func (r *Registry) dispatchComponent(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	customID := i.MessageComponentData().CustomID
+	cmd, ok := r.LookupComponent(customID)
+	if !ok || cmd.ComponentHandler == nil {
+		util.LoggerFrom(ctx).Warn("⚠️ Unknown component", "guildID", i.GuildID, "customID", customID)
+		return
+	}
+
+	Chain(cmd.ComponentHandler,
+		WithTimeout(cmd.timeout()),
+		WithDeferral(deferThreshold),
+	)(ctx, s, i)
+}
+
+func (c *Command) timeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return defaultCommandTimeout
+}
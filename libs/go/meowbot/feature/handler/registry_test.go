@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"github.com/bwmarrin/discordgo"
+	"testing"
+)
+
+func noopCommand(context.Context, Discord, *discordgo.InteractionCreate) {}
+
+func TestRegistryLookup(t *testing.T) {
+	r := NewRegistry(
+		&Command{Definition: &discordgo.ApplicationCommand{Name: "count"}, Handler: noopCommand},
+		&Command{
+			Definition:        &discordgo.ApplicationCommand{Name: "leaderboard"},
+			Handler:           noopCommand,
+			ComponentPrefixes: []string{"lb_"},
+			ComponentHandler:  noopCommand,
+		},
+	)
+
+	if _, ok := r.Lookup("count"); !ok {
+		t.Error("expected to find /count")
+	}
+	if _, ok := r.Lookup("missing"); ok {
+		t.Error("expected /missing to be unknown")
+	}
+
+	cmd, ok := r.LookupComponent("lb_next:1:guild:total")
+	if !ok || cmd.Definition.Name != "leaderboard" {
+		t.Errorf("expected lb_ component to route to /leaderboard, got %v", cmd)
+	}
+	if _, ok := r.LookupComponent("other:1"); ok {
+		t.Error("expected unknown component prefix to be unrouted")
+	}
+
+	if len(r.Definitions()) != 2 {
+		t.Errorf("expected 2 definitions, got %d", len(r.Definitions()))
+	}
+}
+
+func TestNewRegistrySetsDefaultMemberPermissions(t *testing.T) {
+	r := NewRegistry(&Command{
+		Definition: &discordgo.ApplicationCommand{Name: "setup"},
+		Handler:    noopCommand,
+		Permission: discordgo.PermissionAdministrator,
+	})
+
+	cmd, _ := r.Lookup("setup")
+	if cmd.Definition.DefaultMemberPermissions == nil || *cmd.Definition.DefaultMemberPermissions != discordgo.PermissionAdministrator {
+		t.Errorf("expected DefaultMemberPermissions to be set to administrator, got %v", cmd.Definition.DefaultMemberPermissions)
+	}
+}
+
+func TestHasPermission(t *testing.T) {
+	member := func(perms int64) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			Member: &discordgo.Member{Permissions: perms},
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		i        *discordgo.InteractionCreate
+		required int64
+		want     bool
+	}{
+		{"no requirement", member(0), 0, true},
+		{"missing permission", member(discordgo.PermissionSendMessages), discordgo.PermissionManageServer, false},
+		{"has permission", member(discordgo.PermissionManageServer), discordgo.PermissionManageServer, true},
+		{"administrator", member(discordgo.PermissionAdministrator), discordgo.PermissionManageServer, true},
+		{"no member", &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{}}, discordgo.PermissionManageServer, false},
+	}
+
+	for _, tt := range tests {
+		if got := hasPermission(tt.i, tt.required); got != tt.want {
+			t.Errorf("%s: hasPermission() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultRegistryCommandsAreUnique(t *testing.T) {
+	seen := map[string]bool{}
+	for _, cmd := range newTestBot().NewDefaultRegistry().Commands() {
+		if seen[cmd.Definition.Name] {
+			t.Errorf("duplicate command %q", cmd.Definition.Name)
+		}
+		seen[cmd.Definition.Name] = true
+		if cmd.Handler == nil {
+			t.Errorf("command %q has no handler", cmd.Definition.Name)
+		}
+	}
+}
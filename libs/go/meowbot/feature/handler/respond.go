@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"context"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/util"
+	"sync"
+	"time"
+)
+
+var (
+	// deferThreshold is how long a command may run before the bot defers
+	// its response. Discord fails interactions not answered within 3 seconds.
+	deferThreshold = 2 * time.Second
+
+	// defaultCommandTimeout bounds how long a command may spend on its work,
+	// including DB queries, when it doesn't declare its own Timeout.
+	defaultCommandTimeout = 10 * time.Second
+)
+
+type responderKey struct{}
+
+// responder tracks how far along an interaction's response is, so replies go
+// out as an initial response, an edit of a deferred response, or a follow-up.
+type responder struct {
+	mu        sync.Mutex
+	deferred  bool
+	responded bool
+}
+
+func responderFrom(ctx context.Context) *responder {
+	r, _ := ctx.Value(responderKey{}).(*responder)
+	return r
+}
+
+// withResponder attaches a responder to the interaction's context.
+func withResponder() Middleware[*discordgo.InteractionCreate] {
+	return func(next CommandFunc) CommandFunc {
+		return func(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+			if responderFrom(ctx) == nil {
+				ctx = context.WithValue(ctx, responderKey{}, &responder{})
+			}
+			next(ctx, s, i)
+		}
+	}
+}
+
+// WithDeferral defers the interaction response if the wrapped handler hasn't
+// responded within threshold. The handler's eventual response then edits the
+// deferred one instead.
+func WithDeferral(threshold time.Duration) Middleware[*discordgo.InteractionCreate] {
+	return func(next CommandFunc) CommandFunc {
+		return func(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+			r := responderFrom(ctx)
+			if r == nil {
+				r = &responder{}
+				ctx = context.WithValue(ctx, responderKey{}, r)
+			}
+
+			timer := time.AfterFunc(threshold, func() {
+				r.deferResponse(ctx, s, i)
+			})
+			defer timer.Stop()
+
+			next(ctx, s, i)
+		}
+	}
+}
+
+// WithTimeout gives the wrapped handler a context that is cancelled after d,
+// which in turn cancels any DB queries still in flight.
+func WithTimeout(d time.Duration) Middleware[*discordgo.InteractionCreate] {
+	return func(next CommandFunc) CommandFunc {
+		return func(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			next(ctx, s, i)
+		}
+	}
+}
+
+func (r *responder) deferResponse(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.responded || r.deferred {
+		return
+	}
+
+	respType := discordgo.InteractionResponseDeferredChannelMessageWithSource
+	if i.Type == discordgo.InteractionMessageComponent {
+		respType = discordgo.InteractionResponseDeferredMessageUpdate
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: respType,
+		Data: &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral},
+	})
+	if err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to defer interaction response", "guildID", i.GuildID, "error", err)
+		return
+	}
+	r.deferred = true
+	util.LoggerFrom(ctx).Debug("⏳ Deferred interaction response", "guildID", i.GuildID, "interaction", interactionName(i))
+}
+
+// respond replies to an interaction. The first reply is sent as the initial
+// response (or as an edit, if the response was deferred); any later replies
+// are sent as follow-up messages.
+func respond(ctx context.Context, s Discord, i *discordgo.InteractionCreate, data *discordgo.InteractionResponseData) error {
+	respType := discordgo.InteractionResponseChannelMessageWithSource
+	if i.Type == discordgo.InteractionMessageComponent {
+		respType = discordgo.InteractionResponseUpdateMessage
+	}
+
+	r := responderFrom(ctx)
+	if r == nil {
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{Type: respType, Data: data})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch {
+	case r.responded:
+		_, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content:    data.Content,
+			Embeds:     data.Embeds,
+			Components: data.Components,
+			Flags:      data.Flags,
+		})
+		return err
+	case r.deferred:
+		edit := &discordgo.WebhookEdit{
+			Embeds:     &data.Embeds,
+			Components: &data.Components,
+		}
+		if data.Content != "" {
+			edit.Content = &data.Content
+		}
+		if _, err := s.InteractionResponseEdit(i.Interaction, edit); err != nil {
+			return err
+		}
+		r.responded = true
+		return nil
+	default:
+		if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{Type: respType, Data: data}); err != nil {
+			return err
+		}
+		r.responded = true
+		return nil
+	}
+}
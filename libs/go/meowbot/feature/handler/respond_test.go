@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"github.com/bwmarrin/discordgo"
+	"testing"
+	"time"
+)
+
+func newTestInteraction() *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:      "i1",
+		AppID:   "app",
+		Token:   "tok",
+		Type:    discordgo.InteractionApplicationCommand,
+		Data:    discordgo.ApplicationCommandInteractionData{Name: "slow"},
+		GuildID: "g1",
+	}}
+}
+
+func TestWithDeferral_FastHandlerRespondsDirectly(t *testing.T) {
+	s := &fakeDiscord{}
+	h := Chain(func(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+		_ = respond(ctx, s, i, &discordgo.InteractionResponseData{Content: "hi"})
+	}, WithDeferral(time.Second))
+
+	h(context.Background(), s, newTestInteraction())
+
+	responses := s.Responses()
+	if len(responses) != 1 || responses[0].Type != discordgo.InteractionResponseChannelMessageWithSource || responses[0].Data.Content != "hi" {
+		t.Errorf("expected a single initial response, got %+v", responses)
+	}
+	if edits, followups := s.Edits(), s.Followups(); len(edits) != 0 || len(followups) != 0 {
+		t.Errorf("expected no edits or follow-ups, got %d and %d", len(edits), len(followups))
+	}
+}
+
+func TestWithDeferral_SlowHandlerDefersThenEdits(t *testing.T) {
+	s := &fakeDiscord{}
+	h := Chain(func(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+		time.Sleep(50 * time.Millisecond)
+		_ = respond(ctx, s, i, &discordgo.InteractionResponseData{Content: "done"})
+		_ = respond(ctx, s, i, &discordgo.InteractionResponseData{Content: "one more thing"})
+	}, WithDeferral(10*time.Millisecond))
+
+	h(context.Background(), s, newTestInteraction())
+
+	responses := s.Responses()
+	if len(responses) != 1 || responses[0].Type != discordgo.InteractionResponseDeferredChannelMessageWithSource {
+		t.Fatalf("expected a deferred response first, got %+v", responses)
+	}
+	edits := s.Edits()
+	if len(edits) != 1 || edits[0].Content == nil || *edits[0].Content != "done" {
+		t.Errorf("expected an edit of the original response, got %+v", edits)
+	}
+	followups := s.Followups()
+	if len(followups) != 1 || followups[0].Content != "one more thing" {
+		t.Errorf("expected a follow-up message, got %+v", followups)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	var deadline time.Time
+	var ok bool
+	h := Chain(func(ctx context.Context, _ Discord, _ *discordgo.InteractionCreate) {
+		deadline, ok = ctx.Deadline()
+	}, WithTimeout(time.Minute))
+
+	h(context.Background(), nil, newTestInteraction())
+
+	if !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("expected a deadline within a minute, got %v (ok=%v)", deadline, ok)
+	}
+}
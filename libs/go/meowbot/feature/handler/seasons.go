@@ -0,0 +1,320 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"libs/go/meowbot/util"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	seasonDateLayout = "2006-01-02"
+	seasonRecapSize  = 3
+)
+
+// resolveSeason turns a season parameter into a season of the guild or a
+// global one. "" means all-time (nil season), "current" the season running
+// now, and anything else a season ID.
+func (b *Bot) resolveSeason(ctx context.Context, guildID, param string) (*db.Season, error) {
+	switch param {
+	case "":
+		return nil, nil
+	case "current":
+		season, err := b.store.GetActiveSeason(ctx, &guildID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if season == nil {
+			return nil, fmt.Errorf("%w: no active season", db.ErrNotFound)
+		}
+		return season, nil
+	}
+
+	id, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid season %q", db.ErrInvalidInput, param)
+	}
+	season, err := b.store.GetSeason(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if season.GuildID != nil && *season.GuildID != guildID {
+		return nil, fmt.Errorf("%w: season %d belongs to another guild", db.ErrNotFound, id)
+	}
+	return season, nil
+}
+
+// seasonParamFor is the season parameter that resolves back to season.
+func seasonParamFor(season *db.Season) string {
+	if season == nil {
+		return ""
+	}
+	return strconv.FormatInt(season.ID, 10)
+}
+
+// setSeasonTitle adds the season's name to a leaderboard embed's title.
+func setSeasonTitle(tr Localizer, embed *discordgo.MessageEmbed, season *db.Season) {
+	if season != nil {
+		embed.Title = tr.T("leaderboard.title.season", embed.Title, season.Name)
+	}
+}
+
+// seasonScope is the leaderboard scope a season's standings cover.
+func seasonScope(season *db.Season) string {
+	if season.GuildID == nil {
+		return "global"
+	}
+	return "guild"
+}
+
+// fetchLeaderboard loads a page of the all-time leaderboard, or of the
+// season's leaderboard if season is set.
+func (b *Bot) fetchLeaderboard(ctx context.Context, guildID *string, season *db.Season, q db.LeaderboardQuery) (*db.LeaderboardPage, error) {
+	if season != nil {
+		return b.store.GetSeasonLeaderboard(ctx, season, q)
+	}
+	return b.store.GetLeaderboard(ctx, guildID, q)
+}
+
+// fetchUserRank returns the user's all-time rank, or their rank in season if set.
+func (b *Bot) fetchUserRank(ctx context.Context, userID string, guildID *string, season *db.Season, metric db.Metric) (int, error) {
+	if season != nil {
+		return b.store.GetSeasonUserRank(ctx, season, userID, metric)
+	}
+	return b.store.GetUserRank(ctx, userID, guildID, metric)
+}
+
+func (b *Bot) handleSeason(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	tr := localizerFor(ctx, i)
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return
+	}
+
+	sub := options[0]
+	switch sub.Name {
+	case "list":
+		b.handleSeasonList(ctx, s, i, tr)
+	case "create":
+		if !hasPermission(i, discordgo.PermissionManageServer) {
+			embed := formatSimpleEmbed(tr.T("error.permission.title"), tr.T("error.permission.desc"))
+			sendResponseEmbed(ctx, s, i, embed, i.GuildID, "season")
+			return
+		}
+		b.handleSeasonCreate(ctx, s, i, tr, sub.Options)
+	}
+}
+
+func (b *Bot) handleSeasonList(ctx context.Context, s Discord, i *discordgo.InteractionCreate, tr Localizer) {
+	seasons, err := b.store.ListSeasons(ctx, &i.GuildID)
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("season.list.error.title"), tr.T("season.list.error.desc"), i.GuildID, "season", err)
+		return
+	}
+	if len(seasons) == 0 {
+		embed := formatSimpleEmbed(tr.T("season.list.title"), tr.T("season.list.empty"), 0xFEE75C)
+		sendResponseEmbed(ctx, s, i, embed, i.GuildID, "season")
+		return
+	}
+
+	embed := formatSimpleEmbed(tr.T("season.list.title"), formatSeasonList(tr, seasons, time.Now()))
+	sendResponseEmbed(ctx, s, i, embed, i.GuildID, "season")
+}
+
+func (b *Bot) handleSeasonCreate(ctx context.Context, s Discord, i *discordgo.InteractionCreate, tr Localizer, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	var name, start, end string
+	for _, opt := range options {
+		switch opt.Name {
+		case "name":
+			name = opt.StringValue()
+		case "start":
+			start = opt.StringValue()
+		case "end":
+			end = opt.StringValue()
+		}
+	}
+
+	startsAt, endsAt, err := parseSeasonDates(start, end, b.guildLocation(ctx, i.GuildID))
+	if err != nil {
+		embed := formatSimpleEmbed(tr.T("season.create.error.title"), tr.T("season.create.invalid.desc"), 0xED4245)
+		sendResponseEmbed(ctx, s, i, embed, i.GuildID, "season")
+		return
+	}
+
+	guildID := i.GuildID
+	season, err := b.store.CreateSeason(ctx, db.Season{
+		GuildID:  &guildID,
+		Name:     name,
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+	})
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("season.create.error.title"), tr.T("season.create.error.desc"), i.GuildID, "season", err)
+		return
+	}
+
+	b.recordAudit(ctx, i, auditSeasonCreate, fmt.Sprintf("#%d", season.ID), nil, season)
+	util.LoggerFrom(ctx).Info("📅 Season created", "guildID", guildID, "seasonID", season.ID, "startsAt", season.StartsAt, "endsAt", season.EndsAt)
+	sendSuccessEmbed(ctx, s, i, tr.T("season.create.title"), tr.T("season.create.desc", season.Name, season.ID, season.StartsAt.Unix(), season.EndsAt.Unix()), i.GuildID, "season")
+}
+
+// parseSeasonDates parses a season's first and last day. The season runs
+// until the end of its last day in loc.
+func parseSeasonDates(start, end string, loc *time.Location) (time.Time, time.Time, error) {
+	startsAt, err := time.ParseInLocation(seasonDateLayout, start, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	lastDay, err := time.ParseInLocation(seasonDateLayout, end, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	endsAt := lastDay.AddDate(0, 0, 1)
+	if !endsAt.After(startsAt) {
+		return time.Time{}, time.Time{}, fmt.Errorf("season ends before it starts")
+	}
+	return startsAt, endsAt, nil
+}
+
+func formatSeasonList(tr Localizer, seasons []db.Season, now time.Time) string {
+	var b strings.Builder
+	for _, season := range seasons {
+		status := "season.status.upcoming"
+		switch {
+		case season.ClosedAt != nil || !now.Before(season.EndsAt):
+			status = "season.status.closed"
+		case !now.Before(season.StartsAt):
+			status = "season.status.active"
+		}
+
+		scope := tr.T("leaderboard.scope.guild")
+		if season.GuildID == nil {
+			scope = tr.T("leaderboard.scope.global")
+		}
+
+		b.WriteString(tr.T("season.list.line", season.ID, season.Name, scope, season.StartsAt.Unix(), season.EndsAt.Unix(), tr.T(status)))
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// RunSeasons closes seasons that have ended, posting a recap for each, and
+// keeps a global season running when GLOBAL_SEASON_DAYS is set. It checks
+// every interval until ctx is cancelled.
+func (b *Bot) RunSeasons(ctx context.Context, s Discord, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		b.rolloverSeasons(util.WithCorrelationID(ctx, util.NewCorrelationID()), s, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (b *Bot) rolloverSeasons(ctx context.Context, s Discord, now time.Time) {
+	due, err := b.store.GetDueSeasons(ctx, now)
+	if err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to fetch ended seasons", "error", err)
+		return
+	}
+
+	for _, season := range due {
+		closed, err := b.store.CloseSeason(ctx, season.ID, now)
+		if err != nil {
+			util.LoggerFrom(ctx).Error("❌ Failed to close season", "seasonID", season.ID, "error", err)
+			continue
+		}
+		if !closed {
+			continue
+		}
+		season.ClosedAt = &now
+		util.LoggerFrom(ctx).Info("🏁 Season closed", "seasonID", season.ID, "name", season.Name)
+		b.postSeasonRecap(ctx, s, &season)
+	}
+
+	if days := util.Cfg.GlobalSeasonDays; days > 0 {
+		b.startGlobalSeason(ctx, now, days)
+	}
+}
+
+// startGlobalSeason starts the next global season if none is running.
+func (b *Bot) startGlobalSeason(ctx context.Context, now time.Time, days int) {
+	active, err := b.store.GetActiveSeason(ctx, nil, now)
+	if err != nil || active != nil {
+		return
+	}
+
+	previous, err := b.store.ListSeasons(ctx, nil)
+	if err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to list global seasons", "error", err)
+		return
+	}
+
+	season, err := b.store.CreateSeason(ctx, db.Season{
+		Name:     fmt.Sprintf("Season %d", len(previous)+1),
+		StartsAt: now,
+		EndsAt:   now.AddDate(0, 0, days),
+	})
+	if err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to start global season", "error", err)
+		return
+	}
+	util.LoggerFrom(ctx).Info("📅 Global season started", "seasonID", season.ID, "name", season.Name, "endsAt", season.EndsAt)
+}
+
+// postSeasonRecap announces a closed season's final standings in the meow
+// channel of its guild, or of every guild for a global season.
+func (b *Bot) postSeasonRecap(ctx context.Context, s Discord, season *db.Season) {
+	top, err := b.store.GetSeasonLeaderboard(ctx, season, db.LeaderboardQuery{Metric: db.MetricTotal, Limit: seasonRecapSize})
+	if err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to fetch season standings", "seasonID", season.ID, "error", err)
+		return
+	}
+
+	channels, err := b.store.GetAllGuildChannels(ctx)
+	if err != nil {
+		util.LoggerFrom(ctx).Error("❌ Failed to fetch guild channels", "seasonID", season.ID, "error", err)
+		return
+	}
+	if season.GuildID != nil {
+		channels = map[string]string{*season.GuildID: channels[*season.GuildID]}
+	}
+
+	for guildID, channelID := range channels {
+		if channelID == "" {
+			continue
+		}
+		tr := b.localizerForGuild(ctx, guildID)
+		_ = sendMessage(s, channelID, formatSeasonRecap(tr, season, top.Entries, top.Total), guildID)
+	}
+}
+
+func formatSeasonRecap(tr Localizer, season *db.Season, top []db.LeaderboardEntry, participants int) string {
+	if len(top) == 0 {
+		return tr.T("season.recap.empty", season.Name)
+	}
+
+	medals := []string{"🥇", "🥈", "🥉"}
+	var b strings.Builder
+	b.WriteString(tr.T("season.recap", season.Name, participants))
+	for i, entry := range top {
+		medal := fmt.Sprintf("%d.", i+1)
+		if i < len(medals) {
+			medal = medals[i]
+		}
+		b.WriteString("\n")
+		b.WriteString(tr.T("season.recap.line", medal, entry.User.Name(), entry.TotalMeows))
+	}
+	b.WriteString("\n")
+	b.WriteString(tr.T("season.recap.footer", season.ID))
+	return b.String()
+}
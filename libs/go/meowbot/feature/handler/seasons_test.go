@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"libs/go/meowbot/feature/db"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSeasonDates(t *testing.T) {
+	start, end, err := parseSeasonDates("2025-06-01", "2025-06-30", time.UTC)
+	if err != nil {
+		t.Fatalf("parseSeasonDates() error = %v", err)
+	}
+	if want := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("start = %v, want %v", start, want)
+	}
+	if want := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC); !end.Equal(want) {
+		t.Errorf("end = %v, want the end of the last day (%v)", end, want)
+	}
+
+	if _, _, err := parseSeasonDates("2025-06-01", "2025-06-01", time.UTC); err != nil {
+		t.Errorf("a one-day season should be valid, got %v", err)
+	}
+	for _, tc := range [][2]string{{"2025-06-30", "2025-06-01"}, {"June", "2025-06-30"}, {"2025-06-01", ""}} {
+		if _, _, err := parseSeasonDates(tc[0], tc[1], time.UTC); err == nil {
+			t.Errorf("parseSeasonDates(%q, %q) should fail", tc[0], tc[1])
+		}
+	}
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start, _, err = parseSeasonDates("2025-06-01", "2025-06-30", berlin)
+	if want := time.Date(2025, 5, 31, 22, 0, 0, 0, time.UTC); err != nil || !start.Equal(want) {
+		t.Errorf("start in Berlin = %v, %v; want midnight there (%v)", start, err, want)
+	}
+}
+
+func TestFormatSeasonList(t *testing.T) {
+	tr := Localizer{Locale: defaultLocale}
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	guildID := "g1"
+	closedAt := now.AddDate(0, 0, -20)
+
+	got := formatSeasonList(tr, []db.Season{
+		{ID: 3, GuildID: &guildID, Name: "July", StartsAt: now.AddDate(0, 0, 16), EndsAt: now.AddDate(0, 1, 16)},
+		{ID: 2, Name: "June", StartsAt: now.AddDate(0, 0, -14), EndsAt: now.AddDate(0, 0, 16)},
+		{ID: 1, GuildID: &guildID, Name: "May", StartsAt: now.AddDate(0, -1, -14), EndsAt: closedAt, ClosedAt: &closedAt},
+	}, now)
+
+	lines := strings.Split(got, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %q", got)
+	}
+	for i, want := range []string{"upcoming", "running", "finished"} {
+		if !strings.Contains(lines[i], want) {
+			t.Errorf("line %d = %q, want status %q", i, lines[i], want)
+		}
+	}
+	if !strings.Contains(lines[1], tr.T("leaderboard.scope.global")) {
+		t.Errorf("global season should be labelled global, got %q", lines[1])
+	}
+}
+
+func TestFormatSeasonRecap(t *testing.T) {
+	tr := Localizer{Locale: defaultLocale}
+	season := &db.Season{ID: 7, Name: "June"}
+
+	got := formatSeasonRecap(tr, season, []db.LeaderboardEntry{
+		{User: &db.User{ID: "u1", Username: "kitty"}, TotalMeows: 42},
+		{User: &db.User{ID: "u2", Username: "tom"}, TotalMeows: 30},
+	}, 5)
+	for _, want := range []string{"June", "5 meowers", "🥇 **kitty** — 42", "🥈 **tom** — 30", "season:7"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("recap %q missing %q", got, want)
+		}
+	}
+	if strings.Contains(got, "<@") {
+		t.Errorf("recap should not mention users, got %q", got)
+	}
+
+	if got := formatSeasonRecap(tr, season, nil, 0); got != tr.T("season.recap.empty", "June") {
+		t.Errorf("empty recap = %q", got)
+	}
+}
@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"libs/go/meowbot/util"
+	"strings"
+	"time"
+)
+
+// itemKind says what owning a shop item does.
+type itemKind int
+
+const (
+	// itemReaction replaces the 🐱 reaction on the owner's successful meows with Emoji.
+	itemReaction itemKind = iota
+	// itemTitle shows the item's name as a title in the owner's /stats.
+	itemTitle
+	// itemRole grants the owner a cosmetic guild role named RoleName.
+	itemRole
+)
+
+const defaultMeowReaction = "🐱"
+
+// ShopItem is something users can buy with treats. Its name and description
+// come from the message catalog under shop.item.<ID>.name and .desc.
+type ShopItem struct {
+	ID       string
+	Kind     itemKind
+	Emoji    string
+	Price    int
+	RoleName string
+}
+
+// shopItems lists everything sold in /shop. Add new items here, together with
+// their catalog entries.
+var shopItems = []ShopItem{
+	{ID: "reaction_heart_eyes", Kind: itemReaction, Emoji: "😻", Price: 50},
+	{ID: "reaction_lion", Kind: itemReaction, Emoji: "🦁", Price: 150},
+	{ID: "title_nap_champion", Kind: itemTitle, Emoji: "💤", Price: 75},
+	{ID: "title_whisker_lord", Kind: itemTitle, Emoji: "👑", Price: 100},
+	{ID: "role_cat_herder", Kind: itemRole, Emoji: "🎀", Price: 300, RoleName: "Cat Herder"},
+}
+
+func lookupShopItem(id string) (ShopItem, bool) {
+	for _, item := range shopItems {
+		if item.ID == id {
+			return item, true
+		}
+	}
+	return ShopItem{}, false
+}
+
+// shopItemChoices lists the shop items as /buy choices, named after the
+// items in every locale.
+func shopItemChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(shopItems))
+	for _, item := range shopItems {
+		choiceName := func(locale discordgo.Locale) string {
+			return fmt.Sprintf("%s %s (%d)", item.Emoji, Localizer{Locale: locale}.T("shop.item."+item.ID+".name"), item.Price)
+		}
+
+		localized := make(map[discordgo.Locale]string, len(catalog)-1)
+		for locale := range catalog {
+			if locale != defaultLocale {
+				localized[locale] = choiceName(locale)
+			}
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:              choiceName(defaultLocale),
+			NameLocalizations: localized,
+			Value:             item.ID,
+		})
+	}
+	return choices
+}
+
+// equippedItem returns the most recently bought item of the given kind, if any.
+func equippedItem(owned []db.UserItem, kind itemKind) (ShopItem, bool) {
+	for i := len(owned) - 1; i >= 0; i-- {
+		if item, ok := lookupShopItem(owned[i].ItemID); ok && item.Kind == kind {
+			return item, true
+		}
+	}
+	return ShopItem{}, false
+}
+
+// meowReaction is the emoji the bot reacts with to the user's successful meows.
+func (b *Bot) meowReaction(ctx context.Context, guildID, userID string) string {
+	owned, err := b.store.GetUserItems(ctx, guildID, userID)
+	if err != nil {
+		util.LoggerFrom(ctx).Warn("⚠️ Failed to fetch items", "guildID", guildID, "userID", userID, "error", err)
+		return defaultMeowReaction
+	}
+	if item, ok := equippedItem(owned, itemReaction); ok {
+		return item.Emoji
+	}
+	return defaultMeowReaction
+}
+
+// profileTitle is the title shown in the user's /stats, or "" if they have none.
+func (b *Bot) profileTitle(ctx context.Context, tr Localizer, guildID, userID string) string {
+	owned, err := b.store.GetUserItems(ctx, guildID, userID)
+	if err != nil {
+		util.LoggerFrom(ctx).Warn("⚠️ Failed to fetch items", "guildID", guildID, "userID", userID, "error", err)
+		return ""
+	}
+	if item, ok := equippedItem(owned, itemTitle); ok {
+		return item.Emoji + " " + tr.T("shop.item."+item.ID+".name")
+	}
+	return ""
+}
+
+func (b *Bot) handleShop(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	tr := localizerFor(ctx, i)
+	userID := interactionUserID(i)
+
+	balance, err := b.store.GetTreatBalance(ctx, i.GuildID, userID)
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("shop.error.title"), tr.T("shop.error.desc"), i.GuildID, "shop", err)
+		return
+	}
+	owned, err := b.store.GetUserItems(ctx, i.GuildID, userID)
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("shop.error.title"), tr.T("shop.error.desc"), i.GuildID, "shop", err)
+		return
+	}
+
+	embed := formatSimpleEmbed(tr.T("shop.title"), formatShop(tr, balance, owned), 0xF5A623)
+	sendResponseEmbed(ctx, s, i, embed, i.GuildID, "shop")
+}
+
+func formatShop(tr Localizer, balance int, owned []db.UserItem) string {
+	ownedIDs := make(map[string]bool, len(owned))
+	for _, item := range owned {
+		ownedIDs[item.ItemID] = true
+	}
+
+	var b strings.Builder
+	b.WriteString(tr.T("shop.body", balance))
+	for _, item := range shopItems {
+		status := tr.T("shop.price", item.Price)
+		if ownedIDs[item.ID] {
+			status = tr.T("shop.owned")
+		}
+		b.WriteString("\n")
+		b.WriteString(tr.T("shop.line", item.Emoji, tr.T("shop.item."+item.ID+".name"), status, tr.T("shop.item."+item.ID+".desc")))
+	}
+	return b.String()
+}
+
+func (b *Bot) handleBuy(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	tr := localizerFor(ctx, i)
+	userID := interactionUserID(i)
+
+	var itemID string
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "item" {
+			itemID = opt.StringValue()
+		}
+	}
+
+	item, ok := lookupShopItem(itemID)
+	if !ok {
+		embed := formatSimpleEmbed(tr.T("buy.error.title"), tr.T("buy.unknown.desc"), 0xED4245)
+		sendResponseEmbed(ctx, s, i, embed, i.GuildID, "buy")
+		return
+	}
+	name := tr.T("shop.item." + item.ID + ".name")
+
+	balance, err := b.store.PurchaseItem(ctx, i.GuildID, userID, item.ID, item.Price, time.Now())
+	switch {
+	case errors.Is(err, db.ErrInsufficientTreats):
+		embed := formatSimpleEmbed(tr.T("buy.error.title"), tr.T("buy.insufficient.desc", name, item.Price), 0xFEE75C)
+		sendResponseEmbed(ctx, s, i, embed, i.GuildID, "buy")
+		return
+	case errors.Is(err, db.ErrAlreadyOwned):
+		embed := formatSimpleEmbed(tr.T("buy.error.title"), tr.T("buy.owned.desc", name), 0xFEE75C)
+		sendResponseEmbed(ctx, s, i, embed, i.GuildID, "buy")
+		return
+	case err != nil:
+		sendErrorEmbed(ctx, s, i, tr.T("buy.error.title"), tr.T("buy.error.desc"), i.GuildID, "buy", err)
+		return
+	}
+
+	if item.Kind == itemRole {
+		if err := b.grantItemRole(ctx, s, i.GuildID, userID, item); err != nil {
+			// Don't charge for a role the user didn't get.
+			if _, refundErr := b.store.RefundItem(ctx, i.GuildID, userID, item.ID, item.Price, time.Now()); refundErr != nil {
+				util.LoggerFrom(ctx).Error("❌ Failed to refund item", "guildID", i.GuildID, "userID", userID, "item", item.ID, "error", refundErr)
+			}
+			sendErrorEmbed(ctx, s, i, tr.T("buy.error.title"), tr.T("buy.role.error.desc"), i.GuildID, "buy", err)
+			return
+		}
+	}
+
+	util.LoggerFrom(ctx).Info("🛍️ Item purchased", "guildID", i.GuildID, "userID", userID, "item", item.ID, "price", item.Price, "balance", balance)
+	sendSuccessEmbed(ctx, s, i, tr.T("buy.title"), tr.T("buy.desc", item.Emoji, name, balance), i.GuildID, "buy")
+}
+
+// errRoleHasPermissions means the item's role was given permissions after the
+// bot created it, so granting it would hand those out as well.
+var errRoleHasPermissions = errors.New("item role has permissions")
+
+// grantItemRole gives the user the item's cosmetic role. The bot creates the
+// role without permissions the first time the item is bought in the guild and
+// only ever grants it by the stored ID, so a role of the same name made by
+// someone else is never handed out.
+func (b *Bot) grantItemRole(ctx context.Context, s Discord, guildID, userID string, item ShopItem) error {
+	if !util.Cfg.IsAllowedGuild(guildID) {
+		util.Cfg.Logger.Debug("🎀 [DEV] Skipped granting role", "guildID", guildID, "role", item.RoleName)
+		return nil
+	}
+
+	roleID, err := b.store.GetItemRole(ctx, guildID, item.ID)
+	if err != nil {
+		return fmt.Errorf("get item role: %w", err)
+	}
+
+	var role *discordgo.Role
+	if roleID != "" {
+		roles, err := s.GuildRoles(guildID)
+		if err != nil {
+			return fmt.Errorf("list roles: %w", err)
+		}
+		for _, r := range roles {
+			if r.ID == roleID {
+				role = r
+				break
+			}
+		}
+	}
+	// Create the role if the bot hasn't yet or it was deleted since.
+	if role == nil {
+		var permissions int64
+		mentionable := false
+		role, err = s.GuildRoleCreate(guildID, &discordgo.RoleParams{Name: item.RoleName, Permissions: &permissions, Mentionable: &mentionable})
+		if err != nil {
+			return fmt.Errorf("create role: %w", err)
+		}
+		if err := b.store.SetItemRole(ctx, guildID, item.ID, role.ID); err != nil {
+			return fmt.Errorf("store item role: %w", err)
+		}
+	}
+
+	if role.Permissions != 0 {
+		return fmt.Errorf("%w: role %s has permissions %d", errRoleHasPermissions, role.ID, role.Permissions)
+	}
+	if err := s.GuildMemberRoleAdd(guildID, userID, role.ID); err != nil {
+		return fmt.Errorf("add role: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/util"
+	"sort"
+)
+
+// CommandChange describes a single difference between the registry and the
+// commands Discord currently has registered.
+type CommandChange struct {
+	Action  string // "create", "update" or "delete"
+	Name    string
+	GuildID string // empty for global commands
+}
+
+// DiffCommands compares the commands Discord has registered with the desired
+// definitions and returns the changes needed to make them match.
+func DiffCommands(existing, desired []*discordgo.ApplicationCommand, guildID string) []CommandChange {
+	current := make(map[string]*discordgo.ApplicationCommand, len(existing))
+	for _, cmd := range existing {
+		current[cmd.Name] = cmd
+	}
+
+	var changes []CommandChange
+	wanted := make(map[string]bool, len(desired))
+	for _, cmd := range desired {
+		wanted[cmd.Name] = true
+		old, ok := current[cmd.Name]
+		switch {
+		case !ok:
+			changes = append(changes, CommandChange{Action: "create", Name: cmd.Name, GuildID: guildID})
+		case commandFingerprint(old) != commandFingerprint(cmd):
+			changes = append(changes, CommandChange{Action: "update", Name: cmd.Name, GuildID: guildID})
+		}
+	}
+
+	var stale []string
+	for name := range current {
+		if !wanted[name] {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+	for _, name := range stale {
+		changes = append(changes, CommandChange{Action: "delete", Name: name, GuildID: guildID})
+	}
+
+	return changes
+}
+
+// SyncCommands makes the commands registered with Discord match the registry.
+// In production commands are synced globally, otherwise to each whitelisted
+// guild. Each scope is updated with a single bulk overwrite, and only when
+// something changed. In dry-run mode the changes are logged but not applied.
+func SyncCommands(sess *discordgo.Session, registry *Registry, dryRun bool) ([]CommandChange, error) {
+	appID := sess.State.User.ID
+	desired := registry.Definitions()
+
+	var all []CommandChange
+	for _, guildID := range commandScopes() {
+		existing, err := sess.ApplicationCommands(appID, guildID)
+		if err != nil {
+			util.Cfg.Logger.Error("❌ Failed to fetch registered commands", "guildID", guildID, "error", err)
+			return all, err
+		}
+
+		changes := DiffCommands(existing, desired, guildID)
+		all = append(all, changes...)
+		for _, change := range changes {
+			util.Cfg.Logger.Info("🔧 Command change", "action", change.Action, "command", change.Name, "guildID", guildID, "dryRun", dryRun)
+		}
+
+		if len(changes) == 0 {
+			util.Cfg.Logger.Info("✅ Commands up to date", "guildID", guildID)
+			continue
+		}
+		if dryRun {
+			continue
+		}
+
+		if _, err := sess.ApplicationCommandBulkOverwrite(appID, guildID, desired); err != nil {
+			util.Cfg.Logger.Error("❌ Failed to sync commands", "guildID", guildID, "error", err)
+			return all, err
+		}
+		util.Cfg.Logger.Info("✅ Synced commands", "guildID", guildID, "changes", len(changes))
+	}
+
+	return all, nil
+}
+
+// commandScopes returns the guild IDs to sync commands to, where "" means global.
+func commandScopes() []string {
+	if util.Cfg.IsProd {
+		return []string{""}
+	}
+	return util.Cfg.Whitelist.Guilds
+}
+
+// commandFingerprint serializes the parts of a command definition that users
+// can see, with Discord's defaults filled in, so that definitions coming back
+// from the API compare equal to locally declared ones.
+func commandFingerprint(cmd *discordgo.ApplicationCommand) string {
+	type option struct {
+		Type                     discordgo.ApplicationCommandOptionType      `json:"type"`
+		Name                     string                                      `json:"name"`
+		NameLocalizations        map[discordgo.Locale]string                 `json:"name_localizations"`
+		Description              string                                      `json:"description"`
+		DescriptionLocalizations map[discordgo.Locale]string                 `json:"description_localizations"`
+		ChannelTypes             []discordgo.ChannelType                     `json:"channel_types"`
+		Required                 bool                                        `json:"required"`
+		Autocomplete             bool                                        `json:"autocomplete"`
+		Choices                  []*discordgo.ApplicationCommandOptionChoice `json:"choices"`
+		MinValue                 *float64                                    `json:"min_value"`
+		MaxValue                 float64                                     `json:"max_value"`
+		MinLength                *int                                        `json:"min_length"`
+		MaxLength                int                                         `json:"max_length"`
+		Options                  []option                                    `json:"options"`
+	}
+
+	var normalizeOptions func([]*discordgo.ApplicationCommandOption) []option
+	normalizeOptions = func(opts []*discordgo.ApplicationCommandOption) []option {
+		out := make([]option, 0, len(opts))
+		for _, o := range opts {
+			out = append(out, option{
+				Type:                     o.Type,
+				Name:                     o.Name,
+				NameLocalizations:        nonNilMap(o.NameLocalizations),
+				Description:              o.Description,
+				DescriptionLocalizations: nonNilMap(o.DescriptionLocalizations),
+				ChannelTypes:             append([]discordgo.ChannelType{}, o.ChannelTypes...),
+				Required:                 o.Required,
+				Autocomplete:             o.Autocomplete,
+				Choices:                  append([]*discordgo.ApplicationCommandOptionChoice{}, o.Choices...),
+				MinValue:                 o.MinValue,
+				MaxValue:                 o.MaxValue,
+				MinLength:                o.MinLength,
+				MaxLength:                o.MaxLength,
+				Options:                  normalizeOptions(o.Options),
+			})
+		}
+		return out
+	}
+
+	cmdType := cmd.Type
+	if cmdType == 0 {
+		cmdType = discordgo.ChatApplicationCommand
+	}
+	dmPermission := true
+	if cmd.DMPermission != nil {
+		dmPermission = *cmd.DMPermission
+	}
+	var nameLoc, descLoc map[discordgo.Locale]string
+	if cmd.NameLocalizations != nil {
+		nameLoc = *cmd.NameLocalizations
+	}
+	if cmd.DescriptionLocalizations != nil {
+		descLoc = *cmd.DescriptionLocalizations
+	}
+
+	b, _ := json.Marshal(struct {
+		Type                     discordgo.ApplicationCommandType `json:"type"`
+		Name                     string                           `json:"name"`
+		NameLocalizations        map[discordgo.Locale]string      `json:"name_localizations"`
+		Description              string                           `json:"description"`
+		DescriptionLocalizations map[discordgo.Locale]string      `json:"description_localizations"`
+		DefaultMemberPermissions *int64                           `json:"default_member_permissions"`
+		DMPermission             bool                             `json:"dm_permission"`
+		Options                  []option                         `json:"options"`
+	}{
+		Type:                     cmdType,
+		Name:                     cmd.Name,
+		NameLocalizations:        nonNilMap(nameLoc),
+		Description:              cmd.Description,
+		DescriptionLocalizations: nonNilMap(descLoc),
+		DefaultMemberPermissions: cmd.DefaultMemberPermissions,
+		DMPermission:             dmPermission,
+		Options:                  normalizeOptions(cmd.Options),
+	})
+	return string(b)
+}
+
+func nonNilMap(m map[discordgo.Locale]string) map[discordgo.Locale]string {
+	if m == nil {
+		return map[discordgo.Locale]string{}
+	}
+	return m
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"reflect"
+	"testing"
+)
+
+func TestDiffCommands(t *testing.T) {
+	existing := []*discordgo.ApplicationCommand{
+		{ID: "1", ApplicationID: "app", Version: "7", Name: "count", Description: "Check the count", Type: discordgo.ChatApplicationCommand},
+		{ID: "2", Name: "highscore", Description: "Old description"},
+		{ID: "3", Name: "stale", Description: "No longer exists"},
+	}
+	desired := []*discordgo.ApplicationCommand{
+		{Name: "count", Description: "Check the count"},
+		{Name: "highscore", Description: "Check the high score"},
+		{Name: "stats", Description: "Check your stats"},
+	}
+
+	got := DiffCommands(existing, desired, "g1")
+	want := []CommandChange{
+		{Action: "update", Name: "highscore", GuildID: "g1"},
+		{Action: "create", Name: "stats", GuildID: "g1"},
+		{Action: "delete", Name: "stale", GuildID: "g1"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffCommands() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDiffCommands_NoChanges(t *testing.T) {
+	perm := int64(discordgo.PermissionAdministrator)
+	desired := newTestBot().NewDefaultRegistry().Definitions()
+
+	// Simulate what Discord returns: IDs filled in, empty slices instead of nil.
+	var existing []*discordgo.ApplicationCommand
+	for _, cmd := range desired {
+		c := *cmd
+		c.ID = "id-" + cmd.Name
+		c.Type = discordgo.ChatApplicationCommand
+		if c.Options == nil {
+			c.Options = []*discordgo.ApplicationCommandOption{}
+		}
+		existing = append(existing, &c)
+	}
+
+	if changes := DiffCommands(existing, desired, ""); len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+
+	// Changing a permission is detected.
+	existing[0].DefaultMemberPermissions = &perm
+	if changes := DiffCommands(existing, desired, ""); len(changes) != 1 || changes[0].Action != "update" {
+		t.Errorf("expected one update, got %+v", changes)
+	}
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/util"
+	"strings"
+	"time"
+)
+
+// localTimeLayout is how times are shown in a guild's timezone.
+const localTimeLayout = "2006-01-02 15:04 MST"
+
+// loadTimezone resolves an IANA timezone name. Unlike time.LoadLocation, it
+// rejects "" and "Local", which would mean UTC or the bot host's zone.
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, errors.New("not an IANA timezone: " + name)
+	}
+	return time.LoadLocation(name)
+}
+
+// guildLocation is the guild's configured timezone, or UTC.
+func (b *Bot) guildLocation(ctx context.Context, guildID string) *time.Location {
+	settings, err := b.store.GetGuildSettings(ctx, guildID)
+	if err != nil || settings.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := loadTimezone(settings.Timezone)
+	if err != nil {
+		util.LoggerFrom(ctx).Warn("⚠️ Invalid guild timezone", "guildID", guildID, "timezone", settings.Timezone, "error", err)
+		return time.UTC
+	}
+	return loc
+}
+
+// formatLocalTime shows t as a date and time in loc.
+func formatLocalTime(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format(localTimeLayout)
+}
+
+// handleTimezone sets the timezone the guild's dates are shown and counted
+// in, or shows the current one when no zone is given.
+func (b *Bot) handleTimezone(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	guildID := i.GuildID
+	tr := localizerFor(ctx, i)
+
+	var zone string
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "zone" {
+			zone = strings.TrimSpace(opt.StringValue())
+		}
+	}
+
+	previous := b.guildLocation(ctx, guildID)
+	if zone == "" {
+		embed := formatSimpleEmbed(tr.T("timezone.title"), tr.T("timezone.current", previous.String(), formatLocalTime(time.Now(), previous)))
+		sendResponseEmbed(ctx, s, i, embed, guildID, "timezone")
+		return
+	}
+
+	loc, err := loadTimezone(zone)
+	if err != nil {
+		embed := formatSimpleEmbed(tr.T("timezone.error.title"), tr.T("timezone.invalid", zone), 0xED4245)
+		sendResponseEmbed(ctx, s, i, embed, guildID, "timezone")
+		return
+	}
+	if err := b.store.UpsertGuildTimezone(ctx, guildID, loc.String()); err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("timezone.error.title"), tr.T("timezone.error.desc"), guildID, "timezone", err)
+		return
+	}
+	b.recordAudit(ctx, i, auditTimezoneSet, "", previous.String(), loc.String())
+
+	util.LoggerFrom(ctx).Info("🕰️ Timezone set", "guildID", guildID, "timezone", loc.String())
+	sendSuccessEmbed(ctx, s, i, tr.T("timezone.title"), tr.T("timezone.desc", loc.String(), formatLocalTime(time.Now(), loc)), guildID, "timezone")
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"github.com/bwmarrin/discordgo"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadTimezone(t *testing.T) {
+	for _, name := range []string{"Europe/Berlin", "America/New_York", "UTC"} {
+		if loc, err := loadTimezone(name); err != nil || loc.String() != name {
+			t.Errorf("loadTimezone(%q) = %v, %v", name, loc, err)
+		}
+	}
+	for _, name := range []string{"", "Local", "Mars/Olympus"} {
+		if _, err := loadTimezone(name); err == nil {
+			t.Errorf("loadTimezone(%q) should fail", name)
+		}
+	}
+}
+
+func TestGuildLocation(t *testing.T) {
+	ctx := context.Background()
+	b := newTestBot()
+	if loc := b.guildLocation(ctx, "g1"); loc != time.UTC {
+		t.Errorf("guildLocation without a timezone = %v, want UTC", loc)
+	}
+
+	if err := b.store.UpsertGuildTimezone(ctx, "g1", "Asia/Tokyo"); err != nil {
+		t.Fatal(err)
+	}
+	loc := b.guildLocation(ctx, "g1")
+	if loc.String() != "Asia/Tokyo" {
+		t.Fatalf("guildLocation = %v, want Asia/Tokyo", loc)
+	}
+	at := time.Date(2025, 6, 1, 18, 30, 0, 0, time.UTC)
+	if got, want := formatLocalTime(at, loc), "2025-06-02 03:30 JST"; got != want {
+		t.Errorf("formatLocalTime = %q, want %q", got, want)
+	}
+}
+
+func TestHandleTimezone(t *testing.T) {
+	ctx := context.Background()
+	b := newTestBot()
+
+	tests := []struct {
+		zone     string
+		wantDesc string
+		wantZone string
+	}{
+		{"Europe/Berlin", "Europe/Berlin", "Europe/Berlin"},
+		{"Mars/Olympus", "Mars/Olympus", "Europe/Berlin"},
+		{"", "Europe/Berlin", "Europe/Berlin"},
+	}
+	for _, tt := range tests {
+		s := &fakeDiscord{}
+		data := discordgo.ApplicationCommandInteractionData{Name: "timezone"}
+		if tt.zone != "" {
+			data.Options = []*discordgo.ApplicationCommandInteractionDataOption{
+				{Name: "zone", Type: discordgo.ApplicationCommandOptionString, Value: tt.zone},
+			}
+		}
+		b.handleTimezone(ctx, s, &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			Type:    discordgo.InteractionApplicationCommand,
+			Data:    data,
+			GuildID: "g1",
+			Member:  &discordgo.Member{User: &discordgo.User{ID: "admin"}},
+		}})
+
+		responses := s.Responses()
+		if len(responses) != 1 || len(responses[0].Data.Embeds) != 1 {
+			t.Fatalf("zone %q: responses = %+v, want one embed", tt.zone, responses)
+		}
+		if desc := responses[0].Data.Embeds[0].Description; !strings.Contains(desc, tt.wantDesc) {
+			t.Errorf("zone %q: response %q doesn't mention %s", tt.zone, desc, tt.wantDesc)
+		}
+		if loc := b.guildLocation(ctx, "g1"); loc.String() != tt.wantZone {
+			t.Errorf("zone %q: guildLocation = %v, want %s", tt.zone, loc, tt.wantZone)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"libs/go/meowbot/util"
+	"strings"
+)
+
+const (
+	treatsPerMeow     = 1
+	chainBonusEvery   = 10
+	chainBonus        = 5
+	highScoreBonus    = 10
+	chainBreakPenalty = 3
+	ledgerPreviewSize = 5
+)
+
+// treatAward is a single change to a user's treats, recorded in the ledger
+// under Reason.
+type treatAward struct {
+	Amount int
+	Reason string
+}
+
+// treatAwards decides what a processed meow earns: treats for a successful
+// meow, bonuses for every chainBonusEvery-th link and new high scores, and a
+// small penalty for breaking a chain.
+func treatAwards(o meowOutcome) []treatAward {
+	if !o.Success {
+		if o.Broken > 0 {
+			return []treatAward{{Amount: -chainBreakPenalty, Reason: "chain_break"}}
+		}
+		return nil
+	}
+
+	awards := []treatAward{{Amount: treatsPerMeow, Reason: "meow"}}
+	if o.Chain%chainBonusEvery == 0 {
+		awards = append(awards, treatAward{Amount: chainBonus, Reason: "chain_bonus"})
+	}
+	if o.HighScore {
+		awards = append(awards, treatAward{Amount: highScoreBonus, Reason: "high_score"})
+	}
+	return awards
+}
+
+// awardTreats applies the treats a processed meow earned or cost its author.
+func (b *Bot) awardTreats(ctx context.Context, m *discordgo.MessageCreate, outcome meowOutcome) {
+	for _, award := range treatAwards(outcome) {
+		balance, err := b.store.AddTreats(ctx, m.GuildID, m.Author.ID, award.Amount, award.Reason, m.Timestamp)
+		if err != nil {
+			util.LoggerFrom(ctx).Error("❌ Failed to award treats", "guildID", m.GuildID, "userID", m.Author.ID, "reason", award.Reason, "error", err)
+			continue
+		}
+		util.LoggerFrom(ctx).Debug("🍪 Treats awarded", "guildID", m.GuildID, "userID", m.Author.ID, "amount", award.Amount, "reason", award.Reason, "balance", balance)
+	}
+}
+
+func (b *Bot) handleBalance(ctx context.Context, s Discord, i *discordgo.InteractionCreate) {
+	tr := localizerFor(ctx, i)
+	userID := interactionUserID(i)
+
+	balance, err := b.store.GetTreatBalance(ctx, i.GuildID, userID)
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("balance.error.title"), tr.T("balance.error.desc"), i.GuildID, "balance", err)
+		return
+	}
+	ledger, err := b.store.GetTreatLedger(ctx, i.GuildID, userID, ledgerPreviewSize)
+	if err != nil {
+		sendErrorEmbed(ctx, s, i, tr.T("balance.error.title"), tr.T("balance.error.desc"), i.GuildID, "balance", err)
+		return
+	}
+	items, err := b.store.GetUserItems(ctx, i.GuildID, userID)
+	if err != nil {
+		util.LoggerFrom(ctx).Warn("⚠️ Failed to fetch items", "guildID", i.GuildID, "userID", userID, "error", err)
+	}
+
+	embed := formatSimpleEmbed(tr.T("balance.title"), formatBalance(tr, balance, ledger, items), 0xF5A623)
+	sendResponseEmbed(ctx, s, i, embed, i.GuildID, "balance")
+}
+
+func formatBalance(tr Localizer, balance int, ledger []db.TreatLedgerEntry, items []db.UserItem) string {
+	var b strings.Builder
+	b.WriteString(tr.T("balance.body", balance))
+
+	if len(ledger) > 0 {
+		b.WriteString("\n\n")
+		b.WriteString(tr.T("balance.ledger"))
+		for _, e := range ledger {
+			b.WriteString("\n")
+			b.WriteString(tr.T("balance.ledger.line", e.Amount, treatReason(tr, e.Reason), e.CreatedAt.Unix()))
+		}
+	}
+
+	if len(items) > 0 {
+		b.WriteString("\n\n")
+		b.WriteString(tr.T("balance.items"))
+		for _, owned := range items {
+			if item, ok := lookupShopItem(owned.ItemID); ok {
+				b.WriteString("\n")
+				b.WriteString(tr.T("balance.items.line", item.Emoji, tr.T("shop.item."+item.ID+".name")))
+			}
+		}
+	}
+	return b.String()
+}
+
+// treatReason localizes a ledger reason. Purchases and refunds are recorded
+// as "purchase:<item>" and "refund:<item>".
+func treatReason(tr Localizer, reason string) string {
+	kind, itemID, hasItem := strings.Cut(reason, ":")
+	if !hasItem {
+		return tr.T("treats.reason." + kind)
+	}
+	name := itemID
+	if item, ok := lookupShopItem(itemID); ok {
+		name = tr.T("shop.item." + item.ID + ".name")
+	}
+	return tr.T("treats.reason."+kind, name)
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/bwmarrin/discordgo"
+	"libs/go/meowbot/feature/db"
+	"libs/go/meowbot/util"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTreatAwards(t *testing.T) {
+	tests := []struct {
+		name    string
+		outcome meowOutcome
+		want    []treatAward
+	}{
+		{
+			name:    "plain meow",
+			outcome: meowOutcome{Success: true, Chain: 3},
+			want:    []treatAward{{treatsPerMeow, "meow"}},
+		},
+		{
+			name:    "chain milestone with new high score",
+			outcome: meowOutcome{Success: true, Chain: 20, HighScore: true},
+			want:    []treatAward{{treatsPerMeow, "meow"}, {chainBonus, "chain_bonus"}, {highScoreBonus, "high_score"}},
+		},
+		{
+			name:    "broke a chain",
+			outcome: meowOutcome{Broken: 4},
+			want:    []treatAward{{-chainBreakPenalty, "chain_break"}},
+		},
+		{
+			name:    "failed with no chain running",
+			outcome: meowOutcome{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := treatAwards(tt.outcome); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("treatAwards() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatBalance(t *testing.T) {
+	tr := Localizer{Locale: defaultLocale}
+	at := time.Date(2025, 6, 11, 12, 0, 0, 0, time.UTC)
+
+	got := formatBalance(tr, 42, []db.TreatLedgerEntry{
+		{Amount: -100, Reason: "purchase:title_whisker_lord", CreatedAt: at},
+		{Amount: 5, Reason: "chain_bonus", CreatedAt: at},
+	}, []db.UserItem{{ItemID: "title_whisker_lord"}})
+
+	for _, want := range []string{"**42**", "`-100` Bought Whisker Lord", "`+5` Chain bonus", "👑 Whisker Lord"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("balance %q missing %q", got, want)
+		}
+	}
+}
+
+func TestShopItemsHaveCatalogEntries(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, item := range shopItems {
+		if seen[item.ID] {
+			t.Errorf("duplicate shop item %q", item.ID)
+		}
+		seen[item.ID] = true
+
+		for _, key := range []string{"shop.item." + item.ID + ".name", "shop.item." + item.ID + ".desc"} {
+			if _, ok := catalog[defaultLocale][key]; !ok {
+				t.Errorf("missing catalog entry %q", key)
+			}
+		}
+		if item.Kind == itemRole && item.RoleName == "" {
+			t.Errorf("role item %q has no RoleName", item.ID)
+		}
+	}
+}
+
+func TestEquippedItem(t *testing.T) {
+	owned := []db.UserItem{
+		{ItemID: "reaction_heart_eyes"},
+		{ItemID: "title_whisker_lord"},
+		{ItemID: "reaction_lion"},
+	}
+
+	if item, ok := equippedItem(owned, itemReaction); !ok || item.Emoji != "🦁" {
+		t.Errorf("equippedItem(reaction) = %v, %v; want the latest reaction", item, ok)
+	}
+	if item, ok := equippedItem(owned, itemTitle); !ok || item.ID != "title_whisker_lord" {
+		t.Errorf("equippedItem(title) = %v, %v", item, ok)
+	}
+	if _, ok := equippedItem(owned, itemRole); ok {
+		t.Error("equippedItem(role) should find nothing")
+	}
+}
+
+func TestFormatShop(t *testing.T) {
+	tr := Localizer{Locale: defaultLocale}
+	got := formatShop(tr, 80, []db.UserItem{{ItemID: "reaction_heart_eyes"}})
+
+	if !strings.Contains(got, "😻 **Heart-Eyes Reaction** — ✅ Owned") {
+		t.Errorf("owned items should be marked, got %q", got)
+	}
+	if !strings.Contains(got, "🦁 **Lion Reaction** — 🍪 150") {
+		t.Errorf("items should show their price, got %q", got)
+	}
+}
+
+func TestGrantItemRole(t *testing.T) {
+	ctx := context.Background()
+	cfg := util.Cfg
+	t.Cleanup(func() { util.Cfg = cfg })
+	util.Cfg.IsProd = true
+
+	item, _ := lookupShopItem("role_cat_herder")
+	b := newTestBot()
+	if err := b.store.UpsertGuild(ctx, db.Guild{ID: "g1"}); err != nil {
+		t.Fatal(err)
+	}
+	admin := &discordgo.Role{ID: "admin", Name: item.RoleName, Permissions: discordgo.PermissionAdministrator}
+	s := &fakeDiscord{roles: map[string][]*discordgo.Role{"g1": {admin}}}
+
+	if err := b.grantItemRole(ctx, s, "g1", "u1", item); err != nil {
+		t.Fatalf("grantItemRole() error = %v", err)
+	}
+	if err := b.grantItemRole(ctx, s, "g1", "u2", item); err != nil {
+		t.Fatalf("grantItemRole() error = %v", err)
+	}
+	want := []string{"g1 u1 role2", "g1 u2 role2"}
+	if !reflect.DeepEqual(s.granted, want) {
+		t.Errorf("granted = %v, want %v: only the role the bot created, never the one with the same name", s.granted, want)
+	}
+	if roleID, _ := b.store.GetItemRole(ctx, "g1", item.ID); roleID != "role2" {
+		t.Errorf("stored role = %q, want role2", roleID)
+	}
+	if created := s.roles["g1"][1]; created.Permissions != 0 {
+		t.Errorf("created role permissions = %d, want 0", created.Permissions)
+	}
+
+	// Someone gives the bot's role permissions after the fact.
+	s.roles["g1"][1].Permissions = discordgo.PermissionManageMessages
+	if err := b.grantItemRole(ctx, s, "g1", "u3", item); !errors.Is(err, errRoleHasPermissions) {
+		t.Errorf("grantItemRole() error = %v, want errRoleHasPermissions", err)
+	}
+	if len(s.granted) != 2 {
+		t.Errorf("granted = %v, want no grant of a role with permissions", s.granted)
+	}
+
+	// The role was deleted, so the bot makes a new one.
+	s.roles["g1"] = s.roles["g1"][:1]
+	if err := b.grantItemRole(ctx, s, "g1", "u3", item); err != nil {
+		t.Fatalf("grantItemRole() error = %v", err)
+	}
+	if got := s.granted[len(s.granted)-1]; got != "g1 u3 role2" {
+		t.Errorf("granted %q, want the recreated role2", got)
+	}
+}
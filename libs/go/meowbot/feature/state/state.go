@@ -11,26 +11,42 @@ type GuildState struct {
 	LastUserID      string
 	HighScore       int
 	HighScoreUserID string
+
+	// Participants are the users who meowed in the current chain. They are
+	// not persisted, so a restart forgets who built a running chain.
+	Participants []string
 }
 
-var (
-	getGuildStreak = func(ctx context.Context, guildID string) (*db.GuildStreak, error) {
-		return db.GetGuildStreak(ctx, db.DB, guildID)
-	}
+// StreakStore loads a guild's persisted streak. db.Store satisfies it.
+type StreakStore interface {
+	GetGuildStreak(ctx context.Context, guildID string) (*db.GuildStreak, error)
+}
 
-	mu    sync.Mutex
-	store = make(map[string]*GuildState)
-)
+// Tracker holds the in-memory state of every guild the bot has seen, loading
+// a guild's streak from the store the first time it is needed.
+type Tracker struct {
+	streaks StreakStore
+
+	mu     sync.Mutex
+	guilds map[string]*GuildState
+}
+
+func NewTracker(streaks StreakStore) *Tracker {
+	return &Tracker{
+		streaks: streaks,
+		guilds:  make(map[string]*GuildState),
+	}
+}
 
-func GetOrCreate(ctx context.Context, guildID string) *GuildState {
-	mu.Lock()
-	defer mu.Unlock()
+func (t *Tracker) GetOrCreate(ctx context.Context, guildID string) *GuildState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 
-	if gs, ok := store[guildID]; ok {
+	if gs, ok := t.guilds[guildID]; ok {
 		return gs
 	}
 
-	dbStreak, err := getGuildStreak(ctx, guildID)
+	dbStreak, err := t.streaks.GetGuildStreak(ctx, guildID)
 	if err != nil {
 		dbStreak = &db.GuildStreak{} // fallback
 	}
@@ -41,16 +57,45 @@ func GetOrCreate(ctx context.Context, guildID string) *GuildState {
 		HighScore:       dbStreak.HighScore,
 		HighScoreUserID: deref(dbStreak.HighScoreUserID),
 	}
-	store[guildID] = gs
+	t.guilds[guildID] = gs
 	return gs
 }
 
-func Reset(guildID string) {
-	mu.Lock()
-	defer mu.Unlock()
-	if gs, ok := store[guildID]; ok {
+func (t *Tracker) Reset(guildID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if gs, ok := t.guilds[guildID]; ok {
 		gs.MeowCount = 0
 		gs.LastUserID = ""
+		gs.Participants = nil
+	}
+}
+
+// Forget drops a guild's state, so it is loaded from the store again the
+// next time it is needed, such as after the guild's data was imported.
+func (t *Tracker) Forget(guildID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.guilds, guildID)
+}
+
+// ReplaceUser puts newID in place of oldID wherever a guild's state names
+// it, such as when a user's data is deleted and a stand-in takes their place.
+func (t *Tracker) ReplaceUser(oldID, newID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, gs := range t.guilds {
+		if gs.LastUserID == oldID {
+			gs.LastUserID = newID
+		}
+		if gs.HighScoreUserID == oldID {
+			gs.HighScoreUserID = newID
+		}
+		for i, id := range gs.Participants {
+			if id == oldID {
+				gs.Participants[i] = newID
+			}
+		}
 	}
 }
 
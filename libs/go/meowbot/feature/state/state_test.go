@@ -17,30 +17,31 @@ func TestDeref(t *testing.T) {
 	assert.Equal(t, "foo", deref(strPtr("foo")))
 }
 
-func TestGetOrCreate_CachesState(t *testing.T) {
-	// ensure fresh store
-	store = make(map[string]*GuildState)
+// streakFunc adapts a function to StreakStore.
+type streakFunc func(ctx context.Context, guildID string) (*db.GuildStreak, error)
+
+func (f streakFunc) GetGuildStreak(ctx context.Context, guildID string) (*db.GuildStreak, error) {
+	return f(ctx, guildID)
+}
 
+func TestGetOrCreate_CachesState(t *testing.T) {
 	// stub out DB call that should never be called
-	getGuildStreak = func(ctx context.Context, guildID string) (*db.GuildStreak, error) {
-		t.Fatal("getGuildStreak should not be called when state already exists")
+	tracker := NewTracker(streakFunc(func(ctx context.Context, guildID string) (*db.GuildStreak, error) {
+		t.Fatal("GetGuildStreak should not be called when state already exists")
 		return nil, nil
-	}
+	}))
 
 	// pre-populate
 	expected := &GuildState{MeowCount: 42, LastUserID: "u", HighScore: 7, HighScoreUserID: "u2"}
-	store["g1"] = expected
+	tracker.guilds["g1"] = expected
 
-	gs := GetOrCreate(context.Background(), "g1")
+	gs := tracker.GetOrCreate(context.Background(), "g1")
 	assert.Same(t, expected, gs)
 }
 
 func TestGetOrCreate_LoadsFromDB(t *testing.T) {
-	// clear store
-	store = make(map[string]*GuildState)
-
 	// stub DB call
-	getGuildStreak = func(_ context.Context, guildID string) (*db.GuildStreak, error) {
+	tracker := NewTracker(streakFunc(func(_ context.Context, guildID string) (*db.GuildStreak, error) {
 		assert.Equal(t, "g2", guildID)
 		return &db.GuildStreak{
 			GuildID:         "g2",
@@ -49,9 +50,9 @@ func TestGetOrCreate_LoadsFromDB(t *testing.T) {
 			HighScore:       10,
 			HighScoreUserID: strPtr("u4"),
 		}, nil
-	}
+	}))
 
-	gs := GetOrCreate(context.Background(), "g2")
+	gs := tracker.GetOrCreate(context.Background(), "g2")
 	assert.NotNil(t, gs)
 	assert.Equal(t, 5, gs.MeowCount)
 	assert.Equal(t, "u3", gs.LastUserID)
@@ -60,13 +61,11 @@ func TestGetOrCreate_LoadsFromDB(t *testing.T) {
 }
 
 func TestGetOrCreate_DBErrorFallsBack(t *testing.T) {
-	store = make(map[string]*GuildState)
-
-	getGuildStreak = func(_ context.Context, guildID string) (*db.GuildStreak, error) {
+	tracker := NewTracker(streakFunc(func(_ context.Context, guildID string) (*db.GuildStreak, error) {
 		return nil, errors.New("boom")
-	}
+	}))
 
-	gs := GetOrCreate(context.Background(), "g3")
+	gs := tracker.GetOrCreate(context.Background(), "g3")
 	assert.NotNil(t, gs)
 	// fallback -> zero values
 	assert.Equal(t, 0, gs.MeowCount)
@@ -74,10 +73,32 @@ func TestGetOrCreate_DBErrorFallsBack(t *testing.T) {
 }
 
 func TestReset(t *testing.T) {
-	store = make(map[string]*GuildState)
-	store["g4"] = &GuildState{MeowCount: 9, LastUserID: "u5"}
-	Reset("g4")
-	gs := store["g4"]
+	tracker := NewTracker(db.NewMemoryStore())
+	tracker.guilds["g4"] = &GuildState{MeowCount: 9, LastUserID: "u5", Participants: []string{"u4", "u5"}}
+	tracker.Reset("g4")
+	gs := tracker.guilds["g4"]
 	assert.Equal(t, 0, gs.MeowCount)
 	assert.Equal(t, "", gs.LastUserID)
+	assert.Empty(t, gs.Participants)
+}
+
+func TestForget(t *testing.T) {
+	loads := 0
+	tracker := NewTracker(streakFunc(func(_ context.Context, guildID string) (*db.GuildStreak, error) {
+		loads++
+		return &db.GuildStreak{GuildID: guildID, MeowCount: loads}, nil
+	}))
+
+	tracker.GetOrCreate(context.Background(), "g5").MeowCount = 7
+	tracker.Forget("g5")
+	assert.Equal(t, 2, tracker.GetOrCreate(context.Background(), "g5").MeowCount)
+}
+
+func TestReplaceUser(t *testing.T) {
+	tracker := NewTracker(db.NewMemoryStore())
+	tracker.guilds["g1"] = &GuildState{MeowCount: 2, LastUserID: "u1", HighScoreUserID: "u1", Participants: []string{"u1", "u2"}}
+	tracker.guilds["g2"] = &GuildState{LastUserID: "u2", HighScoreUserID: "u3"}
+	tracker.ReplaceUser("u1", "anon")
+	assert.Equal(t, &GuildState{MeowCount: 2, LastUserID: "anon", HighScoreUserID: "anon", Participants: []string{"anon", "u2"}}, tracker.guilds["g1"])
+	assert.Equal(t, &GuildState{LastUserID: "u2", HighScoreUserID: "u3"}, tracker.guilds["g2"])
 }
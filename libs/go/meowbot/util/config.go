@@ -3,27 +3,44 @@ package util
 import (
 	"github.com/jba/slog/handlers/loghandler"
 	"log/slog"
+	"net"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 var Cfg = LoadConfig()
 
 type AppConfig struct {
-	Mode             string
-	Debug            bool
-	IsProd           bool
-	BotToken         string
-	ApiPort          string
-	DatabaseURL      string
-	DatabaseUser     string
-	DatabasePassword string
-	DatabaseHost     string
-	DatabasePort     string
-	DatabaseName     string
-	EmojiList        string
-	Logger           *slog.Logger
-	Whitelist        struct {
+	Mode              string
+	Debug             bool
+	IsProd            bool
+	BotToken          string
+	ApiPort           string
+	ApiToken          string
+	DatabaseDriver    string
+	DatabasePath      string
+	DatabaseURL       string
+	DatabaseUser      string
+	DatabasePassword  string
+	DatabaseHost      string
+	DatabasePort      string
+	DatabaseName      string
+	DatabaseSSLMode   string
+	DatabaseSSLRoot   string
+	DatabaseSSLCert   string
+	DatabaseSSLKey    string
+	DatabasePool      DatabasePool
+	DatabaseDeadline  time.Duration
+	AutoMigrate       bool
+	EmojiList         string
+	CommandSyncDryRun bool
+	MemberSync        bool
+	GlobalSeasonDays  int
+	RankCacheMaxAge   time.Duration
+	Logger            *slog.Logger
+	Whitelist         struct {
 		Guilds []string
 	}
 }
@@ -56,26 +73,160 @@ func LoadConfig() AppConfig {
 		guilds = strings.Split(guildsCSV, ",")
 	}
 
+	// DATABASE_SSLMODE is passed to lib/pq. Local databases, such as the
+	// meowbotdb dev image on the default host, usually don't speak TLS;
+	// remote production ones should.
+	databaseHost := getenvDefault("DATABASE_HOST", "127.0.0.1")
+	sslMode := os.Getenv("DATABASE_SSLMODE")
+	if sslMode == "" {
+		sslMode = "disable"
+		if mode == "production" && !isLoopback(databaseHost) {
+			sslMode = "require"
+		}
+	}
+
+	// DATABASE_DRIVER picks the storage backend: "postgres" (the default) or
+	// "sqlite", a single file at DATABASE_PATH.
+	databaseDriver := os.Getenv("DATABASE_DRIVER")
+	if databaseDriver == "" {
+		databaseDriver = "postgres"
+	}
+	databasePath := os.Getenv("DATABASE_PATH")
+	if databasePath == "" {
+		databasePath = "meowbot.db"
+	}
+
+	pool := DatabasePool{
+		MaxOpenConns:    getenvInt(logger, "DATABASE_MAX_OPEN_CONNS", 10),
+		MaxIdleConns:    getenvInt(logger, "DATABASE_MAX_IDLE_CONNS", 5),
+		ConnMaxLifetime: getenvDuration(logger, "DATABASE_CONN_MAX_LIFETIME", 30*time.Minute),
+		ConnMaxIdleTime: getenvDuration(logger, "DATABASE_CONN_MAX_IDLE_TIME", 0),
+	}
+	// DATABASE_CONNECT_TIMEOUT bounds how long startup keeps retrying an
+	// unreachable database.
+	databaseDeadline := getenvDuration(logger, "DATABASE_CONNECT_TIMEOUT", time.Minute)
+
+	// GLOBAL_SEASON_DAYS > 0 makes the bot run back-to-back global seasons of that length.
+	globalSeasonDays, err := strconv.Atoi(os.Getenv("GLOBAL_SEASON_DAYS"))
+	if err != nil || globalSeasonDays < 0 {
+		globalSeasonDays = 0
+	}
+
+	// RANK_CACHE_MAX_AGE is how stale the in-memory global ranking may get
+	// before it is reloaded; 0 turns the cache off.
+	rankCacheMaxAge := getenvDuration(logger, "RANK_CACHE_MAX_AGE", time.Minute)
+
 	return AppConfig{
-		Mode:             mode,
-		Debug:            debug,
-		IsProd:           mode == "production",
-		ApiPort:          apiPort,
-		BotToken:         os.Getenv("DISCORD_BOT_TOKEN"),
-		DatabaseURL:      os.Getenv("DATABASE_URL"),
-		DatabaseUser:     os.Getenv("DATABASE_USER"),
-		DatabasePassword: os.Getenv("DATABASE_PASSWORD"),
-		DatabaseHost:     os.Getenv("DATABASE_HOST"),
-		DatabasePort:     os.Getenv("DATABASE_PORT"),
-		DatabaseName:     os.Getenv("DATABASE_NAME"),
-		EmojiList:        os.Getenv("EMOJI_LIST"),
-		Logger:           logger,
+		Mode:              mode,
+		Debug:             debug,
+		IsProd:            mode == "production",
+		ApiPort:           apiPort,
+		ApiToken:          getSecret(logger, "API_TOKEN"),
+		BotToken:          getSecret(logger, "DISCORD_BOT_TOKEN"),
+		DatabaseDriver:    databaseDriver,
+		DatabasePath:      databasePath,
+		DatabaseURL:       getSecret(logger, "DATABASE_URL"),
+		DatabaseUser:      getenvDefault("DATABASE_USER", "default_user"),
+		DatabasePassword:  getSecretDefault(logger, "DATABASE_PASSWORD", "default_password"),
+		DatabaseHost:      databaseHost,
+		DatabasePort:      getenvDefault("DATABASE_PORT", "5432"),
+		DatabaseName:      getenvDefault("DATABASE_NAME", "meowbot"),
+		DatabaseSSLMode:   sslMode,
+		DatabaseSSLRoot:   os.Getenv("DATABASE_SSLROOTCERT"),
+		DatabaseSSLCert:   os.Getenv("DATABASE_SSLCERT"),
+		DatabaseSSLKey:    os.Getenv("DATABASE_SSLKEY"),
+		DatabasePool:      pool,
+		DatabaseDeadline:  databaseDeadline,
+		AutoMigrate:       os.Getenv("DATABASE_AUTO_MIGRATE") != "false",
+		EmojiList:         os.Getenv("EMOJI_LIST"),
+		CommandSyncDryRun: os.Getenv("COMMAND_SYNC_DRY_RUN") == "true",
+		MemberSync:        os.Getenv("MEMBER_SYNC") == "true",
+		GlobalSeasonDays:  globalSeasonDays,
+		RankCacheMaxAge:   rankCacheMaxAge,
+		Logger:            logger,
 		Whitelist: struct {
 			Guilds []string
 		}{Guilds: guilds},
 	}
 }
 
+// DatabasePool sizes the Postgres connection pool; see the matching
+// sql.DB setters. Zero durations mean connections are kept indefinitely.
+type DatabasePool struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// isLoopback reports whether host names this machine.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func getenvDefault(name, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// getSecret reads a secret from the file named by <name>_FILE, as mounted by
+// Docker or Kubernetes secrets, or else from the variable itself.
+func getSecret(logger *slog.Logger, name string) string {
+	path := os.Getenv(name + "_FILE")
+	if path == "" {
+		return os.Getenv(name)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		logger.Error("❌ Failed to read secret file", "var", name+"_FILE", "error", err)
+		return ""
+	}
+	return strings.TrimRight(string(b), "\r\n")
+}
+
+func getSecretDefault(logger *slog.Logger, name, fallback string) string {
+	if v := getSecret(logger, name); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// getenvInt parses a non-negative integer, keeping fallback if the variable
+// is unset or invalid.
+func getenvInt(logger *slog.Logger, name string, fallback int) int {
+	v := os.Getenv(name)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		logger.Warn("⚠️ Ignoring invalid number", "var", name, "value", v)
+		return fallback
+	}
+	return n
+}
+
+// getenvDuration parses a non-negative duration such as "30m", keeping
+// fallback if the variable is unset or invalid.
+func getenvDuration(logger *slog.Logger, name string, fallback time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		logger.Warn("⚠️ Ignoring invalid duration", "var", name, "value", v)
+		return fallback
+	}
+	return d
+}
+
 func (cfg AppConfig) IsAllowedGuild(guildID string) bool {
 	if cfg.IsProd {
 		return true
@@ -1,7 +1,10 @@
 package util
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestLoadConfig_Defaults(t *testing.T) {
@@ -29,6 +32,129 @@ func TestLoadConfig_Defaults(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_GlobalSeasonDays(t *testing.T) {
+	t.Setenv("GLOBAL_SEASON_DAYS", "90")
+	if cfg := LoadConfig(); cfg.GlobalSeasonDays != 90 {
+		t.Errorf("Expected GlobalSeasonDays=90, got %d", cfg.GlobalSeasonDays)
+	}
+
+	t.Setenv("GLOBAL_SEASON_DAYS", "soon")
+	if cfg := LoadConfig(); cfg.GlobalSeasonDays != 0 {
+		t.Errorf("Expected invalid GLOBAL_SEASON_DAYS to disable global seasons, got %d", cfg.GlobalSeasonDays)
+	}
+}
+
+func TestLoadConfig_AutoMigrate(t *testing.T) {
+	t.Setenv("DATABASE_AUTO_MIGRATE", "")
+	if cfg := LoadConfig(); !cfg.AutoMigrate {
+		t.Error("Expected migrations to run on start by default")
+	}
+
+	t.Setenv("DATABASE_AUTO_MIGRATE", "false")
+	if cfg := LoadConfig(); cfg.AutoMigrate {
+		t.Error("Expected DATABASE_AUTO_MIGRATE=false to disable migrations on start")
+	}
+}
+
+func TestLoadConfig_MemberSync(t *testing.T) {
+	t.Setenv("MEMBER_SYNC", "")
+	if cfg := LoadConfig(); cfg.MemberSync {
+		t.Error("Expected member sync, and its privileged intent, to be off by default")
+	}
+
+	t.Setenv("MEMBER_SYNC", "true")
+	if cfg := LoadConfig(); !cfg.MemberSync {
+		t.Error("Expected MEMBER_SYNC=true to turn member sync on")
+	}
+}
+
+func TestLoadConfig_DatabaseDriver(t *testing.T) {
+	t.Setenv("DATABASE_DRIVER", "")
+	t.Setenv("DATABASE_PATH", "")
+	cfg := LoadConfig()
+	if cfg.DatabaseDriver != "postgres" || cfg.DatabasePath != "meowbot.db" {
+		t.Errorf("Expected postgres driver and meowbot.db path by default, got %s and %s", cfg.DatabaseDriver, cfg.DatabasePath)
+	}
+
+	t.Setenv("DATABASE_DRIVER", "sqlite")
+	t.Setenv("DATABASE_PATH", "/data/meow.db")
+	cfg = LoadConfig()
+	if cfg.DatabaseDriver != "sqlite" || cfg.DatabasePath != "/data/meow.db" {
+		t.Errorf("Expected sqlite driver at /data/meow.db, got %s and %s", cfg.DatabaseDriver, cfg.DatabasePath)
+	}
+}
+
+func TestLoadConfig_DatabaseSettings(t *testing.T) {
+	t.Setenv("MODE", "")
+	t.Setenv("DATABASE_SSLMODE", "")
+	t.Setenv("DATABASE_HOST", "db.internal")
+	t.Setenv("DATABASE_MAX_OPEN_CONNS", "25")
+	t.Setenv("DATABASE_MAX_IDLE_CONNS", "lots")
+	t.Setenv("DATABASE_CONN_MAX_LIFETIME", "1h")
+	t.Setenv("DATABASE_CONNECT_TIMEOUT", "")
+	cfg := LoadConfig()
+
+	if cfg.DatabaseSSLMode != "require" {
+		t.Errorf("Expected sslmode=require in production, got %s", cfg.DatabaseSSLMode)
+	}
+	want := DatabasePool{MaxOpenConns: 25, MaxIdleConns: 5, ConnMaxLifetime: time.Hour}
+	if cfg.DatabasePool != want {
+		t.Errorf("Expected pool %+v, got %+v", want, cfg.DatabasePool)
+	}
+	if cfg.DatabaseDeadline != time.Minute {
+		t.Errorf("Expected a 1m connect timeout by default, got %s", cfg.DatabaseDeadline)
+	}
+
+	t.Setenv("MODE", "development")
+	if cfg := LoadConfig(); cfg.DatabaseSSLMode != "disable" {
+		t.Errorf("Expected sslmode=disable outside production, got %s", cfg.DatabaseSSLMode)
+	}
+
+	// The local dev database has no TLS, so the default host keeps it off.
+	t.Setenv("MODE", "")
+	for _, host := range []string{"", "localhost", "127.0.0.1", "::1"} {
+		t.Setenv("DATABASE_HOST", host)
+		if cfg := LoadConfig(); cfg.DatabaseSSLMode != "disable" {
+			t.Errorf("Expected sslmode=disable for host %q, got %s", host, cfg.DatabaseSSLMode)
+		}
+	}
+	t.Setenv("DATABASE_SSLMODE", "verify-full")
+	if cfg := LoadConfig(); cfg.DatabaseSSLMode != "verify-full" {
+		t.Errorf("Expected DATABASE_SSLMODE to win, got %s", cfg.DatabaseSSLMode)
+	}
+}
+
+func TestLoadConfig_RankCacheMaxAge(t *testing.T) {
+	t.Setenv("RANK_CACHE_MAX_AGE", "")
+	if cfg := LoadConfig(); cfg.RankCacheMaxAge != time.Minute {
+		t.Errorf("Expected a 1m rank cache by default, got %s", cfg.RankCacheMaxAge)
+	}
+
+	t.Setenv("RANK_CACHE_MAX_AGE", "0")
+	if cfg := LoadConfig(); cfg.RankCacheMaxAge != 0 {
+		t.Errorf("Expected RANK_CACHE_MAX_AGE=0 to turn the cache off, got %s", cfg.RankCacheMaxAge)
+	}
+}
+
+func TestLoadConfig_SecretFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(path, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DATABASE_PASSWORD", "from-env")
+	t.Setenv("DATABASE_PASSWORD_FILE", path)
+	t.Setenv("API_TOKEN", "token")
+	t.Setenv("API_TOKEN_FILE", "")
+	cfg := LoadConfig()
+
+	if cfg.DatabasePassword != "s3cret" {
+		t.Errorf("Expected the password from DATABASE_PASSWORD_FILE, got %q", cfg.DatabasePassword)
+	}
+	if cfg.ApiToken != "token" {
+		t.Errorf("Expected API_TOKEN without a _FILE variant, got %q", cfg.ApiToken)
+	}
+}
+
 func TestLoadConfig_WhitelistParsing(t *testing.T) {
 	t.Setenv("WHITELISTED_GUILDS", "123,456,789")
 	cfg := LoadConfig()
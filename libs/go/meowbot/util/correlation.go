@@ -0,0 +1,39 @@
+package util
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+)
+
+type correlationIDKey struct{}
+
+// NewCorrelationID returns a short random ID used to tie together every log
+// line produced while handling a single Discord event.
+func NewCorrelationID() string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// WithCorrelationID returns a copy of ctx carrying the given correlation ID.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the correlation ID stored in ctx, or "" if there is none.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// LoggerFrom returns the app logger annotated with the correlation ID from ctx, if any.
+func LoggerFrom(ctx context.Context) *slog.Logger {
+	if id := CorrelationID(ctx); id != "" {
+		return Cfg.Logger.With("correlationID", id)
+	}
+	return Cfg.Logger
+}
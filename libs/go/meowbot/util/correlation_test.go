@@ -0,0 +1,27 @@
+package util
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCorrelationID(t *testing.T) {
+	if id := CorrelationID(context.Background()); id != "" {
+		t.Errorf("expected empty correlation ID, got %q", id)
+	}
+
+	ctx := WithCorrelationID(context.Background(), "abc123")
+	if id := CorrelationID(ctx); id != "abc123" {
+		t.Errorf("expected abc123, got %q", id)
+	}
+}
+
+func TestNewCorrelationID(t *testing.T) {
+	a, b := NewCorrelationID(), NewCorrelationID()
+	if len(a) != 12 {
+		t.Errorf("expected 12 hex chars, got %q", a)
+	}
+	if a == b {
+		t.Error("expected unique correlation IDs")
+	}
+}